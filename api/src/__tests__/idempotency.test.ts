@@ -0,0 +1,56 @@
+import request from 'supertest';
+import express from 'express';
+import { idempotency } from '../middleware/idempotency';
+
+let created = 0;
+
+const app = express();
+app.use(express.json());
+app.use(idempotency);
+app.post('/things', (req, res) => {
+  created++;
+  res.status(201).json({ id: created, name: req.body.name });
+});
+app.post('/broken', (req, res) => {
+  created++;
+  res.status(500).json({ error: 'Internal server error' });
+});
+
+describe('Idempotency-Key', () => {
+  beforeEach(() => {
+    created = 0;
+  });
+
+  it('replays the first response for a repeated key', async () => {
+    const first = await request(app).post('/things').set('Idempotency-Key', 'k-replay').send({ name: 'a' });
+    const second = await request(app).post('/things').set('Idempotency-Key', 'k-replay').send({ name: 'a' });
+
+    expect(first.status).toBe(201);
+    expect(second.status).toBe(201);
+    expect(second.body).toEqual(first.body);
+    expect(second.headers['idempotent-replayed']).toBe('true');
+    expect(created).toBe(1);
+  });
+
+  it('rejects a key reused for a different request', async () => {
+    await request(app).post('/things').set('Idempotency-Key', 'k-reused').send({ name: 'a' });
+    const response = await request(app).post('/things').set('Idempotency-Key', 'k-reused').send({ name: 'b' });
+
+    expect(response.status).toBe(422);
+    expect(created).toBe(1);
+  });
+
+  it('runs the request again after a server error', async () => {
+    await request(app).post('/broken').set('Idempotency-Key', 'k-broken').send({});
+    await request(app).post('/broken').set('Idempotency-Key', 'k-broken').send({});
+
+    expect(created).toBe(2);
+  });
+
+  it('leaves requests without a key alone', async () => {
+    await request(app).post('/things').send({ name: 'a' });
+    await request(app).post('/things').send({ name: 'a' });
+
+    expect(created).toBe(2);
+  });
+});
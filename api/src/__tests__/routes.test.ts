@@ -1,12 +1,15 @@
 import request from 'supertest';
 import express from 'express';
-import { tasksRouter } from '../routes/tasks';
+import { taskRouter } from '../routes/tasks';
+import { apiKeysRouter, optionalApiKey } from '../routes/apiKeys';
 import { blockchainRouter } from '../routes/blockchain';
 import { healthRouter } from '../routes/health';
+import { wsService } from '../services/websocket';
 
 const app = express();
 app.use(express.json());
-app.use('/api/tasks', tasksRouter);
+app.use('/api/tasks', taskRouter);
+app.use('/api/auth/keys', optionalApiKey, apiKeysRouter);
 app.use('/api/blockchain', blockchainRouter);
 app.use('/health', healthRouter);
 
@@ -33,9 +36,10 @@ describe('Tasks Endpoints', () => {
     it('should create a new task with valid data', async () => {
       const taskData = {
         title: 'Test Task',
-        description: 'Test description',
+        description: 'Test description for a task',
         budget: 10,
         currency: 'USDC',
+        deadline: '2026-12-31T00:00:00.000Z',
         posterId: 'test-agent',
         requiredSkills: ['javascript'],
       };
@@ -86,6 +90,134 @@ describe('Tasks Endpoints', () => {
       expect(response.status).toBe(404);
     });
   });
+
+  describe('POST /api/tasks/:id/cancel', () => {
+    const keyFor = async (agentId: string) => {
+      const response = await request(app)
+        .post('/api/auth/keys')
+        .send({ name: `${agentId} key`, agentId });
+      return response.body.apiKey.key;
+    };
+
+    const postTask = async () => {
+      const response = await request(app).post('/api/tasks').send({
+        title: 'Task to cancel',
+        description: 'Test description for a task',
+        budget: 10,
+        deadline: '2026-12-31T00:00:00.000Z',
+        posterId: 'test-agent',
+        requiredSkills: ['javascript'],
+      });
+      return response.body.task.id;
+    };
+
+    let posterKey: string;
+    let otherKey: string;
+    beforeAll(async () => {
+      posterKey = await keyFor('test-agent');
+      otherKey = await keyFor('someone-else');
+    });
+
+    it('should cancel a posted task', async () => {
+      const id = await postTask();
+      const response = await request(app)
+        .post(`/api/tasks/${id}/cancel`)
+        .set('X-API-Key', posterKey)
+        .send({});
+
+      expect(response.status).toBe(200);
+      expect(response.body.task.status).toBe('cancelled');
+      expect(response.body).toHaveProperty('blockchain');
+    });
+
+    it('should require an API key', async () => {
+      const id = await postTask();
+      const response = await request(app)
+        .post(`/api/tasks/${id}/cancel`)
+        .send({ posterId: 'test-agent' });
+
+      expect(response.status).toBe(401);
+    });
+
+    it('should only let the poster cancel', async () => {
+      const id = await postTask();
+      const response = await request(app)
+        .post(`/api/tasks/${id}/cancel`)
+        .set('X-API-Key', otherKey)
+        .send({ posterId: 'test-agent' });
+
+      expect(response.status).toBe(403);
+    });
+
+    it('should not hand out a second key for an agent', async () => {
+      const response = await request(app)
+        .post('/api/auth/keys')
+        .send({ name: 'stolen', agentId: 'test-agent' });
+
+      expect(response.status).toBe(403);
+    });
+
+    it('should not cancel a task twice', async () => {
+      const id = await postTask();
+      await request(app).post(`/api/tasks/${id}/cancel`).set('X-API-Key', posterKey).send({});
+      const response = await request(app)
+        .post(`/api/tasks/${id}/cancel`)
+        .set('X-API-Key', posterKey)
+        .send({});
+
+      expect(response.status).toBe(400);
+    });
+
+    it('should return 404 for non-existent task', async () => {
+      const response = await request(app)
+        .post('/api/tasks/non-existent-id/cancel')
+        .set('X-API-Key', posterKey)
+        .send({});
+      expect(response.status).toBe(404);
+    });
+  });
+
+  describe('task lifecycle events', () => {
+    afterEach(() => jest.restoreAllMocks());
+
+    it('should broadcast every change to the task list', async () => {
+      const bidPlaced = jest.spyOn(wsService, 'broadcastBidPlaced');
+      const bidAccepted = jest.spyOn(wsService, 'broadcastBidAccepted');
+      const completed = jest.spyOn(wsService, 'broadcastTaskCompleted');
+      const toChannel = jest.spyOn(wsService, 'broadcastToChannel');
+
+      const created = await request(app).post('/api/tasks').send({
+        title: 'Task with events',
+        description: 'Test description for a task',
+        budget: 10,
+        deadline: '2026-12-31T00:00:00.000Z',
+        posterId: 'test-agent',
+        requiredSkills: ['javascript'],
+      });
+      const id = created.body.task.id;
+
+      const bid = await request(app)
+        .post(`/api/tasks/${id}/bid`)
+        .send({ agentId: 'worker', amount: 9 });
+      expect(bidPlaced).toHaveBeenCalledWith(id, bid.body.bid);
+
+      await request(app).post(`/api/tasks/${id}/accept`).send({ bidId: bid.body.bid.id });
+      expect(bidAccepted).toHaveBeenCalledWith(id, expect.objectContaining({ id: bid.body.bid.id }));
+
+      await request(app)
+        .post(`/api/tasks/${id}/complete`)
+        .send({ agentId: 'worker', deliveryUrl: 'https://example.com' });
+      expect(completed).toHaveBeenCalledWith(id, expect.objectContaining({ status: 'completed' }));
+
+      await request(app).post(`/api/tasks/${id}/verify`).send({});
+      expect(toChannel).toHaveBeenCalledWith('tasks:updates', {
+        type: 'status_change',
+        taskId: id,
+        oldStatus: 'completed',
+        newStatus: 'verified',
+      });
+    });
+  });
 });
 
 describe('Blockchain Endpoints', () => {
@@ -0,0 +1,37 @@
+import fs from 'fs';
+import path from 'path';
+import { generateSignature } from '../routes/webhooks';
+
+// Shared with the Go SDK, so that both sides agree on what is signed
+const vectorsPath = path.join(__dirname, '../../../cli/gigclaw/webhook/testdata/signatures.json');
+
+interface SignatureVector {
+  name: string;
+  secret: string;
+  event: string;
+  body: string;
+  signed: 'payload' | 'body';
+  signature: string;
+  valid: boolean;
+}
+
+const { vectors } = JSON.parse(fs.readFileSync(vectorsPath, 'utf8')) as {
+  vectors: SignatureVector[];
+};
+
+describe('Webhook signatures', () => {
+  it.each(vectors.map(v => [v.name, v] as const))('%s', (_name, v) => {
+    const body = JSON.parse(v.body);
+    const signed = v.signed === 'payload' ? body.payload : body;
+
+    // Deliveries are sent as JSON.stringify of the parsed body
+    expect(JSON.stringify(body)).toBe(v.body);
+
+    const signature = generateSignature(signed, v.secret);
+    if (v.valid) {
+      expect(signature).toBe(v.signature);
+    } else {
+      expect(signature).not.toBe(v.signature);
+    }
+  });
+});
@@ -20,11 +20,17 @@ import { disputesRouter } from './routes/disputes';
 import { agentDiscoveryRouter } from './routes/agentDiscovery';
 import { escrowRouter } from './routes/escrow';
 import { taskCategoriesRouter } from './routes/taskCategories';
-import { apiKeysRouter, validateApiKey, createApiKeyRateLimiter } from './routes/apiKeys';
+import {
+  apiKeysRouter,
+  validateApiKey,
+  optionalApiKey,
+  createApiKeyRateLimiter,
+} from './routes/apiKeys';
 import { bulkRouter } from './routes/bulk';
 import { analyticsRouter } from './routes/analytics';
 import { errorHandler, notFoundHandler } from './middleware/errorHandler';
 import { sanitizeInput } from './middleware/validation';
+import { idempotency } from './middleware/idempotency';
 import { startTaskExpiryChecker } from './services/taskExpiry';
 import { wsService } from './services/websocket';
 import http from 'http';
@@ -56,6 +62,9 @@ const limiter = rateLimit({
 });
 app.use(limiter);
 
+// Replay retried writes that carry an Idempotency-Key
+app.use(idempotency);
+
 // Stricter rate limiting for task creation
 const taskCreationLimiter = rateLimit({
   windowMs: 60 * 60 * 1000, // 1 hour
@@ -68,6 +77,8 @@ app.use('/api/tasks', taskCreationLimiter);
 
 // Routes
 app.use('/api/tasks', taskRouter);
+// Before /api/agents, whose /:id would otherwise catch /api/agents/discover
+app.use('/api/agents/discover', agentDiscoveryRouter);
 app.use('/api/agents', agentRouter);
 app.use('/api/matching', matchingRouter);
 app.use('/api/webhooks', webhookRouter);
@@ -80,10 +91,9 @@ app.use('/api/negotiations', negotiationRouter);
 app.use('/api/predictive', predictiveRouter);
 app.use('/api/blockchain', blockchainRouter);
 app.use('/api/disputes', disputesRouter);
-app.use('/api/agents/discover', agentDiscoveryRouter);
 app.use('/api/escrow', escrowRouter);
 app.use('/api/tasks/categories', taskCategoriesRouter);
-app.use('/api/auth/keys', apiKeysRouter);
+app.use('/api/auth/keys', optionalApiKey, apiKeysRouter);
 app.use('/api/bulk', bulkRouter);
 app.use('/api/analytics', analyticsRouter);
 app.use('/health', healthRouter);
@@ -0,0 +1,96 @@
+import { createHash } from 'crypto';
+import { Request, Response, NextFunction } from 'express';
+
+// How long a key's response is kept for replay
+export const IDEMPOTENCY_TTL_MS = 24 * 60 * 60 * 1000;
+
+interface StoredResponse {
+  fingerprint: string;
+  createdAt: number;
+  done: boolean;
+  status?: number;
+  body?: unknown;
+}
+
+const responses = new Map<string, StoredResponse>();
+
+// A key is scoped to the caller, so two clients picking the same key never
+// see each other's responses.
+function storeKey(req: Request, key: string): string {
+  const caller = (req.headers['x-api-key'] as string) || req.ip || 'unknown';
+  return `${caller}:${key}`;
+}
+
+function fingerprint(req: Request): string {
+  return createHash('sha256')
+    .update(`${req.method} ${req.originalUrl} ${JSON.stringify(req.body ?? {})}`)
+    .digest('hex');
+}
+
+function prune(now: number): void {
+  for (const [key, stored] of responses) {
+    if (now - stored.createdAt > IDEMPOTENCY_TTL_MS) {
+      responses.delete(key);
+    }
+  }
+}
+
+// Replays the stored response of a write that carries a known Idempotency-Key,
+// instead of running it twice. Responses are kept for IDEMPOTENCY_TTL_MS.
+// Server errors (5xx) are not kept, so the request can be retried.
+export const idempotency = (req: Request, res: Response, next: NextFunction) => {
+  const key = req.header('Idempotency-Key');
+  if (!key || req.method === 'GET' || req.method === 'HEAD' || req.method === 'OPTIONS') {
+    return next();
+  }
+
+  const now = Date.now();
+  prune(now);
+
+  const id = storeKey(req, key);
+  const print = fingerprint(req);
+  const stored = responses.get(id);
+
+  if (stored) {
+    if (stored.fingerprint !== print) {
+      return res.status(422).json({
+        error: 'Idempotency key reused',
+        message: 'This Idempotency-Key was already used for a different request',
+      });
+    }
+    if (!stored.done) {
+      return res.status(409).json({
+        error: 'Request in progress',
+        message: 'A request with this Idempotency-Key is still being processed',
+      });
+    }
+    res.setHeader('Idempotent-Replayed', 'true');
+    return res.status(stored.status as number).json(stored.body);
+  }
+
+  const entry: StoredResponse = { fingerprint: print, createdAt: now, done: false };
+  responses.set(id, entry);
+
+  const json = res.json.bind(res);
+  res.json = (body: unknown) => {
+    if (res.statusCode >= 500) {
+      responses.delete(id);
+    } else {
+      entry.done = true;
+      entry.status = res.statusCode;
+      entry.body = body;
+    }
+    return json(body);
+  };
+
+  // A handler that answers without JSON must not pin the key in progress. A
+  // client that hangs up early keeps it: the handler is still running and
+  // stores its response when it answers.
+  res.on('finish', () => {
+    if (!entry.done) {
+      responses.delete(id);
+    }
+  });
+
+  next();
+};
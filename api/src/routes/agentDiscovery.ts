@@ -207,6 +207,70 @@ agentDiscoveryRouter.get(
   }
 );
 
+// Get skill categories
+// GET /api/agents/discover/categories
+agentDiscoveryRouter.get('/categories', (req: Request, res: Response) => {
+  const categories = new Map<string, { count: number; topAgents: string[] }>();
+
+  agents.forEach((agent) => {
+    agent.skills.forEach((skill) => {
+      const existing = categories.get(skill.category);
+      if (existing) {
+        existing.count++;
+        if (existing.topAgents.length < 3) {
+          existing.topAgents.push(agent.name);
+        }
+      } else {
+        categories.set(skill.category, {
+          count: 1,
+          topAgents: [agent.name],
+        });
+      }
+    });
+  });
+
+  res.json({
+    categories: Array.from(categories.entries()).map(([name, data]) => ({
+      name,
+      ...data,
+    })),
+  });
+});
+
+// Get discovery statistics
+// GET /api/agents/discover/stats/overview
+agentDiscoveryRouter.get('/stats/overview', (req: Request, res: Response) => {
+  const allAgents = Array.from(agents.values());
+
+  const stats = {
+    totalAgents: allAgents.length,
+    availableNow: allAgents.filter((a) => a.availability.status === 'available')
+      .length,
+    averageRating:
+      allAgents.reduce((sum, a) => sum + a.reputation.rating, 0) /
+        allAgents.length || 0,
+    totalTasksCompleted: allAgents.reduce(
+      (sum, a) => sum + a.stats.totalTasksCompleted,
+      0
+    ),
+    totalEarnings: allAgents.reduce(
+      (sum, a) => sum + a.stats.totalEarnings,
+      0
+    ),
+    topSkills: getTopSkills(allAgents),
+    availabilityBreakdown: {
+      available: allAgents.filter((a) => a.availability.status === 'available')
+        .length,
+      busy: allAgents.filter((a) => a.availability.status === 'busy').length,
+      away: allAgents.filter((a) => a.availability.status === 'away').length,
+      offline: allAgents.filter((a) => a.availability.status === 'offline')
+        .length,
+    },
+  };
+
+  res.json({ stats });
+});
+
 // Get agent profile by ID
 // GET /api/agents/discover/:id
 agentDiscoveryRouter.get(
@@ -441,70 +505,6 @@ function generateMatchReasons(
   return reasons;
 }
 
-// Get skill categories
-// GET /api/agents/discover/categories
-agentDiscoveryRouter.get('/categories', (req: Request, res: Response) => {
-  const categories = new Map<string, { count: number; topAgents: string[] }>();
-
-  agents.forEach((agent) => {
-    agent.skills.forEach((skill) => {
-      const existing = categories.get(skill.category);
-      if (existing) {
-        existing.count++;
-        if (existing.topAgents.length < 3) {
-          existing.topAgents.push(agent.name);
-        }
-      } else {
-        categories.set(skill.category, {
-          count: 1,
-          topAgents: [agent.name],
-        });
-      }
-    });
-  });
-
-  res.json({
-    categories: Array.from(categories.entries()).map(([name, data]) => ({
-      name,
-      ...data,
-    })),
-  });
-});
-
-// Get discovery statistics
-// GET /api/agents/discover/stats/overview
-agentDiscoveryRouter.get('/stats/overview', (req: Request, res: Response) => {
-  const allAgents = Array.from(agents.values());
-
-  const stats = {
-    totalAgents: allAgents.length,
-    availableNow: allAgents.filter((a) => a.availability.status === 'available')
-      .length,
-    averageRating:
-      allAgents.reduce((sum, a) => sum + a.reputation.rating, 0) /
-        allAgents.length || 0,
-    totalTasksCompleted: allAgents.reduce(
-      (sum, a) => sum + a.stats.totalTasksCompleted,
-      0
-    ),
-    totalEarnings: allAgents.reduce(
-      (sum, a) => sum + a.stats.totalEarnings,
-      0
-    ),
-    topSkills: getTopSkills(allAgents),
-    availabilityBreakdown: {
-      available: allAgents.filter((a) => a.availability.status === 'available')
-        .length,
-      busy: allAgents.filter((a) => a.availability.status === 'busy').length,
-      away: allAgents.filter((a) => a.availability.status === 'away').length,
-      offline: allAgents.filter((a) => a.availability.status === 'offline')
-        .length,
-    },
-  };
-
-  res.json({ stats });
-});
-
 function getTopSkills(
   agents: AgentProfile[]
 ): { name: string; count: number; avgLevel: number }[] {
@@ -15,6 +15,7 @@ interface ApiKey {
   key: string;
   name: string;
   userId: string;
+  agentId?: string; // agent the key acts for, set when created for one
   permissions: Permission[];
   rateLimit: RateLimitConfig;
   createdAt: number;
@@ -90,6 +91,32 @@ export function validateApiKey(
   next();
 }
 
+// Middleware that validates an API key when one is sent, for routes that
+// also serve callers without one
+export function optionalApiKey(
+  req: Request,
+  res: Response,
+  next: NextFunction
+): void {
+  if (!req.headers['x-api-key']) {
+    next();
+    return;
+  }
+  validateApiKey(req, res, next);
+}
+
+// Agent the caller's API key acts for, if any. Use after validateApiKey.
+export function callerAgentId(req: Request): string | undefined {
+  return ((req as any).apiKey as ApiKey | undefined)?.agentId;
+}
+
+// Whether an agent already has a usable key
+function agentHasKey(agentId: string): boolean {
+  return Array.from(apiKeys.values()).some(
+    (k) => k.agentId === agentId && k.active && (!k.expiresAt || k.expiresAt > Date.now())
+  );
+}
+
 // Middleware to check permissions
 export function requirePermission(
   resource: string,
@@ -162,10 +189,13 @@ function generateApiKey(): string {
 
 // Create new API key
 // POST /api/auth/keys
+// A key created with agentId acts for that agent. The first key of an agent
+// can be created by anyone; later ones only with one of its keys.
 apiKeysRouter.post(
   '/',
   [
     body('name').isString().isLength({ min: 1, max: 100 }),
+    body('agentId').optional().isString().isLength({ min: 1, max: 100 }),
     body('permissions').isArray().optional(),
     body('rateLimit').optional().isObject(),
     body('expiresInDays').optional().isInt({ min: 1, max: 365 }),
@@ -173,7 +203,23 @@ apiKeysRouter.post(
   ],
   (req: Request, res: Response) => {
     const { name, permissions = [], rateLimit, expiresInDays } = req.body;
-    const userId = (req as any).apiKey?.userId || 'anonymous';
+    const caller = (req as any).apiKey as ApiKey | undefined;
+    let agentId: string | undefined = req.body.agentId;
+
+    if (caller?.agentId) {
+      if (agentId && agentId !== caller.agentId) {
+        return res.status(403).json({
+          error: 'Cannot create a key for another agent',
+        });
+      }
+      agentId = caller.agentId;
+    } else if (agentId && agentHasKey(agentId)) {
+      return res.status(403).json({
+        error: 'Agent already has an API key',
+        message: 'Create more keys for this agent with one of its keys in the X-API-Key header',
+      });
+    }
+    const userId = caller?.userId || agentId || 'anonymous';
 
     const keyValue = generateApiKey();
     const keyId = `key_${Date.now()}_${Math.random()
@@ -185,6 +231,7 @@ apiKeysRouter.post(
       key: keyValue,
       name,
       userId,
+      agentId,
       permissions: permissions.length > 0
         ? permissions
         : [{ resource: '*', actions: ['read', 'write'] }],
@@ -216,6 +263,7 @@ apiKeysRouter.post(
         id: keyId,
         key: keyValue, // Only shown once!
         name,
+        agentId,
         permissions: apiKey.permissions,
         expiresAt: apiKey.expiresAt,
       },
@@ -337,6 +385,7 @@ apiKeysRouter.get('/validate', (req: Request, res: Response) => {
     valid: true,
     keyId: keyData.id,
     userId: keyData.userId,
+    agentId: keyData.agentId,
     permissions: keyData.permissions,
     rateLimit: keyData.rateLimit,
   });
@@ -1,10 +1,12 @@
 import { Router } from 'express';
+import { PublicKey } from '@solana/web3.js';
 import {
   getProgramState,
   getTaskCount,
   PROGRAM_ID,
   NETWORK,
   getConnection,
+  getReputationFromChain,
 } from '../services/solana';
 
 export const blockchainRouter = Router();
@@ -86,3 +88,36 @@ blockchainRouter.get('/verify/:signature', async (req, res) => {
     });
   }
 });
+
+// Get an agent's on-chain reputation account
+blockchainRouter.get('/reputation/:wallet', async (req, res) => {
+  let agent: PublicKey;
+  try {
+    agent = new PublicKey(req.params.wallet);
+  } catch (_e) {
+    return res.status(400).json({ error: 'Invalid wallet address' });
+  }
+
+  try {
+    const reputation = await getReputationFromChain(agent);
+    if (!reputation) {
+      return res.status(404).json({
+        error: 'Reputation account not found',
+        wallet: agent.toBase58(),
+        network: NETWORK,
+      });
+    }
+
+    res.json({
+      ...reputation,
+      rating: reputation.ratingCount > 0 ? reputation.ratingSum / reputation.ratingCount : 0,
+      network: NETWORK,
+      explorer: `https://explorer.solana.com/address/${reputation.account}?cluster=${NETWORK}`,
+    });
+  } catch (error: any) {
+    res.status(500).json({
+      error: 'Failed to get on-chain reputation',
+      message: error.message,
+    });
+  }
+});
@@ -1,6 +1,9 @@
 import { Router, Request, Response } from 'express';
 import { body } from 'express-validator';
 import { validate } from '../middleware/validation';
+import { tasks } from './tasks';
+import { agents } from './agents';
+import { validateApiKey, callerAgentId } from './apiKeys';
 
 export const reputationRouter = Router();
 
@@ -15,10 +18,15 @@ interface AgentReputation {
   bonuses: Map<string, number>; // Bonus multipliers
   penalties: Map<string, number>; // Penalty multipliers
   skillLevels: Map<string, number>; // Per-skill reputation
+  ratingSum: number; // Stars from posters
+  ratingCount: number;
 }
 
 const reputations = new Map<string, AgentReputation>();
 
+// Stars given per task, so that each task is rated once
+const ratings = new Map<string, number>();
+
 // Decay configuration
 const DECAY_CONFIG = {
   baseDecayRate: 0.5, // Lose 0.5 rep per day inactive
@@ -41,6 +49,8 @@ function getReputation(agentId: string): AgentReputation {
       bonuses: new Map(),
       penalties: new Map(),
       skillLevels: new Map(),
+      ratingSum: 0,
+      ratingCount: 0,
     });
   }
   return reputations.get(agentId)!;
@@ -157,6 +167,29 @@ reputationRouter.post(
   }
 );
 
+// Get leaderboard (before /:agentId, which would otherwise catch it)
+reputationRouter.get('/leaderboard', (req, res) => {
+  const allReps = Array.from(reputations.values())
+    .map(rep => ({
+      ...rep,
+      effectiveReputation: calculateEffectiveReputation(rep),
+    }))
+    .sort((a, b) => b.effectiveReputation - a.effectiveReputation)
+    .slice(0, 100);
+
+  res.json({
+    leaderboard: allReps.map((rep, index) => ({
+      rank: index + 1,
+      agentId: rep.agentId,
+      effectiveReputation: rep.effectiveReputation,
+      baseReputation: rep.baseReputation,
+      streakDays: rep.streakDays,
+      rating: rep.ratingCount > 0 ? rep.ratingSum / rep.ratingCount : 0,
+    })),
+    total: reputations.size,
+  });
+});
+
 // Get reputation status
 reputationRouter.get('/:agentId', (req, res) => {
   const rep = getReputation(req.params.agentId);
@@ -177,6 +210,11 @@ reputationRouter.get('/:agentId', (req, res) => {
     daysInactive: inactiveDays,
     decayAmount,
     nextDecayAt: rep.lastActivity + DECAY_CONFIG.gracePeriod,
+    lastActivity: rep.lastActivity,
+    bonuses: Object.fromEntries(rep.bonuses),
+    penalties: Object.fromEntries(rep.penalties),
+    rating: rep.ratingCount > 0 ? rep.ratingSum / rep.ratingCount : 0,
+    ratingCount: rep.ratingCount,
     skillLevels: Object.fromEntries(rep.skillLevels),
   });
 });
@@ -211,27 +249,59 @@ reputationRouter.post(
   }
 );
 
-// Get leaderboard
-reputationRouter.get('/leaderboard', (req, res) => {
-  const allReps = Array.from(reputations.values())
-    .map(rep => ({
-      ...rep,
-      effectiveReputation: calculateEffectiveReputation(rep),
-    }))
-    .sort((a, b) => b.effectiveReputation - a.effectiveReputation)
-    .slice(0, 100);
+// Rate the agent of a verified task (1-5 stars, once per task). Only the
+// poster, with an API key created for it.
+reputationRouter.post(
+  '/rate',
+  validateApiKey,
+  [
+    body('taskId').isString(),
+    body('stars').isInt({ min: 1, max: 5 }),
+    validate,
+  ],
+  (req: Request, res: Response) => {
+    const { taskId, stars } = req.body;
+    const task = tasks.get(taskId);
+    if (!task) {
+      return res.status(404).json({ error: 'Task not found' });
+    }
+    if (task.status !== 'verified') {
+      return res.status(400).json({ error: 'Only verified tasks can be rated' });
+    }
+    if (!task.assignedAgent) {
+      return res.status(400).json({ error: 'Task has no assigned agent' });
+    }
+    if (!task.posterId || callerAgentId(req) !== task.posterId) {
+      return res.status(403).json({
+        error: 'Only the poster can rate the agent',
+        message: 'Use an API key created for the poster (agentId)',
+      });
+    }
+    if (ratings.has(taskId)) {
+      return res.status(409).json({ error: 'Task already rated', stars: ratings.get(taskId) });
+    }
 
-  res.json({
-    leaderboard: allReps.map((rep, index) => ({
-      rank: index + 1,
-      agentId: rep.agentId,
-      effectiveReputation: rep.effectiveReputation,
-      baseReputation: rep.baseReputation,
-      streakDays: rep.streakDays,
-    })),
-    total: reputations.size,
-  });
-});
+    ratings.set(taskId, stars);
+    const rep = getReputation(task.assignedAgent);
+    rep.ratingSum += stars;
+    rep.ratingCount++;
+    const rating = rep.ratingSum / rep.ratingCount;
+
+    const agent = agents.get(task.assignedAgent);
+    if (agent) {
+      agent.reputation.rating = rating;
+    }
+
+    res.json({
+      message: 'Agent rated',
+      agentId: task.assignedAgent,
+      taskId,
+      stars,
+      rating,
+      ratingCount: rep.ratingCount,
+    });
+  }
+);
 
 // Run decay calculation for all agents (cron job endpoint)
 reputationRouter.post('/process-decay', (req, res) => {
@@ -1,9 +1,10 @@
 import { Router, Request, Response, NextFunction } from 'express';
 import { createTaskValidation } from '../middleware/validation';
 import { triggerWebhook } from '../routes/webhooks';
-import { getTasksFromChain, createTaskOnChain } from '../services/solana';
+import { getTasksFromChain, createTaskOnChain, cancelTaskOnChain } from '../services/solana';
 import { wsService } from '../services/websocket';
 import logger from '../utils/logger';
+import { validateApiKey, callerAgentId } from './apiKeys';
 
 // In-memory store (fallback when Solana unavailable)
 const tasks = new Map<string, any>();
@@ -187,6 +188,7 @@ taskRouter.post('/:id/bid', (req, res) => {
   };
 
   task.bids.push(bid);
+  wsService.broadcastBidPlaced(task.id, bid);
 
   res.json({
     message: 'Bid placed',
@@ -212,6 +214,7 @@ taskRouter.post('/:id/accept', (req, res) => {
   task.assignedAgent = bid.agentId;
   task.status = 'in_progress';
   task.acceptedBid = bid;
+  wsService.broadcastBidAccepted(task.id, bid);
 
   res.json({
     message: 'Bid accepted',
@@ -235,6 +238,7 @@ taskRouter.post('/:id/complete', (req, res) => {
   task.status = 'completed';
   task.deliveryUrl = deliveryUrl;
   task.completedAt = Date.now();
+  wsService.broadcastTaskCompleted(task.id, task);
 
   res.json({
     message: 'Task completed',
@@ -249,7 +253,14 @@ taskRouter.post('/:id/verify', (req, res) => {
     return res.status(404).json({ error: 'Task not found' });
   }
 
+  const oldStatus = task.status;
   task.status = 'verified';
+  wsService.broadcastToChannel('tasks:updates', {
+    type: 'status_change',
+    taskId: task.id,
+    oldStatus,
+    newStatus: task.status,
+  });
 
   res.json({
     message: 'Task verified and payment released',
@@ -257,4 +268,70 @@ taskRouter.post('/:id/verify', (req, res) => {
   });
 });
 
+// Cancel task - only the poster, with an API key created for it, and only
+// while it is open for bidding. Escrow is refunded on chain when the task
+// was created there.
+taskRouter.post('/:id/cancel', validateApiKey, async (req, res) => {
+  const task = tasks.get(req.params.id);
+  if (!task) {
+    return res.status(404).json({ error: 'Task not found' });
+  }
+
+  if (!task.posterId || callerAgentId(req) !== task.posterId) {
+    return res.status(403).json({
+      error: 'Only the poster can cancel this task',
+      message: 'Use an API key created for the poster (agentId)',
+    });
+  }
+
+  if (task.status !== 'posted') {
+    return res.status(400).json({
+      error: 'Only tasks open for bidding can be cancelled',
+      status: task.status,
+    });
+  }
+
+  task.status = 'cancelled';
+  task.cancelledAt = Date.now();
+  wsService.broadcastToChannel('tasks:updates', {
+    type: 'status_change',
+    taskId: task.id,
+    oldStatus: 'posted',
+    newStatus: task.status,
+  });
+
+  triggerWebhook('task.cancelled', {
+    taskId: task.id,
+    posterId: task.posterId,
+  });
+
+  let blockchainResult: any = {
+    status: 'skipped',
+    note: 'Task is not on chain',
+  };
+
+  if (task.onChain) {
+    const result = await cancelTaskOnChain(task.id);
+    if (result.success) {
+      blockchainResult = {
+        status: 'confirmed',
+        signature: result.signature,
+        explorer: `https://explorer.solana.com/tx/${result.signature}?cluster=devnet`,
+      };
+    } else {
+      blockchainResult = {
+        status: 'failed',
+        error: result.error,
+        note: 'Task cancelled in API but the on-chain refund failed',
+      };
+    }
+  }
+
+  res.json({
+    message: 'Task cancelled',
+    task,
+    blockchain: blockchainResult,
+  });
+});
+
 export { tasks };
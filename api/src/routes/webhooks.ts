@@ -322,7 +322,9 @@ export async function triggerWebhook(event: WebhookEvent, payload: any, agentId?
   });
 }
 
-function generateSignature(payload: any, secret: string): string {
+// Signature sent as X-GigClaw-Signature. Test vectors shared with the Go SDK
+// live in cli/gigclaw/webhook/testdata/signatures.json.
+export function generateSignature(payload: any, secret: string): string {
   const hmac = crypto.createHmac('sha256', secret);
   hmac.update(JSON.stringify(payload));
   return `sha256=${hmac.digest('hex')}`;
@@ -8,8 +8,9 @@ import {
   TransactionInstruction,
   sendAndConfirmTransaction,
 } from '@solana/web3.js';
-import { getAssociatedTokenAddress } from '@solana/spl-token';
+import { getAssociatedTokenAddress, TOKEN_PROGRAM_ID } from '@solana/spl-token';
 import bs58 from 'bs58';
+import { createHash } from 'crypto';
 import fs from 'fs';
 import path from 'path';
 import os from 'os';
@@ -287,6 +288,116 @@ export async function createTaskOnChain(
   }
 }
 
+// Cancel a posted task on chain, refunding its escrow to the poster. The
+// funded wallet posted the task there, so it signs as the poster.
+export async function cancelTaskOnChain(
+  taskId: string
+): Promise<{ success: boolean; signature?: string; error?: string }> {
+  try {
+    const conn = getConnection();
+    const wallet = getFundedWallet();
+
+    const [taskPDA] = PublicKey.findProgramAddressSync(
+      [Buffer.from('task'), Buffer.from(taskId)],
+      PROGRAM_ID
+    );
+    const [escrowPDA] = PublicKey.findProgramAddressSync(
+      [Buffer.from('escrow'), Buffer.from(taskId)],
+      PROGRAM_ID
+    );
+    const posterTokenAccount = await getAssociatedTokenAddress(USDC_MINT_DEVNET, wallet.publicKey);
+
+    // Anchor discriminator: first 8 bytes of sha256("global:cancel_task")
+    const data = createHash('sha256').update('global:cancel_task').digest().subarray(0, 8);
+
+    const instruction = new TransactionInstruction({
+      keys: [
+        { pubkey: taskPDA, isSigner: false, isWritable: true },
+        { pubkey: escrowPDA, isSigner: false, isWritable: true },
+        { pubkey: posterTokenAccount, isSigner: false, isWritable: true },
+        { pubkey: wallet.publicKey, isSigner: true, isWritable: false },
+        { pubkey: TOKEN_PROGRAM_ID, isSigner: false, isWritable: false },
+      ],
+      programId: PROGRAM_ID,
+      data,
+    });
+
+    const transaction = new Transaction().add(instruction);
+    const { blockhash } = await conn.getLatestBlockhash();
+    transaction.recentBlockhash = blockhash;
+    transaction.feePayer = wallet.publicKey;
+
+    const signature = await sendAndConfirmTransaction(conn, transaction, [wallet], {
+      commitment: 'confirmed',
+      maxRetries: 3,
+    });
+
+    console.log('[Solana] ✅ Task cancelled:', signature);
+
+    return { success: true, signature };
+  } catch (error: any) {
+    console.error('[Solana] ❌ Error cancelling task:', error.message);
+    return { success: false, error: error.message };
+  }
+}
+
+// Reputation account of an agent, decoded from chain
+export interface ChainReputation {
+  agent: string;
+  account: string;
+  completedTasks: number;
+  failedTasks: number;
+  totalEarned: number; // USDC
+  successRate: number; // percent
+  ratingSum: number;
+  ratingCount: number;
+}
+
+function reputationPDA(agent: PublicKey): PublicKey {
+  const [pda] = PublicKey.findProgramAddressSync(
+    [Buffer.from('reputation'), agent.toBuffer()],
+    PROGRAM_ID
+  );
+  return pda;
+}
+
+// Get an agent's reputation account, or null if it was never initialized
+export async function getReputationFromChain(agent: PublicKey): Promise<ChainReputation | null> {
+  const conn = getConnection();
+  const account = reputationPDA(agent);
+  const accountInfo = await conn.getAccountInfo(account);
+  if (!accountInfo) {
+    return null;
+  }
+
+  // Layout: discriminator(8) + agent(32) + completed_tasks(u32) + failed_tasks(u32)
+  // + total_earned(u64) + success_rate(u64) + rating_sum(u64) + rating_count(u32)
+  const data = accountInfo.data;
+  let offset = 8 + 32;
+  const completedTasks = data.readUInt32LE(offset);
+  offset += 4;
+  const failedTasks = data.readUInt32LE(offset);
+  offset += 4;
+  const totalEarned = Number(data.readBigUInt64LE(offset)) / 1e6;
+  offset += 8;
+  const successRate = Number(data.readBigUInt64LE(offset));
+  offset += 8;
+  const ratingSum = Number(data.readBigUInt64LE(offset));
+  offset += 8;
+  const ratingCount = data.readUInt32LE(offset);
+
+  return {
+    agent: agent.toBase58(),
+    account: account.toBase58(),
+    completedTasks,
+    failedTasks,
+    totalEarned,
+    successRate,
+    ratingSum,
+    ratingCount,
+  };
+}
+
 export async function getTaskCount(): Promise<number> {
   const tasks = await getTasksFromChain();
   return tasks.length;
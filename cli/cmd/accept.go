@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
 	"github.com/spf13/cobra"
 )
 
@@ -14,13 +15,15 @@ var acceptCmd = &cobra.Command{
 }
 
 var (
-	acceptBidID string
+	acceptBidID   string
+	acceptIdemKey string
 )
 
 func init() {
 	taskCmd.AddCommand(acceptCmd)
 
 	acceptCmd.Flags().StringVarP(&acceptBidID, "bid", "b", "", "Bid ID to accept (required)")
+	acceptCmd.Flags().StringVar(&acceptIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never accepts twice")
 	acceptCmd.MarkFlagRequired("bid")
 }
 
@@ -36,17 +39,38 @@ func runAccept(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := client.AcceptBid(taskID, acceptBidID); err != nil {
-		return fmt.Errorf("failed to accept bid: %w", err)
+	params := []interface{}{taskID, acceptBidID}
+	res, err := runJournaled("task accept", acceptIdemKey, params, func(key string) (string, error) {
+		return acceptBidID, client.AcceptBid(cmd.Context(), taskID, acceptBidID, gigclaw.IdempotencyKey(key))
+	})
+	if err != nil {
+		return HandleAPIError(err)
 	}
 
-	fmt.Println("✅ Bid accepted!")
-	fmt.Println()
-	fmt.Printf("Task ID: %s\n", taskID)
-	fmt.Printf("Bid ID:  %s\n", acceptBidID)
-	fmt.Println()
-	fmt.Println("Funds are now locked in escrow.")
-	fmt.Println("The agent will be notified to start work.")
+	view := acceptView{
+		TaskID:         taskID,
+		BidID:          acceptBidID,
+		Status:         "accepted",
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Bid already accepted with idempotency key %s\n", res.Key)
+			fmt.Println()
+			fmt.Printf("Task ID: %s\n", taskID)
+			fmt.Printf("Bid ID:  %s\n", acceptBidID)
+			return
+		}
 
-	return nil
+		fmt.Println("✅ Bid accepted!")
+		fmt.Println()
+		fmt.Printf("Task ID: %s\n", taskID)
+		fmt.Printf("Bid ID:  %s\n", acceptBidID)
+		fmt.Println()
+		fmt.Println("Funds are now locked in escrow.")
+		fmt.Println("The agent will be notified to start work.")
+		fmt.Println()
+		fmt.Println("Track the escrow with: gigclaw escrow status " + taskID)
+	})
 }
@@ -0,0 +1,472 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Register and manage your agent identity",
+	Long: `Register an agent with the API and manage its status.
+
+The agent you register is saved as agent-id in the active profile, and
+commands that act as an agent (task bid, task complete, negotiate, dispute,
+escrow, webhook, ...) default to it.`,
+}
+
+var agentRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Register an agent and make it yours",
+	Long: `Register an agent and save its ID as agent-id in the active profile.
+
+The ID defaults to one derived from --name. Registering an ID that already
+exists replaces that agent and resets its track record, so it is refused
+unless --force is given.`,
+	Example: `  gigclaw agent register --name "Rust Auditor" --skill rust,security --wallet 7xKX...9fQ
+  gigclaw --profile devnet agent register --name tester --id agent-dev`,
+	Args: cobra.NoArgs,
+	RunE: runAgentRegister,
+}
+
+var agentShowCmd = &cobra.Command{
+	Use:   "show [agent-id]",
+	Short: "Show an agent (default: your own)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAgentShow,
+}
+
+var agentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered agents",
+	Args:  cobra.NoArgs,
+	RunE:  runAgentList,
+}
+
+var agentStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Manage your agent's availability",
+}
+
+var agentStatusSetCmd = &cobra.Command{
+	Use:   "set <online|busy|offline>",
+	Short: "Set your agent's availability",
+	Long: `Set your agent's availability. Only online agents are matched to new
+tasks; the API calls this status "available".`,
+	Example:   `  gigclaw agent status set busy`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"online", "busy", "offline"},
+	RunE:      runAgentStatusSet,
+}
+
+var (
+	agentRegisterName    string
+	agentRegisterID      string
+	agentRegisterSkills  []string
+	agentRegisterWallet  string
+	agentRegisterForce   bool
+	agentRegisterNoSave  bool
+	agentRegisterIdemKey string
+
+	agentListStatus string
+	agentListSkill  string
+
+	agentStatusAs string
+)
+
+// agentStatuses maps the statuses accepted by agent status set to the API's
+var agentStatuses = map[string]string{
+	"online":               gigclaw.AgentAvailable,
+	gigclaw.AgentAvailable: gigclaw.AgentAvailable,
+	gigclaw.AgentBusy:      gigclaw.AgentBusy,
+	gigclaw.AgentOffline:   gigclaw.AgentOffline,
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+	agentCmd.AddCommand(agentRegisterCmd)
+	agentCmd.AddCommand(agentShowCmd)
+	agentCmd.AddCommand(agentListCmd)
+	agentCmd.AddCommand(agentStatusCmd)
+	agentStatusCmd.AddCommand(agentStatusSetCmd)
+
+	agentRegisterCmd.Flags().StringVarP(&agentRegisterName, "name", "n", "", "Agent name (required)")
+	agentRegisterCmd.Flags().StringVar(&agentRegisterID, "id", "", "Agent ID (default derived from --name)")
+	agentRegisterCmd.Flags().StringSliceVarP(&agentRegisterSkills, "skill", "s", []string{}, "Skills, e.g. rust,security (can specify multiple)")
+	agentRegisterCmd.Flags().StringVar(&agentRegisterWallet, "wallet", "", "Wallet address to be paid to (default wallet from the profile)")
+	agentRegisterCmd.Flags().BoolVar(&agentRegisterForce, "force", false, "Replace an agent already registered with this ID")
+	agentRegisterCmd.Flags().BoolVar(&agentRegisterNoSave, "no-save", false, "Do not save the agent ID in the config file")
+	agentRegisterCmd.Flags().StringVar(&agentRegisterIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never registers twice")
+	agentRegisterCmd.MarkFlagRequired("name")
+
+	agentListCmd.Flags().StringVar(&agentListStatus, "status", "", "Only agents with this status (online, busy, offline)")
+	agentListCmd.Flags().StringVarP(&agentListSkill, "skill", "s", "", "Only agents with this skill")
+
+	agentStatusSetCmd.Flags().StringVar(&agentStatusAs, "as", "", "Agent ID (default agent-id from the config file)")
+}
+
+// agentView is a registered agent
+type agentView struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Skills         []string `json:"skills"`
+	Wallet         string   `json:"wallet"`
+	Status         string   `json:"status"`
+	CompletedTasks int      `json:"completedTasks"`
+	FailedTasks    int      `json:"failedTasks"`
+	SuccessRate    float64  `json:"successRate"`
+	TotalEarned    float64  `json:"totalEarned"`
+	CreatedAt      string   `json:"createdAt"`
+	Mine           bool     `json:"mine"` // the agent-id of the active profile
+}
+
+var agentColumns = []string{"ID", "NAME", "STATUS", "SKILLS", "WALLET", "COMPLETED", "FAILED", "SUCCESS RATE", "EARNED", "CREATED", "MINE"}
+
+func newAgentView(a gigclaw.Agent) agentView {
+	return agentView{
+		ID:             a.ID,
+		Name:           a.Name,
+		Skills:         a.Skills,
+		Wallet:         a.WalletAddress,
+		Status:         a.Status,
+		CompletedTasks: a.Record.CompletedTasks,
+		FailedTasks:    a.Record.FailedTasks,
+		SuccessRate:    a.Record.SuccessRate,
+		TotalEarned:    a.Record.TotalEarned,
+		CreatedAt:      formatTime(a.CreatedAt.Time),
+		Mine:           a.ID != "" && a.ID == viper.GetString("agent-id"),
+	}
+}
+
+func (a agentView) row() []string {
+	return []string{
+		a.ID, a.Name, a.Status, strings.Join(a.Skills, ","), a.Wallet,
+		strconv.Itoa(a.CompletedTasks), strconv.Itoa(a.FailedTasks), formatAmount(a.SuccessRate),
+		formatAmount(a.TotalEarned), a.CreatedAt, strconv.FormatBool(a.Mine),
+	}
+}
+
+func (a agentView) columns() []string { return agentColumns }
+func (a agentView) rows() [][]string  { return [][]string{a.row()} }
+
+// agentListView is a list of agents
+type agentListView []agentView
+
+func (l agentListView) columns() []string { return agentColumns }
+
+func (l agentListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, a := range l {
+		rows = append(rows, a.row())
+	}
+	return rows
+}
+
+// agentRegisterView is the result of agent register
+type agentRegisterView struct {
+	agentView
+	Saved          bool   `json:"saved"` // saved as agent-id
+	Profile        string `json:"profile"`
+	IdempotencyKey string `json:"idempotencyKey"`
+	Replayed       bool   `json:"replayed"`
+}
+
+func (r agentRegisterView) columns() []string {
+	return append(agentColumns, "SAVED", "PROFILE", "IDEMPOTENCY KEY", "REPLAYED")
+}
+
+func (r agentRegisterView) rows() [][]string {
+	return [][]string{append(r.row(), strconv.FormatBool(r.Saved), r.Profile, r.IdempotencyKey, strconv.FormatBool(r.Replayed))}
+}
+
+// agentIDSlug matches runs of characters left out of derived agent IDs
+var agentIDSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// newAgentID derives an agent ID from a name, with a random suffix so that
+// agents with the same name do not replace each other
+func newAgentID(name string) (string, error) {
+	slug := strings.Trim(agentIDSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(slug) > 24 {
+		slug = strings.TrimRight(slug[:24], "-")
+	}
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	if slug != "" {
+		slug += "-"
+	}
+	return "agent-" + slug + hex.EncodeToString(suffix), nil
+}
+
+func runAgentRegister(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(agentRegisterName)
+	if name == "" {
+		return fmt.Errorf("--name must not be empty")
+	}
+	var skills []string
+	for _, s := range agentRegisterSkills {
+		if s = strings.TrimSpace(s); s != "" {
+			skills = append(skills, s)
+		}
+	}
+	wallet := firstNonEmpty(agentRegisterWallet, viper.GetString("wallet"))
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	// A derived ID has a random suffix, so only an explicit one can collide
+	if agentRegisterID != "" && !agentRegisterForce {
+		existing, err := client.GetAgent(cmd.Context(), agentRegisterID)
+		if err == nil {
+			return friendlyError(nil,
+				fmt.Sprintf("Agent %s is already registered", agentRegisterID),
+				[]string{fmt.Sprintf("Name: %s, %d tasks completed", existing.Name, existing.Record.CompletedTasks)},
+				"Registering it again resets its track record: add --force to do so anyway",
+				"Or pick another ID with --id")
+		}
+		if !errors.Is(err, gigclaw.ErrNotFound) {
+			return HandleAPIError(err)
+		}
+	}
+
+	// The journal fingerprint leaves out a derived ID, so that a re-run
+	// finds the agent registered before rather than a new random ID
+	params := []interface{}{name, skills, wallet, agentRegisterID}
+	var agent *gigclaw.Agent
+	res, err := runJournaled("agent register", agentRegisterIdemKey, params, func(key string) (string, error) {
+		id := agentRegisterID
+		if id == "" {
+			var err error
+			if id, err = newAgentID(name); err != nil {
+				return "", err
+			}
+		}
+		var err error
+		agent, err = client.RegisterAgent(cmd.Context(), gigclaw.RegisterAgentRequest{
+			AgentID:       id,
+			Name:          name,
+			Skills:        skills,
+			WalletAddress: wallet,
+		}, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return agent.ID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := agentRegisterView{
+		agentView:      agentView{ID: res.Result, Name: name, Skills: skills, Wallet: wallet},
+		Profile:        activeProfile(),
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	if agent != nil {
+		view.agentView = newAgentView(*agent)
+	}
+
+	previous := viper.GetString("agent-id")
+	if !agentRegisterNoSave {
+		if _, err := updateConfig(map[string]interface{}{"agent-id": view.ID}); err != nil {
+			logger.Warning(fmt.Sprintf("agent registered but not saved: %v", err))
+		} else {
+			view.Saved = true
+			view.Mine = true
+		}
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if view.Replayed {
+			colorWarning.Printf("  Agent already registered with idempotency key %s\n", view.IdempotencyKey)
+		} else {
+			colorSuccess.Println("  ✓ Agent registered")
+		}
+		fmt.Println()
+		printAgent(view.agentView)
+		fmt.Println()
+		switch {
+		case view.Saved && previous != "" && previous != view.ID:
+			colorSuccess.Printf("  Saved as agent-id of profile %s (was %s)\n", view.Profile, previous)
+		case view.Saved:
+			colorSuccess.Printf("  Saved as agent-id of profile %s\n", view.Profile)
+		case agentRegisterNoSave:
+			colorDim.Printf("  Not saved; act as it with --as %s\n", view.ID)
+		default:
+			colorWarning.Printf("  Not saved; act as it with --as %s\n", view.ID)
+		}
+		if wallet == "" {
+			colorDim.Println("  No wallet set: register again with --force --wallet <address> to be paid on-chain")
+		}
+		fmt.Println()
+	})
+}
+
+func runAgentShow(cmd *cobra.Command, args []string) error {
+	var flag string
+	if len(args) > 0 {
+		flag = args[0]
+	}
+	agentID, err := actingAgent(flag, "an agent ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	agent, err := client.GetAgent(cmd.Context(), agentID)
+	if errors.Is(err, gigclaw.ErrNotFound) && len(args) == 0 {
+		return friendlyError(err,
+			fmt.Sprintf("Your agent %s is not registered", agentID),
+			[]string{"API: " + client.BaseURL()},
+			"The API keeps agents in memory: register it again after a restart with gigclaw agent register --force --id "+agentID)
+	}
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := newAgentView(*agent)
+	return render(view, func() {
+		fmt.Println()
+		printAgent(view)
+		fmt.Println()
+	})
+}
+
+func runAgentList(cmd *cobra.Command, args []string) error {
+	status := agentListStatus
+	if status != "" {
+		var ok bool
+		if status, ok = agentStatuses[strings.ToLower(status)]; !ok {
+			return fmt.Errorf("invalid --status %q: must be online, busy or offline", agentListStatus)
+		}
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	agents, err := client.ListAgents(cmd.Context())
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := agentListView{}
+	for _, a := range agents {
+		if status != "" && a.Status != status {
+			continue
+		}
+		if agentListSkill != "" && !containsFold(a.Skills, agentListSkill) {
+			continue
+		}
+		view = append(view, newAgentView(a))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view) == 0 {
+			colorWarning.Println("  No agents found.")
+			fmt.Println()
+			colorDim.Println("  Register yours: gigclaw agent register --name <name>")
+			fmt.Println()
+			return
+		}
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("ID")+"\t"+bold.Sprint("NAME")+"\t"+bold.Sprint("STATUS")+"\t"+
+			bold.Sprint("SKILLS")+"\t"+bold.Sprint("DONE")+"\t"+bold.Sprint("SUCCESS"))
+		for _, a := range view {
+			name := a.Name
+			if a.Mine {
+				name += " (you)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				colorDim.Sprint(a.ID),
+				colorHighlight.Sprint(truncate(name, 30)),
+				formatStatus(a.Status),
+				colorValue.Sprint(truncate(strings.Join(a.Skills, ", "), 30)),
+				a.CompletedTasks,
+				colorValue.Sprintf("%.0f%%", a.SuccessRate),
+			)
+		}
+		w.Flush()
+		fmt.Println()
+	})
+}
+
+func runAgentStatusSet(cmd *cobra.Command, args []string) error {
+	status, ok := agentStatuses[strings.ToLower(args[0])]
+	if !ok {
+		return fmt.Errorf("invalid status %q: must be online, busy or offline", args[0])
+	}
+	agentID, err := actingAgent(agentStatusAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	agent, err := client.SetAgentStatus(cmd.Context(), agentID, status)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := newAgentView(*agent)
+	return render(view, func() {
+		fmt.Println()
+		colorSuccess.Printf("  ✓ %s is now ", firstNonEmpty(agent.Name, agent.ID))
+		fmt.Println(formatStatus(agent.Status))
+		if agent.Status != gigclaw.AgentAvailable {
+			colorDim.Println("  Not matched to new tasks until set online again.")
+		}
+		fmt.Println()
+	})
+}
+
+// printAgent prints the details of an agent
+func printAgent(a agentView) {
+	colorLabel.Printf("  %-15s ", "Agent ID:")
+	colorHighlight.Println(a.ID)
+	colorLabel.Printf("  %-15s ", "Name:")
+	colorValue.Println(a.Name)
+	if a.Status != "" {
+		colorLabel.Printf("  %-15s ", "Status:")
+		fmt.Println(formatStatus(a.Status))
+	}
+	colorLabel.Printf("  %-15s ", "Skills:")
+	colorValue.Println(firstNonEmpty(strings.Join(a.Skills, ", "), "-"))
+	colorLabel.Printf("  %-15s ", "Wallet:")
+	colorValue.Println(firstNonEmpty(a.Wallet, "-"))
+	colorLabel.Printf("  %-15s ", "Tasks:")
+	colorValue.Printf("%d completed, %d failed (%.0f%% success)\n", a.CompletedTasks, a.FailedTasks, a.SuccessRate)
+	colorLabel.Printf("  %-15s ", "Earned:")
+	colorValue.Println(formatAmount(a.TotalEarned))
+	if a.CreatedAt != "" {
+		colorLabel.Printf("  %-15s ", "Registered:")
+		colorValue.Println(a.CreatedAt)
+	}
+}
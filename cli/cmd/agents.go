@@ -0,0 +1,563 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "Find agents to hire",
+	Long: `Search, compare and get recommendations from the agents' public
+discovery profiles: their skills, reputation, rates and availability.`,
+}
+
+var agentsSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search agents by skill, reputation and availability",
+	Example: `  gigclaw agents search --skill rust --skill solana --min-reputation 80 --available
+  gigclaw agents search --category security --max-rate 50 --sort rating`,
+	Args: cobra.NoArgs,
+	RunE: runAgentsSearch,
+}
+
+var agentsCompareCmd = &cobra.Command{
+	Use:     "compare <agent-id> <agent-id>...",
+	Short:   "Compare agents side by side",
+	Example: `  gigclaw agents compare agent-7 agent-12 agent-31`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runAgentsCompare,
+}
+
+var agentsTopCmd = &cobra.Command{
+	Use:     "top <category>",
+	Short:   "Show the best-reputed agents in a skill category",
+	Example: `  gigclaw agents top security --limit 5`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runAgentsTop,
+}
+
+var agentsRecommendCmd = &cobra.Command{
+	Use:   "recommend",
+	Short: "Recommend agents for a task",
+	Long: `Recommend the agents that fit a task best, scored on matching skills,
+reputation, availability and success rate. The task's tags are the skills
+looked for, and its budget favours agents whose hourly rate is within it.`,
+	Example: `  gigclaw agents recommend --task 123
+  gigclaw agents recommend --task 123 --skill anchor --urgency high`,
+	Args: cobra.NoArgs,
+	RunE: runAgentsRecommend,
+}
+
+var (
+	searchSkills        []string
+	searchMinReputation int
+	searchMinRating     float64
+	searchAvailable     bool
+	searchAvailability  string
+	searchMaxRate       float64
+	searchCategory      string
+	searchSort          string
+	searchLimit         int
+	searchOffset        int
+
+	topLimit int
+
+	recommendTask    string
+	recommendSkills  []string
+	recommendUrgency string
+	recommendLimit   int
+)
+
+func init() {
+	rootCmd.AddCommand(agentsCmd)
+	agentsCmd.AddCommand(agentsSearchCmd)
+	agentsCmd.AddCommand(agentsCompareCmd)
+	agentsCmd.AddCommand(agentsTopCmd)
+	agentsCmd.AddCommand(agentsRecommendCmd)
+
+	agentsSearchCmd.Flags().StringSliceVarP(&searchSkills, "skill", "s", []string{}, "Agents with any of these skills or categories (can specify multiple)")
+	agentsSearchCmd.Flags().IntVar(&searchMinReputation, "min-reputation", 0, "Minimum reputation score (0-100)")
+	agentsSearchCmd.Flags().Float64Var(&searchMinRating, "min-rating", 0, "Minimum average rating (1-5)")
+	agentsSearchCmd.Flags().BoolVar(&searchAvailable, "available", false, "Only agents available now")
+	agentsSearchCmd.Flags().StringVar(&searchAvailability, "availability", "", "Only agents that are available, busy, away or offline")
+	agentsSearchCmd.Flags().Float64Var(&searchMaxRate, "max-rate", 0, "Maximum hourly rate")
+	agentsSearchCmd.Flags().StringVar(&searchCategory, "category", "", "Only agents with a skill in this category")
+	agentsSearchCmd.Flags().StringVar(&searchSort, "sort", gigclaw.SortReputation, "Sort by reputation, rating, earnings, tasks or recent")
+	agentsSearchCmd.Flags().IntVarP(&searchLimit, "limit", "l", 20, "Maximum agents to show (1-100)")
+	agentsSearchCmd.Flags().IntVar(&searchOffset, "offset", 0, "Skip this many agents")
+	agentsSearchCmd.MarkFlagsMutuallyExclusive("available", "availability")
+
+	agentsTopCmd.Flags().IntVarP(&topLimit, "limit", "l", 10, "Maximum agents to show (1-50)")
+
+	agentsRecommendCmd.Flags().StringVarP(&recommendTask, "task", "t", "", "Task to recommend agents for (required)")
+	agentsRecommendCmd.Flags().StringSliceVarP(&recommendSkills, "skill", "s", []string{}, "Skills to look for (default the task's tags)")
+	agentsRecommendCmd.Flags().StringVar(&recommendUrgency, "urgency", "", "How urgent the task is: low, medium or high (default medium)")
+	agentsRecommendCmd.Flags().IntVarP(&recommendLimit, "limit", "l", 5, "Maximum agents to recommend (1-20)")
+	agentsRecommendCmd.MarkFlagRequired("task")
+}
+
+// agentProfileView is an agent's discovery profile
+type agentProfileView struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Availability   string   `json:"availability"`
+	Skills         []string `json:"skills"`
+	Reputation     float64  `json:"reputation"`
+	Rating         float64  `json:"rating"`
+	Reviews        int      `json:"reviews"`
+	CompletedTasks int      `json:"completedTasks"`
+	SuccessRate    float64  `json:"successRate"`
+	OnTimeDelivery float64  `json:"onTimeDelivery"`
+	HourlyRate     float64  `json:"hourlyRate"` // 0 when not stated
+	Currency       string   `json:"currency"`
+	Negotiable     bool     `json:"negotiable"`
+	ResponseTime   string   `json:"responseTime"`
+}
+
+var agentProfileColumns = []string{"ID", "NAME", "AVAILABILITY", "SKILLS", "REPUTATION", "RATING", "REVIEWS", "COMPLETED", "SUCCESS RATE", "ON TIME", "HOURLY RATE", "CURRENCY", "NEGOTIABLE", "RESPONSE TIME"}
+
+func newAgentProfileView(p gigclaw.AgentProfile) agentProfileView {
+	return agentProfileView{
+		ID:             p.ID,
+		Name:           p.Name,
+		Availability:   p.Availability.Status,
+		Skills:         p.SkillNames(),
+		Reputation:     p.Reputation.Score,
+		Rating:         p.Reputation.Rating,
+		Reviews:        p.Reputation.ReviewCount,
+		CompletedTasks: p.Stats.TotalTasksCompleted,
+		SuccessRate:    p.Reputation.SuccessRate,
+		OnTimeDelivery: p.Stats.OnTimeDelivery,
+		HourlyRate:     p.Rates.HourlyRate,
+		Currency:       p.Rates.Currency,
+		Negotiable:     p.Rates.Negotiable,
+		ResponseTime:   p.Availability.TypicalResponseTime,
+	}
+}
+
+func (a agentProfileView) row() []string {
+	return []string{
+		a.ID, a.Name, a.Availability, strings.Join(a.Skills, ","), formatAmount(a.Reputation),
+		formatAmount(a.Rating), strconv.Itoa(a.Reviews), strconv.Itoa(a.CompletedTasks), formatAmount(a.SuccessRate),
+		formatAmount(a.OnTimeDelivery), formatAmount(a.HourlyRate), a.Currency, strconv.FormatBool(a.Negotiable), a.ResponseTime,
+	}
+}
+
+// rate is the hourly rate for display, "-" when not stated
+func (a agentProfileView) rate() string {
+	if a.HourlyRate == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s %s/h", formatAmount(a.HourlyRate), a.Currency)
+}
+
+// agentProfileListView is a list of discovery profiles
+type agentProfileListView []agentProfileView
+
+func (l agentProfileListView) columns() []string { return agentProfileColumns }
+
+func (l agentProfileListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, a := range l {
+		rows = append(rows, a.row())
+	}
+	return rows
+}
+
+// agentSearchView is one page of search results
+type agentSearchView struct {
+	Agents  agentProfileListView `json:"agents"`
+	Total   int                  `json:"total"`
+	Offset  int                  `json:"offset"`
+	HasMore bool                 `json:"hasMore"`
+}
+
+func (s agentSearchView) columns() []string { return agentProfileColumns }
+func (s agentSearchView) rows() [][]string  { return s.Agents.rows() }
+
+// agentComparisonView compares agents side by side
+type agentComparisonView struct {
+	Agents          agentProfileListView `json:"agents"`
+	HighestRated    string               `json:"highestRated"`
+	MostExperienced string               `json:"mostExperienced"`
+	MostAffordable  string               `json:"mostAffordable"` // empty when no agent states a rate
+	BestSuccessRate string               `json:"bestSuccessRate"`
+	NotFound        []string             `json:"notFound"`
+}
+
+func (c agentComparisonView) columns() []string {
+	return append(agentProfileColumns, "BEST")
+}
+
+func (c agentComparisonView) rows() [][]string {
+	rows := make([][]string, 0, len(c.Agents))
+	for _, a := range c.Agents {
+		rows = append(rows, append(a.row(), strings.Join(c.best(a.ID), ",")))
+	}
+	return rows
+}
+
+// best lists what an agent compares best on
+func (c agentComparisonView) best(id string) []string {
+	var best []string
+	for _, b := range []struct{ id, what string }{
+		{c.HighestRated, "rating"},
+		{c.MostExperienced, "experience"},
+		{c.MostAffordable, "rate"},
+		{c.BestSuccessRate, "success rate"},
+	} {
+		if b.id == id {
+			best = append(best, b.what)
+		}
+	}
+	return best
+}
+
+// agentRecommendationView is an agent recommended for a task
+type agentRecommendationView struct {
+	agentProfileView
+	MatchScore   int      `json:"matchScore"`
+	MatchReasons []string `json:"matchReasons"`
+}
+
+// agentRecommendationsView is the agents recommended for a task
+type agentRecommendationsView struct {
+	TaskID          string                    `json:"taskId"`
+	Skills          []string                  `json:"skills"`
+	Budget          float64                   `json:"budget"`
+	Currency        string                    `json:"currency"`
+	Recommendations []agentRecommendationView `json:"recommendations"`
+}
+
+func (r agentRecommendationsView) columns() []string {
+	return append(agentProfileColumns, "MATCH SCORE", "MATCH REASONS")
+}
+
+func (r agentRecommendationsView) rows() [][]string {
+	rows := make([][]string, 0, len(r.Recommendations))
+	for _, rec := range r.Recommendations {
+		rows = append(rows, append(rec.row(), strconv.Itoa(rec.MatchScore), strings.Join(rec.MatchReasons, "; ")))
+	}
+	return rows
+}
+
+func runAgentsSearch(cmd *cobra.Command, args []string) error {
+	opts := gigclaw.SearchAgentsOptions{
+		MinReputation: searchMinReputation,
+		MinRating:     searchMinRating,
+		Availability:  strings.ToLower(searchAvailability),
+		MaxRate:       searchMaxRate,
+		Category:      searchCategory,
+		Sort:          strings.ToLower(searchSort),
+		Limit:         searchLimit,
+		Offset:        searchOffset,
+	}
+	for _, s := range searchSkills {
+		if s = strings.TrimSpace(s); s != "" {
+			opts.Skills = append(opts.Skills, s)
+		}
+	}
+	if searchAvailable {
+		opts.Availability = gigclaw.AgentAvailable
+	}
+	if opts.Limit < 1 || opts.Limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	result, err := client.SearchAgents(cmd.Context(), opts)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := agentSearchView{
+		Agents:  agentProfileListView{},
+		Total:   result.Pagination.Total,
+		Offset:  result.Pagination.Offset,
+		HasMore: result.Pagination.HasMore,
+	}
+	for _, p := range result.Agents {
+		view.Agents = append(view.Agents, newAgentProfileView(p))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view.Agents) == 0 {
+			colorWarning.Println("  No agents match.")
+			fmt.Println()
+			colorDim.Println("  Try fewer filters, or a skill category: gigclaw agents top <category>")
+			fmt.Println()
+			return
+		}
+		printAgentProfiles(view.Agents)
+		fmt.Println()
+		colorDim.Printf("  Showing %d-%d of %d agents", view.Offset+1, view.Offset+len(view.Agents), view.Total)
+		if view.HasMore {
+			colorDim.Printf("; next page: --offset %d", view.Offset+len(view.Agents))
+		}
+		fmt.Println()
+		fmt.Println()
+	})
+}
+
+func runAgentsCompare(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	comparison, err := client.CompareAgents(cmd.Context(), args)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := agentComparisonView{
+		Agents:          agentProfileListView{},
+		HighestRated:    comparison.Best.HighestRated.ID,
+		MostExperienced: comparison.Best.MostExperienced.ID,
+		BestSuccessRate: comparison.Best.BestSuccessRate.ID,
+		NotFound:        []string{},
+	}
+	// The API picks an agent as most affordable even when none states a rate
+	if comparison.Best.MostAffordable.Rates.HourlyRate > 0 {
+		view.MostAffordable = comparison.Best.MostAffordable.ID
+	}
+	found := make(map[string]bool)
+	for _, p := range comparison.Agents {
+		view.Agents = append(view.Agents, newAgentProfileView(p))
+		found[p.ID] = true
+	}
+	for _, id := range args {
+		if !found[id] {
+			view.NotFound = append(view.NotFound, id)
+		}
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		for _, id := range view.NotFound {
+			colorWarning.Printf("  Agent %s not found; left out\n", id)
+		}
+		if len(view.NotFound) > 0 {
+			fmt.Println()
+		}
+
+		// The table is left plain: colouring single cells would throw the
+		// tabwriter's alignment off
+		mark := func(id, winner, s string) string {
+			if id == winner {
+				return s + " ★"
+			}
+			return s
+		}
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		line := func(label string, cell func(a agentProfileView) string) {
+			cells := []string{"  " + label}
+			for _, a := range view.Agents {
+				cells = append(cells, cell(a))
+			}
+			fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+		line("", func(a agentProfileView) string { return a.ID })
+		line("Name", func(a agentProfileView) string { return truncate(a.Name, 24) })
+		line("Availability", func(a agentProfileView) string { return firstNonEmpty(a.Availability, "-") })
+		line("Response time", func(a agentProfileView) string { return firstNonEmpty(a.ResponseTime, "-") })
+		line("Reputation", func(a agentProfileView) string { return formatAmount(a.Reputation) + "/100" })
+		line("Rating", func(a agentProfileView) string {
+			return mark(a.ID, view.HighestRated, fmt.Sprintf("%.1f (%d reviews)", a.Rating, a.Reviews))
+		})
+		line("Completed", func(a agentProfileView) string {
+			return mark(a.ID, view.MostExperienced, strconv.Itoa(a.CompletedTasks))
+		})
+		line("Success rate", func(a agentProfileView) string {
+			return mark(a.ID, view.BestSuccessRate, formatAmount(a.SuccessRate)+"%")
+		})
+		line("On time", func(a agentProfileView) string { return formatAmount(a.OnTimeDelivery) + "%" })
+		line("Hourly rate", func(a agentProfileView) string { return mark(a.ID, view.MostAffordable, a.rate()) })
+		line("Negotiable", func(a agentProfileView) string {
+			if a.Negotiable {
+				return "yes"
+			}
+			return "no"
+		})
+		line("Skills", func(a agentProfileView) string { return truncate(strings.Join(a.Skills, ", "), 24) })
+		w.Flush()
+		fmt.Println()
+		colorDim.Println("  ★ best of the agents compared")
+		fmt.Println()
+	})
+}
+
+func runAgentsTop(cmd *cobra.Command, args []string) error {
+	category := args[0]
+	if topLimit < 1 || topLimit > 50 {
+		return fmt.Errorf("--limit must be between 1 and 50")
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	agents, err := client.TopAgents(cmd.Context(), category, topLimit)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := agentProfileListView{}
+	for _, p := range agents {
+		view = append(view, newAgentProfileView(p))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view) == 0 {
+			colorWarning.Printf("  No agents with skills in %s.\n", category)
+			// Suggest the categories there are; without them, say nothing more
+			if categories, err := client.ListAgentCategories(cmd.Context()); err == nil && len(categories) > 0 {
+				names := make([]string, 0, len(categories))
+				for _, c := range categories {
+					names = append(names, c.Name)
+				}
+				fmt.Println()
+				colorDim.Printf("  Categories: %s\n", strings.Join(names, ", "))
+			}
+			fmt.Println()
+			return
+		}
+		colorPrimary.Printf("  Top agents in %s\n", category)
+		fmt.Println()
+		printAgentProfiles(view)
+		fmt.Println()
+	})
+}
+
+func runAgentsRecommend(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), recommendTask)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	skills := make([]string, 0, len(recommendSkills))
+	for _, s := range recommendSkills {
+		if s = strings.TrimSpace(s); s != "" {
+			skills = append(skills, s)
+		}
+	}
+	if len(skills) == 0 {
+		skills = task.Skills()
+	}
+	if len(skills) == 0 {
+		return friendlyError(nil,
+			fmt.Sprintf("Task %s has no skills or tags to match agents' skills on", task.ID),
+			nil,
+			"Name the skills: gigclaw agents recommend --task "+task.ID+" --skill rust --skill security")
+	}
+	if recommendLimit < 1 || recommendLimit > 20 {
+		return fmt.Errorf("--limit must be between 1 and 20")
+	}
+
+	recs, err := client.RecommendAgents(cmd.Context(), gigclaw.RecommendAgentsRequest{
+		Skills:  skills,
+		Budget:  task.Budget,
+		Urgency: strings.ToLower(recommendUrgency),
+		Limit:   recommendLimit,
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := agentRecommendationsView{
+		TaskID:          task.ID,
+		Skills:          skills,
+		Budget:          task.Budget,
+		Currency:        task.Currency,
+		Recommendations: []agentRecommendationView{},
+	}
+	for _, r := range recs {
+		view.Recommendations = append(view.Recommendations, agentRecommendationView{
+			agentProfileView: newAgentProfileView(r.Agent),
+			MatchScore:       r.MatchScore,
+			MatchReasons:     r.MatchReasons,
+		})
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		colorPrimary.Printf("  Agents for %s\n", truncate(task.Title, 50))
+		colorDim.Printf("  Skills: %s · Budget: %s %s\n", strings.Join(skills, ", "), formatAmount(task.Budget), task.Currency)
+		fmt.Println()
+		if len(view.Recommendations) == 0 {
+			colorWarning.Println("  No agents to recommend.")
+			fmt.Println()
+			return
+		}
+		for i, r := range view.Recommendations {
+			fmt.Printf("  %d. ", i+1)
+			colorHighlight.Print(r.Name)
+			colorDim.Printf(" (%s)  ", r.ID)
+			colorSuccess.Printf("%d%% match\n", r.MatchScore)
+			fmt.Printf("     %s · %s/100 reputation · %.1f rating · %s\n",
+				formatStatus(r.Availability), formatAmount(r.Reputation), r.Rating, r.rate())
+			for _, reason := range r.MatchReasons {
+				colorDim.Printf("     • %s\n", reason)
+			}
+			fmt.Println()
+		}
+		if len(view.Recommendations) > 1 {
+			colorDim.Printf("  Compare them: gigclaw agents compare %s\n", strings.Join(recommendedIDs(view.Recommendations, 3), " "))
+		}
+		colorDim.Printf("  Negotiate:    gigclaw negotiate start %s --with <agent-id> --price <price>\n", task.ID)
+		fmt.Println()
+	})
+}
+
+// recommendedIDs returns the IDs of the first n recommendations
+func recommendedIDs(recs []agentRecommendationView, n int) []string {
+	ids := make([]string, 0, n)
+	for _, r := range recs {
+		if len(ids) == n {
+			break
+		}
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// printAgentProfiles prints discovery profiles as a table
+func printAgentProfiles(agents agentProfileListView) {
+	w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+	bold := color.New(color.FgHiWhite, color.Bold)
+	fmt.Fprintln(w, bold.Sprint("ID")+"\t"+bold.Sprint("NAME")+"\t"+bold.Sprint("AVAILABILITY")+"\t"+
+		bold.Sprint("REPUTATION")+"\t"+bold.Sprint("RATING")+"\t"+bold.Sprint("DONE")+"\t"+
+		bold.Sprint("RATE")+"\t"+bold.Sprint("SKILLS"))
+	for _, a := range agents {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			colorDim.Sprint(a.ID),
+			colorHighlight.Sprint(truncate(a.Name, 24)),
+			formatStatus(a.Availability),
+			colorValue.Sprint(formatAmount(a.Reputation)),
+			colorValue.Sprintf("%.1f", a.Rating),
+			a.CompletedTasks,
+			colorValue.Sprint(a.rate()),
+			colorValue.Sprint(truncate(strings.Join(a.Skills, ", "), 30)),
+		)
+	}
+	w.Flush()
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage API keys and check credentials",
+	Long: `Manage API keys and check the credentials gigclaw uses.
+
+Keys are sent in the X-API-Key header. Each key carries permissions per
+resource (read, write, delete or admin, which implies the others), a rate
+limit and an optional expiry.`,
+}
+
+var authWhoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the API key in use, its permissions and expiry",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthWhoami,
+}
+
+// apiActions are the actions a permission can grant
+var apiActions = []string{gigclaw.ActionRead, gigclaw.ActionWrite, gigclaw.ActionDelete, gigclaw.ActionAdmin}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authWhoamiCmd)
+}
+
+// maskKey shows only enough of an API key to recognise it
+func maskKey(key string) string {
+	if len(key) <= 12 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:6] + "…" + key[len(key)-4:]
+}
+
+// parsePermissions parses resource:action[,action] specs, merging specs
+// for the same resource
+func parsePermissions(specs []string) ([]gigclaw.Permission, error) {
+	var perms []gigclaw.Permission
+	index := make(map[string]int)
+	for _, spec := range specs {
+		resource, actions, ok := strings.Cut(spec, ":")
+		resource = strings.TrimSpace(resource)
+		if !ok || resource == "" || actions == "" {
+			return nil, fmt.Errorf("invalid --permission %q: use resource:action[,action], e.g. tasks:read,write or '*:read'", spec)
+		}
+
+		i, seen := index[resource]
+		if !seen {
+			i = len(perms)
+			index[resource] = i
+			perms = append(perms, gigclaw.Permission{Resource: resource})
+		}
+		for _, a := range strings.Split(actions, ",") {
+			a = strings.ToLower(strings.TrimSpace(a))
+			if !containsFold(apiActions, a) {
+				return nil, fmt.Errorf("invalid action %q in --permission %q: must be one of %s", a, spec, strings.Join(apiActions, ", "))
+			}
+			if !containsFold(perms[i].Actions, a) {
+				perms[i].Actions = append(perms[i].Actions, a)
+			}
+		}
+	}
+	return perms, nil
+}
+
+// formatPermissions prints permissions as resource:action,action specs
+func formatPermissions(perms []gigclaw.Permission) string {
+	specs := make([]string, 0, len(perms))
+	for _, p := range perms {
+		specs = append(specs, p.Resource+":"+strings.Join(p.Actions, ","))
+	}
+	sort.Strings(specs)
+	return strings.Join(specs, " ")
+}
+
+// formatRateLimit prints a rate limit, e.g. "100/1m0s"
+func formatRateLimit(r gigclaw.RateLimit) string {
+	if r.MaxRequests == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%s", r.MaxRequests, r.Window())
+}
+
+// formatExpiry describes when a key expires
+func formatExpiry(expiresAt gigclaw.Timestamp) string {
+	switch {
+	case expiresAt.IsZero():
+		return "never"
+	case expiresAt.Before(time.Now()):
+		return "expired " + expiresAt.Local().Format("Jan 02 2006")
+	default:
+		days := int(time.Until(expiresAt.Time).Hours() / 24)
+		return fmt.Sprintf("%s (in %d days)", expiresAt.Local().Format("Jan 02 2006"), days)
+	}
+}
+
+// whoamiView is the result of auth whoami
+type whoamiView struct {
+	APIURL      string               `json:"apiUrl"`
+	Key         string               `json:"key"` // masked
+	KeyID       string               `json:"keyId"`
+	Name        string               `json:"name,omitempty"`
+	UserID      string               `json:"userId"`
+	Permissions []gigclaw.Permission `json:"permissions"`
+	RateLimit   gigclaw.RateLimit    `json:"rateLimit"`
+	ExpiresAt   string               `json:"expiresAt"` // empty if the key never expires or is not listed
+	Expired     bool                 `json:"expired"`
+	LastUsedAt  string               `json:"lastUsedAt,omitempty"`
+	UseCount    *int                 `json:"useCount,omitempty"` // nil when the key is not listed
+}
+
+func (v whoamiView) columns() []string {
+	return []string{"API URL", "KEY", "KEY ID", "NAME", "USER", "PERMISSIONS", "RATE LIMIT", "EXPIRES AT", "EXPIRED"}
+}
+
+func (v whoamiView) rows() [][]string {
+	return [][]string{{
+		v.APIURL, v.Key, v.KeyID, v.Name, v.UserID, formatPermissions(v.Permissions), formatRateLimit(v.RateLimit),
+		v.ExpiresAt, strconv.FormatBool(v.Expired),
+	}}
+}
+
+func runAuthWhoami(cmd *cobra.Command, args []string) error {
+	apiKey, err := currentAPIKey()
+	if err != nil {
+		return err
+	}
+	if apiKey == "" {
+		return friendlyError(nil,
+			"No API key configured",
+			nil,
+			"Create one: gigclaw auth keys create --name <name>",
+			"Then save it with gigclaw init, or set GIGCLAW_API_KEY")
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	info, err := client.ValidateAPIKey(cmd.Context())
+	if errors.Is(err, gigclaw.ErrUnauthorized) {
+		return friendlyError(err,
+			"The API did not accept the key in use",
+			[]string{"Key: " + maskKey(apiKey), "API: " + client.BaseURL()},
+			"Check that the key was not revoked: gigclaw auth keys list",
+			"Check that it belongs to this API (--api-url)",
+			"If the key is valid, the server is not checking keys on /api/auth/keys; ask its operator to enable key validation")
+	}
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := whoamiView{
+		APIURL:      client.BaseURL(),
+		Key:         maskKey(apiKey),
+		KeyID:       info.KeyID,
+		UserID:      info.UserID,
+		Permissions: info.Permissions,
+		RateLimit:   info.RateLimit,
+	}
+
+	// Validation does not report the expiry; the key list does
+	keys, err := client.ListAPIKeys(cmd.Context())
+	if err != nil {
+		logger.Debug("API key list unavailable", err)
+	}
+	var listed *gigclaw.APIKey
+	for i := range keys {
+		if keys[i].ID == info.KeyID {
+			listed = &keys[i]
+		}
+	}
+	if listed != nil {
+		view.Name = listed.Name
+		view.ExpiresAt = formatTime(listed.ExpiresAt.Time)
+		view.Expired = !listed.ExpiresAt.IsZero() && listed.ExpiresAt.Before(time.Now())
+		view.LastUsedAt = formatTime(listed.LastUsedAt.Time)
+		view.UseCount = &listed.UseCount
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		colorPrimary.Println("  🔑 API key in use")
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "API:")
+		colorValue.Println(view.APIURL)
+		colorLabel.Printf("  %-15s ", "Key:")
+		colorValue.Println(view.Key)
+		colorLabel.Printf("  %-15s ", "Key ID:")
+		colorHighlight.Println(view.KeyID)
+		if view.Name != "" {
+			colorLabel.Printf("  %-15s ", "Name:")
+			colorValue.Println(view.Name)
+		}
+		colorLabel.Printf("  %-15s ", "User:")
+		colorValue.Println(view.UserID)
+		colorLabel.Printf("  %-15s ", "Rate limit:")
+		colorValue.Println(firstNonEmpty(formatRateLimit(view.RateLimit), "-"))
+		colorLabel.Printf("  %-15s ", "Expires:")
+		switch {
+		case listed == nil:
+			colorDim.Println("unknown (key not in your key list)")
+		case view.Expired:
+			colorWarning.Println(formatExpiry(listed.ExpiresAt))
+		default:
+			colorValue.Println(formatExpiry(listed.ExpiresAt))
+		}
+		if view.UseCount != nil {
+			colorLabel.Printf("  %-15s ", "Uses:")
+			colorValue.Println(*view.UseCount)
+		}
+
+		fmt.Println()
+		colorLabel.Println("  Permissions:")
+		for _, p := range view.Permissions {
+			colorValue.Printf("    %-13s ", p.Resource)
+			fmt.Println(strings.Join(p.Actions, ", "))
+		}
+		fmt.Println()
+	})
+}
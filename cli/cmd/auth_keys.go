@@ -0,0 +1,451 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var authKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Create, list and revoke API keys",
+}
+
+var authKeysCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key",
+	Long: `Create an API key, e.g. a scoped key for one of your agents.
+
+--permission grants actions on a resource as resource:action[,action];
+repeat it for several resources. Actions are read, write, delete and admin,
+which implies the others; the resource * matches every resource. Without
+--permission the key may read and write everything.
+
+--agent creates a key that acts for an agent: posters need one to cancel
+their tasks and rate the agents who did them. Anyone may create an agent's
+first key; further ones must be created with one of its keys.
+
+The key is shown only once: store it before closing the terminal.`,
+	Example: `  gigclaw auth keys create --name poster-1 --agent poster-1
+  gigclaw auth keys create --name worker-7 --permission tasks:read,write --permission bids:write
+  gigclaw auth keys create --name ci --permission '*:read' --rate-limit 30 --expires-in-days 90
+  gigclaw auth keys create --name worker-7 -o json | jq -r .key`,
+	Args: cobra.NoArgs,
+	RunE: runAuthKeysCreate,
+}
+
+var authKeysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your API keys",
+	Args:  cobra.NoArgs,
+	RunE:  runAuthKeysList,
+}
+
+var authKeysRevokeCmd = &cobra.Command{
+	Use:   "revoke <key-id>",
+	Short: "Revoke an API key",
+	Long: `Revoke an API key. Requests made with it are refused from then on.
+Without a terminal, pass --yes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthKeysRevoke,
+}
+
+var authKeysStatsCmd = &cobra.Command{
+	Use:   "stats <key-id>",
+	Short: "Show how much an API key is used",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runAuthKeysStats,
+}
+
+var (
+	authKeysCreateName        string
+	authKeysCreateAgent       string
+	authKeysCreatePermissions []string
+	authKeysCreateRateLimit   int
+	authKeysCreateRateWindow  time.Duration
+	authKeysCreateExpiresIn   int
+	authKeysCreateIdemKey     string
+
+	authKeysRevokeYes bool
+)
+
+func init() {
+	authCmd.AddCommand(authKeysCmd)
+	authKeysCmd.AddCommand(authKeysCreateCmd)
+	authKeysCmd.AddCommand(authKeysListCmd)
+	authKeysCmd.AddCommand(authKeysRevokeCmd)
+	authKeysCmd.AddCommand(authKeysStatsCmd)
+
+	authKeysCreateCmd.Flags().StringVarP(&authKeysCreateName, "name", "n", "", "Name of the key, e.g. the agent using it (required)")
+	authKeysCreateCmd.Flags().StringVar(&authKeysCreateAgent, "agent", "", "Agent the key acts for, e.g. to cancel and rate as a poster")
+	authKeysCreateCmd.Flags().StringArrayVarP(&authKeysCreatePermissions, "permission", "p", []string{}, "Permission as resource:action[,action] (can specify multiple)")
+	authKeysCreateCmd.Flags().IntVar(&authKeysCreateRateLimit, "rate-limit", 0, "Maximum requests per --rate-window (default 100)")
+	authKeysCreateCmd.Flags().DurationVar(&authKeysCreateRateWindow, "rate-window", time.Minute, "Rate limit window")
+	authKeysCreateCmd.Flags().IntVar(&authKeysCreateExpiresIn, "expires-in-days", 0, "Expire the key after this many days, 1-365 (default never)")
+	authKeysCreateCmd.Flags().StringVar(&authKeysCreateIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never creates twice")
+	authKeysCreateCmd.MarkFlagRequired("name")
+
+	authKeysRevokeCmd.Flags().BoolVarP(&authKeysRevokeYes, "yes", "y", false, "Revoke without asking for confirmation")
+}
+
+// apiKeyView is an API key
+type apiKeyView struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Permissions []gigclaw.Permission `json:"permissions"`
+	RateLimit   gigclaw.RateLimit    `json:"rateLimit"`
+	CreatedAt   string               `json:"createdAt"`
+	ExpiresAt   string               `json:"expiresAt"`
+	LastUsedAt  string               `json:"lastUsedAt"`
+	UseCount    int                  `json:"useCount"`
+	Active      bool                 `json:"active"`
+	InUse       bool                 `json:"inUse"` // the key gigclaw is using
+}
+
+var apiKeyColumns = []string{"ID", "NAME", "PERMISSIONS", "RATE LIMIT", "CREATED", "EXPIRES", "LAST USED", "USES", "ACTIVE", "IN USE"}
+
+func (k apiKeyView) row() []string {
+	return []string{
+		k.ID, k.Name, formatPermissions(k.Permissions), formatRateLimit(k.RateLimit), k.CreatedAt, k.ExpiresAt,
+		k.LastUsedAt, strconv.Itoa(k.UseCount), strconv.FormatBool(k.Active), strconv.FormatBool(k.InUse),
+	}
+}
+
+// apiKeyListView is a list of API keys
+type apiKeyListView []apiKeyView
+
+func (l apiKeyListView) columns() []string { return apiKeyColumns }
+
+func (l apiKeyListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, k := range l {
+		rows = append(rows, k.row())
+	}
+	return rows
+}
+
+// createdKeyView is the result of auth keys create
+type createdKeyView struct {
+	ID             string               `json:"id"`
+	Key            string               `json:"key,omitempty"` // empty when replayed
+	Name           string               `json:"name"`
+	AgentID        string               `json:"agentId,omitempty"`
+	Permissions    []gigclaw.Permission `json:"permissions"`
+	ExpiresAt      string               `json:"expiresAt"`
+	IdempotencyKey string               `json:"idempotencyKey"`
+	Replayed       bool                 `json:"replayed"`
+}
+
+func (k createdKeyView) columns() []string {
+	return []string{"ID", "KEY", "NAME", "AGENT", "PERMISSIONS", "EXPIRES", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (k createdKeyView) rows() [][]string {
+	return [][]string{{k.ID, k.Key, k.Name, k.AgentID, formatPermissions(k.Permissions), k.ExpiresAt, k.IdempotencyKey, strconv.FormatBool(k.Replayed)}}
+}
+
+// apiKeyStatsView is the result of auth keys stats
+type apiKeyStatsView struct {
+	KeyID      string `json:"keyId"`
+	Name       string `json:"name"`
+	UseCount   int    `json:"useCount"`
+	LastUsedAt string `json:"lastUsedAt"`
+	CreatedAt  string `json:"createdAt"`
+	Active     bool   `json:"active"`
+}
+
+func (s apiKeyStatsView) columns() []string {
+	return []string{"KEY ID", "NAME", "USES", "LAST USED", "CREATED", "ACTIVE"}
+}
+
+func (s apiKeyStatsView) rows() [][]string {
+	return [][]string{{s.KeyID, s.Name, strconv.Itoa(s.UseCount), s.LastUsedAt, s.CreatedAt, strconv.FormatBool(s.Active)}}
+}
+
+// revokedKeyView is the result of auth keys revoke
+type revokedKeyView struct {
+	ID      string `json:"id"`
+	Revoked bool   `json:"revoked"`
+	InUse   bool   `json:"inUse"`
+}
+
+func (r revokedKeyView) columns() []string { return []string{"ID", "REVOKED", "IN USE"} }
+
+func (r revokedKeyView) rows() [][]string {
+	return [][]string{{r.ID, strconv.FormatBool(r.Revoked), strconv.FormatBool(r.InUse)}}
+}
+
+// currentKeyID returns the ID of the key gigclaw is using, or "" if there
+// is none or the server does not say. The lookup is best effort.
+func currentKeyID(cmd *cobra.Command, client *gigclaw.Client) string {
+	if key, err := currentAPIKey(); err != nil || key == "" {
+		return ""
+	}
+	info, err := client.ValidateAPIKey(cmd.Context())
+	if err != nil {
+		logger.Debug("API key validation unavailable", err)
+		return ""
+	}
+	return info.KeyID
+}
+
+func runAuthKeysCreate(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(authKeysCreateName)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return fmt.Errorf("--name must be 1-100 characters")
+	}
+	perms, err := parsePermissions(authKeysCreatePermissions)
+	if err != nil {
+		return err
+	}
+	if authKeysCreateRateLimit < 0 {
+		return fmt.Errorf("--rate-limit must not be negative")
+	}
+	if authKeysCreateRateWindow <= 0 {
+		return fmt.Errorf("--rate-window must be positive")
+	}
+	if authKeysCreateExpiresIn != 0 && (authKeysCreateExpiresIn < 1 || authKeysCreateExpiresIn > 365) {
+		return fmt.Errorf("--expires-in-days must be between 1 and 365")
+	}
+
+	req := gigclaw.CreateAPIKeyRequest{
+		Name:          name,
+		AgentID:       authKeysCreateAgent,
+		Permissions:   perms,
+		ExpiresInDays: authKeysCreateExpiresIn,
+	}
+	if authKeysCreateRateLimit > 0 {
+		req.RateLimit = &gigclaw.RateLimit{
+			WindowMs:    authKeysCreateRateWindow.Milliseconds(),
+			MaxRequests: authKeysCreateRateLimit,
+		}
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	var key *gigclaw.NewAPIKey
+	res, err := runJournaled("auth key create", authKeysCreateIdemKey, []interface{}{req}, func(idemKey string) (string, error) {
+		var err error
+		key, err = client.CreateAPIKey(cmd.Context(), req, gigclaw.IdempotencyKey(idemKey))
+		if err != nil {
+			return "", err
+		}
+		return key.ID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := createdKeyView{
+		ID:             res.Result,
+		Name:           name,
+		AgentID:        authKeysCreateAgent,
+		Permissions:    perms,
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	if key != nil {
+		view.Key = key.Key
+		view.Permissions = key.Permissions
+		view.ExpiresAt = formatTime(key.ExpiresAt.Time)
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if view.Replayed {
+			colorWarning.Println("  API key already created by an earlier run (idempotency key reused)")
+			colorDim.Println("  The key is only shown once; revoke it and create a new one if it was lost.")
+		} else {
+			colorSuccess.Println("  ✓ API key created")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Key ID:")
+		colorValue.Println(view.ID)
+		colorLabel.Printf("  %-15s ", "Name:")
+		colorValue.Println(view.Name)
+		if view.AgentID != "" {
+			colorLabel.Printf("  %-15s ", "Agent:")
+			colorValue.Println(view.AgentID)
+		}
+		if view.Key != "" {
+			colorLabel.Printf("  %-15s ", "Key:")
+			colorHighlight.Println(view.Key)
+		}
+		if len(view.Permissions) > 0 {
+			colorLabel.Printf("  %-15s ", "Permissions:")
+			colorValue.Println(formatPermissions(view.Permissions))
+		}
+		if key != nil {
+			colorLabel.Printf("  %-15s ", "Expires:")
+			colorValue.Println(formatExpiry(key.ExpiresAt))
+		}
+		if view.Key != "" {
+			fmt.Println()
+			colorWarning.Println("  Store this key now: it will not be shown again.")
+			colorDim.Println("  Use it: gigclaw --api-key <key> ..., GIGCLAW_API_KEY, or save it with gigclaw init")
+		}
+		fmt.Println()
+	})
+}
+
+func runAuthKeysList(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	keys, err := client.ListAPIKeys(cmd.Context())
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	current := currentKeyID(cmd, client)
+
+	view := make(apiKeyListView, 0, len(keys))
+	for _, k := range keys {
+		view = append(view, apiKeyView{
+			ID:          k.ID,
+			Name:        k.Name,
+			Permissions: k.Permissions,
+			RateLimit:   k.RateLimit,
+			CreatedAt:   formatTime(k.CreatedAt.Time),
+			ExpiresAt:   formatTime(k.ExpiresAt.Time),
+			LastUsedAt:  formatTime(k.LastUsedAt.Time),
+			UseCount:    k.UseCount,
+			Active:      k.Active,
+			InUse:       k.ID == current,
+		})
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(keys) == 0 {
+			colorWarning.Println("  No API keys found.")
+			fmt.Println()
+			colorDim.Println("  Create one: gigclaw auth keys create --name <name>")
+			fmt.Println()
+			return
+		}
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("ID")+"\t"+bold.Sprint("NAME")+"\t"+bold.Sprint("PERMISSIONS")+"\t"+
+			bold.Sprint("RATE LIMIT")+"\t"+bold.Sprint("EXPIRES")+"\t"+bold.Sprint("LAST USED")+"\t"+bold.Sprint("USES"))
+		for _, k := range keys {
+			name := k.Name
+			if k.ID == current {
+				name += " (in use)"
+			}
+			expires := colorValue.Sprint(formatExpiry(k.ExpiresAt))
+			if !k.ExpiresAt.IsZero() && k.ExpiresAt.Before(time.Now()) {
+				expires = colorWarning.Sprint(formatExpiry(k.ExpiresAt))
+			}
+			last := "never"
+			if !k.LastUsedAt.IsZero() {
+				last = k.LastUsedAt.Local().Format("Jan 02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+				colorDim.Sprint(k.ID),
+				colorHighlight.Sprint(name),
+				colorValue.Sprint(truncate(formatPermissions(k.Permissions), 40)),
+				colorValue.Sprint(formatRateLimit(k.RateLimit)),
+				expires,
+				colorDim.Sprint(last),
+				k.UseCount,
+			)
+		}
+		w.Flush()
+		fmt.Println()
+	})
+}
+
+func runAuthKeysRevoke(cmd *cobra.Command, args []string) error {
+	keyID := args[0]
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	inUse := keyID == currentKeyID(cmd, client)
+	question := fmt.Sprintf("Revoke API key %s?", keyID)
+	if inUse {
+		question = fmt.Sprintf("API key %s is the one gigclaw is using; later commands will be refused. Revoke it?", keyID)
+	}
+	if err := confirmAction(question, authKeysRevokeYes); err != nil {
+		return err
+	}
+
+	if err := client.RevokeAPIKey(cmd.Context(), keyID); err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := revokedKeyView{ID: keyID, Revoked: true, InUse: inUse}
+	return render(view, func() {
+		fmt.Println()
+		colorSuccess.Printf("  ✓ API key %s revoked\n", keyID)
+		if inUse {
+			colorWarning.Println("  gigclaw was using this key; configure another with gigclaw init or --api-key")
+		}
+		fmt.Println()
+	})
+}
+
+func runAuthKeysStats(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	stats, err := client.GetAPIKeyStats(cmd.Context(), args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := apiKeyStatsView{
+		KeyID:      stats.KeyID,
+		Name:       stats.Name,
+		UseCount:   stats.UseCount,
+		LastUsedAt: formatTime(stats.LastUsedAt.Time),
+		CreatedAt:  formatTime(stats.CreatedAt.Time),
+		Active:     stats.Active,
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		colorPrimary.Printf("  📈 Usage of %s\n", firstNonEmpty(stats.Name, stats.KeyID))
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Key ID:")
+		colorValue.Println(stats.KeyID)
+		colorLabel.Printf("  %-15s ", "Status:")
+		if stats.Active {
+			colorSuccess.Println("active")
+		} else {
+			colorWarning.Println("revoked")
+		}
+		colorLabel.Printf("  %-15s ", "Requests:")
+		colorHighlight.Println(stats.UseCount)
+		colorLabel.Printf("  %-15s ", "Last used:")
+		if stats.LastUsedAt.IsZero() {
+			colorDim.Println("never")
+		} else {
+			colorValue.Println(stats.LastUsedAt.Local().Format(time.RFC822))
+		}
+		colorLabel.Printf("  %-15s ", "Created:")
+		colorValue.Println(stats.CreatedAt.Local().Format(time.RFC822))
+		if days := time.Since(stats.CreatedAt.Time).Hours() / 24; days >= 1 && stats.UseCount > 0 {
+			colorLabel.Printf("  %-15s ", "Average:")
+			colorValue.Printf("%.1f requests/day\n", float64(stats.UseCount)/days)
+		}
+		fmt.Println()
+	})
+}
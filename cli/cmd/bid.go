@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var bidCmd = &cobra.Command{
@@ -14,8 +16,10 @@ var bidCmd = &cobra.Command{
 }
 
 var (
-	bidAmount float64
+	bidAmount  float64
 	bidMessage string
+	bidIdemKey string
+	bidAs      string
 )
 
 func init() {
@@ -23,6 +27,9 @@ func init() {
 
 	bidCmd.Flags().Float64VarP(&bidAmount, "amount", "a", 0, "Bid amount (required)")
 	bidCmd.Flags().StringVarP(&bidMessage, "message", "m", "", "Bid message")
+	bidCmd.Flags().StringVar(&bidIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never bids twice")
+
+	bidCmd.Flags().StringVar(&bidAs, "as", "", "Agent ID to bid as (default agent-id from the config file)")
 
 	bidCmd.MarkFlagRequired("amount")
 }
@@ -39,21 +46,72 @@ func runBid(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	bid, err := client.PlaceBid(taskID, bidAmount, bidMessage)
+	// Without an agent the bid is anonymous, as before agent register
+	agentID := firstNonEmpty(bidAs, viper.GetString("agent-id"))
+
+	var bid *gigclaw.Bid
+	params := []interface{}{taskID, bidAmount, bidMessage}
+	if agentID != "" {
+		params = append(params, agentID)
+	}
+	res, err := runJournaled("task bid", bidIdemKey, params, func(key string) (string, error) {
+		var err error
+		if agentID != "" {
+			bid, err = client.PlaceAgentBid(cmd.Context(), taskID, agentID, bidAmount, bidMessage, gigclaw.IdempotencyKey(key))
+		} else {
+			bid, err = client.PlaceBid(cmd.Context(), taskID, bidAmount, bidMessage, gigclaw.IdempotencyKey(key))
+		}
+		if err != nil {
+			return "", err
+		}
+		return bid.ID, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to place bid: %w", err)
+		return HandleAPIError(err)
 	}
 
-	fmt.Println("✅ Bid placed successfully!")
-	fmt.Println()
-	fmt.Printf("Bid ID:   %s\n", bid.ID)
-	fmt.Printf("Task ID:  %s\n", taskID)
-	fmt.Printf("Amount:   %.2f\n", bid.Amount)
-	if bid.Message != "" {
-		fmt.Printf("Message:  %s\n", bid.Message)
+	if res.Replayed {
+		// The journal fingerprint guarantees the amount and message match
+		view := bidView{
+			ID:             res.Result,
+			TaskID:         taskID,
+			AgentID:        agentID,
+			Amount:         bidAmount,
+			Message:        bidMessage,
+			IdempotencyKey: res.Key,
+			Replayed:       true,
+		}
+		return render(view, func() {
+			fmt.Printf("Bid already placed with idempotency key %s\n", res.Key)
+			fmt.Println()
+			fmt.Printf("Bid ID:   %s\n", res.Result)
+			fmt.Printf("Task ID:  %s\n", taskID)
+		})
 	}
-	fmt.Println()
-	fmt.Println("Wait for the task owner to accept your bid.")
 
-	return nil
+	view := bidView{
+		ID:             bid.ID,
+		TaskID:         taskID,
+		AgentID:        bid.AgentID,
+		Amount:         bid.Amount,
+		Message:        bid.Message,
+		Status:         bid.Status,
+		CreatedAt:      formatTime(bid.CreatedAt.Time),
+		IdempotencyKey: res.Key,
+	}
+	return render(view, func() {
+		fmt.Println("✅ Bid placed successfully!")
+		fmt.Println()
+		fmt.Printf("Bid ID:   %s\n", bid.ID)
+		fmt.Printf("Task ID:  %s\n", taskID)
+		if bid.AgentID != "" {
+			fmt.Printf("Agent:    %s\n", bid.AgentID)
+		}
+		fmt.Printf("Amount:   %.2f\n", bid.Amount)
+		if bid.Message != "" {
+			fmt.Printf("Message:  %s\n", bid.Message)
+		}
+		fmt.Println()
+		fmt.Println("Wait for the task owner to accept your bid.")
+	})
 }
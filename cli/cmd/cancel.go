@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel <task-id>",
+	Short: "Cancel a task you posted",
+	Long: `Cancel a task you posted before any bid is accepted. Funds escrowed
+on chain for the task are refunded to you.
+
+The API only takes the cancellation from an API key created for the poster
+(gigclaw auth keys create --agent).`,
+	Example: `  gigclaw task cancel 123
+  gigclaw task cancel 123 --as poster-1 --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCancel,
+}
+
+var (
+	cancelAs      string
+	cancelYes     bool
+	cancelIdemKey string
+)
+
+func init() {
+	taskCmd.AddCommand(cancelCmd)
+
+	cancelCmd.Flags().StringVar(&cancelAs, "as", "", "Poster of the task (default agent-id from the config file)")
+	cancelCmd.Flags().BoolVarP(&cancelYes, "yes", "y", false, "Cancel without asking for confirmation")
+	cancelCmd.Flags().StringVar(&cancelIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never cancels twice")
+}
+
+func runCancel(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	done := []string{"cancelled"}
+	if err := requireTaskStatus(task, "cancel", "posted", done, cancelIdemKey,
+		"Tasks can only be cancelled before a bid is accepted",
+		"To contest accepted work, open a dispute instead"); err != nil {
+		return err
+	}
+	poster := firstNonEmpty(cancelAs, viper.GetString("agent-id"))
+	if task.PosterID != "" && task.PosterID != poster {
+		return friendlyError(nil,
+			fmt.Sprintf("Cannot cancel task %s: it was posted by %s", task.ID, task.PosterID),
+			nil,
+			"Only the poster can cancel a task: pass --as "+task.PosterID+" if that is you")
+	}
+
+	view := taskActionView{
+		TaskID:         task.ID,
+		Action:         "cancel",
+		PreviousStatus: task.Status,
+		Status:         "cancelled",
+		Funds: fundsView{
+			Amount:    escrowedAmount(cmd.Context(), client, task),
+			Currency:  task.Currency,
+			Outcome:   fundsRefund,
+			Recipient: task.PosterID,
+			Note:      "Any funds escrowed on chain for this task are refunded to the poster",
+		},
+	}
+
+	question := fmt.Sprintf("Cancel task %s (%q) and withdraw it from the marketplace?", task.ID, task.Title)
+	if n := len(task.Bids); n > 0 {
+		question = fmt.Sprintf("Cancel task %s (%q)? Its %d open bid(s) will be dropped.", task.ID, task.Title, n)
+	}
+	if err := confirmAction(question, cancelYes); err != nil {
+		return err
+	}
+
+	res, err := runJournaled("task cancel", cancelIdemKey, []interface{}{taskID}, func(key string) (string, error) {
+		updated, blockchain, err := client.CancelTask(cmd.Context(), taskID, poster, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		if updated.Status != "" {
+			view.Status = updated.Status
+		}
+		view.Blockchain = newChainView(blockchain)
+		return taskID, nil
+	})
+	if errors.Is(err, gigclaw.ErrUnauthorized) || errors.Is(err, gigclaw.ErrForbidden) {
+		return friendlyError(err,
+			fmt.Sprintf("Cannot cancel task %s: the API key in use does not act for %s", task.ID, task.PosterID),
+			nil,
+			"Create a key for the poster: gigclaw auth keys create --name "+task.PosterID+" --agent "+task.PosterID,
+			"Then use it: gigclaw --api-key <key> task cancel "+task.ID)
+	}
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	view.IdempotencyKey = res.Key
+	view.Replayed = res.Replayed
+
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Task already cancelled with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("✅ Task cancelled")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Task:")
+		colorValue.Println(task.ID)
+		printFunds(view.Funds)
+		if c := view.Blockchain; c != nil && c.Status != "skipped" {
+			colorLabel.Printf("  %-15s ", "On-chain:")
+			switch c.Status {
+			case "confirmed":
+				colorSuccess.Println("refunded")
+				colorDim.Printf("  %-15s %s\n", "", c.ExplorerURL)
+			default:
+				colorWarning.Printf("%s: %s\n", c.Status, c.Error)
+			}
+		}
+		fmt.Println()
+	})
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var completeCmd = &cobra.Command{
+	Use:   "complete <task-id>",
+	Short: "Deliver the work for a task assigned to you",
+	Long: `Mark a task you are assigned to as completed and submit the delivery URL.
+
+The payment stays in escrow until the poster verifies the work.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runComplete,
+}
+
+// maxDeliveryURL is the longest delivery URL the program accepts
+const maxDeliveryURL = 200
+
+var (
+	completeDeliveryURL string
+	completeAgentID     string
+	completeIdemKey     string
+)
+
+func init() {
+	taskCmd.AddCommand(completeCmd)
+
+	completeCmd.Flags().StringVarP(&completeDeliveryURL, "delivery-url", "u", "", "Where the delivered work can be found (required)")
+	completeCmd.Flags().StringVar(&completeAgentID, "agent", "", "Your agent ID (default agent-id from the config file)")
+	completeCmd.Flags().StringVar(&completeIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never completes twice")
+	completeCmd.MarkFlagRequired("delivery-url")
+}
+
+func runComplete(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	if err := validateDeliveryURL(completeDeliveryURL); err != nil {
+		return err
+	}
+
+	agentID := completeAgentID
+	if agentID == "" {
+		agentID = viper.GetString("agent-id")
+	}
+	if agentID == "" {
+		return fmt.Errorf("agent ID is required: pass --agent or set agent-id in the config file")
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	done := []string{"completed"}
+	if err := requireTaskStatus(task, "complete", "in_progress", done, completeIdemKey,
+		"Only tasks with an accepted bid can be completed"); err != nil {
+		return err
+	}
+	if task.AssignedAgent != "" && task.AssignedAgent != agentID {
+		return friendlyError(nil,
+			fmt.Sprintf("Task %s is assigned to %s, not %s", task.ID, task.AssignedAgent, agentID),
+			nil,
+			"Pass the assigned agent's ID with --agent")
+	}
+
+	view := taskActionView{
+		TaskID:         task.ID,
+		Action:         "complete",
+		PreviousStatus: task.Status,
+		Status:         "completed",
+		DeliveryURL:    completeDeliveryURL,
+		Funds: fundsView{
+			Amount:   escrowedAmount(cmd.Context(), client, task),
+			Currency: task.Currency,
+			Outcome:  fundsHeld,
+			Note:     "Stays in escrow until the poster verifies the work",
+		},
+	}
+
+	params := []interface{}{taskID, agentID, completeDeliveryURL}
+	res, err := runJournaled("task complete", completeIdemKey, params, func(key string) (string, error) {
+		updated, err := client.CompleteTask(cmd.Context(), taskID, agentID, completeDeliveryURL, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		if updated.Status != "" {
+			view.Status = updated.Status
+		}
+		return taskID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	view.IdempotencyKey = res.Key
+	view.Replayed = res.Replayed
+
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Task already completed with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("✅ Work delivered!")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Task:")
+		colorValue.Println(task.ID)
+		colorLabel.Printf("  %-15s ", "Delivery:")
+		colorValue.Println(completeDeliveryURL)
+		printFunds(view.Funds)
+		fmt.Println()
+		fmt.Println("The poster will review the delivery and release payment with:")
+		fmt.Println("  gigclaw task verify " + task.ID)
+	})
+}
+
+// validateDeliveryURL checks the URL against the program's limits
+func validateDeliveryURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("--delivery-url is required")
+	}
+	if len(raw) > maxDeliveryURL {
+		return fmt.Errorf("--delivery-url is %d characters long (at most %d allowed)", len(raw), maxDeliveryURL)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("--delivery-url must be an absolute URL, e.g. https://github.com/you/repo/pull/1")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configPath returns the config file in use, by default
+// ~/.gigclaw/config.yaml
+func configPath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".gigclaw", "config.yaml"), nil
+}
+
+// readConfigFile returns the settings in the config file, or none if there
+// is no config file yet
+func readConfigFile(path string) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	return config, nil
+}
+
+// editConfig applies edit to the settings in the config file, keeping the
+// rest. The file is only ever readable by its owner and is replaced in one
+// step, so a failed write leaves the old config in place. It returns the
+// path written.
+func editConfig(edit func(config map[string]interface{}) error) (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+
+	config, err := readConfigFile(path)
+	if err != nil {
+		return "", err
+	}
+	if err := edit(config); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := writeFileAtomic(path, buf.Bytes(), 0600); err != nil {
+		return "", fmt.Errorf("failed to write config: %w", err)
+	}
+	return path, nil
+}
+
+// updateConfig applies changes to the settings of the active profile in
+// the config file; a nil value removes the setting. It returns the path
+// written.
+func updateConfig(changes map[string]interface{}) (string, error) {
+	profile := activeProfile()
+	path, err := editConfig(func(config map[string]interface{}) error {
+		settings := config
+		if profile != defaultProfile {
+			settings = profileSettings(config, profile, true)
+		}
+		for key, value := range changes {
+			if value == nil {
+				delete(settings, key)
+			} else {
+				settings[key] = value
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for key, value := range changes {
+		if value == nil {
+			value = "" // viper falls back to the file it read for nil
+		}
+		viper.Set(key, value)
+	}
+	return path, nil
+}
@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Solana-inspired color palette
@@ -77,15 +82,37 @@ var (
 type dashboardModel struct {
 	spinner    spinner.Model
 	taskTable  table.Model
-	tasks      []Task
+	tasks      []gigclaw.Task
 	loading    bool
 	err        error
 	width      int
 	height     int
 	activeTab  int
 	tabs       []string
-	client     *Client
+	client     *gigclaw.Client
+	agentID    string // bids and deliveries are made as this agent
 	lastUpdate time.Time
+
+	// Live updates; see dashboard_live.go
+	ctx              context.Context
+	states           chan streamStateMsg
+	stream           *gigclaw.Stream
+	streamErr        error
+	conn             connState
+	subscribeAttempt int
+	pollSeq          int
+	pollAttempt      int
+	pollEvery        time.Duration
+	lastEvent        string
+
+	// Task detail pane and action modals; see dashboard_detail.go
+	detailID      string
+	detail        *taskDetail
+	detailErr     error
+	detailLoading bool
+	modal         *actionModal
+	flash         string
+	warning       string // from the last action, shown in the status line
 }
 
 const (
@@ -94,19 +121,21 @@ const (
 	TabHelp
 )
 
-type tasksMsg []Task
+type tasksMsg []gigclaw.Task
 type errMsg error
 
 func (m dashboardModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
-		fetchTasksCmd(m.client),
+		fetchTasksCmd(m.ctx, m.client),
+		subscribeCmd(m.ctx, m.client, m.states),
+		waitForState(m.states),
 	)
 }
 
-func fetchTasksCmd(client *Client) tea.Cmd {
+func fetchTasksCmd(ctx context.Context, client *gigclaw.Client) tea.Cmd {
 	return func() tea.Msg {
-		tasks, err := client.ListTasks()
+		tasks, err := client.ListTasks(ctx)
 		if err != nil {
 			return errMsg(err)
 		}
@@ -115,11 +144,43 @@ func fetchTasksCmd(client *Client) tea.Cmd {
 }
 
 func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m, cmd, ok := m.updateLive(msg); ok {
+		if ev, isEvent := msg.(eventMsg); isEvent && m.detailID != "" && ev.event.TaskID == m.detailID && m.modal == nil {
+			m.detailLoading = true
+			cmd = tea.Batch(cmd, loadDetailCmd(m.ctx, m.client, m.detailID))
+		}
+		return m, cmd
+	}
+	if m, cmd, ok := m.updateDetail(msg); ok {
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.modal != nil {
+			return m.updateModal(msg)
+		}
 		switch msg.String() {
-		case "q", "ctrl+c", "esc":
+		case "esc":
+			if m.detailID != "" {
+				m.closeDetail()
+				return m, nil
+			}
 			return m, tea.Quit
+		case "q":
+			return m, tea.Quit
+		case "enter":
+			if m.activeTab == TabTasks {
+				return m, m.openDetail()
+			}
+		case "b", "a", "c", "v":
+			if m.detailID != "" && m.activeTab == TabTasks {
+				kinds := map[string]modalKind{"b": modalBid, "a": modalAccept, "c": modalComplete, "v": modalVerify}
+				return m, m.openModal(kinds[msg.String()])
+			}
 		case "tab", "right":
 			m.activeTab = (m.activeTab + 1) % len(m.tabs)
 			return m, nil
@@ -128,7 +189,7 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "r", "f5":
 			m.loading = true
-			return m, fetchTasksCmd(m.client)
+			return m, fetchTasksCmd(m.ctx, m.client)
 		case "?":
 			m.activeTab = TabHelp
 			return m, nil
@@ -137,20 +198,7 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		
-		tableWidth := m.width - 10
-		if tableWidth < 60 {
-			tableWidth = 60
-		}
-		
-		columns := []table.Column{
-			{Title: "ID", Width: 10},
-			{Title: "Title", Width: tableWidth/3},
-			{Title: "Budget", Width: 14},
-			{Title: "Status", Width: 14},
-		}
-		m.taskTable.SetColumns(columns)
-		m.taskTable.SetHeight(m.height - 12)
+		m.layout()
 		return m, nil
 
 	case spinner.TickMsg:
@@ -159,25 +207,13 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case tasksMsg:
+		// Refreshes are one-off; events or the polling fallback keep
+		// the list current afterwards
 		m.tasks = msg
 		m.loading = false
 		m.lastUpdate = time.Now()
-		
-		rows := []table.Row{}
-		for _, task := range m.tasks {
-			status := formatStatus(task.Status)
-			rows = append(rows, table.Row{
-				truncate(task.ID, 10),
-				truncate(task.Title, 40),
-				fmt.Sprintf("%.2f %s", task.Budget, task.Currency),
-				status,
-			})
-		}
-		m.taskTable.SetRows(rows)
-		
-		return m, tea.Tick(time.Second*30, func(t time.Time) tea.Msg {
-			return fetchTasksCmd(m.client)()
-		})
+		m.refreshRows()
+		return m, nil
 
 	case errMsg:
 		m.err = msg
@@ -190,6 +226,70 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// taskTableColumns returns the task table columns for a title width
+func taskTableColumns(titleWidth int) []table.Column {
+	return []table.Column{
+		{Title: "ID", Width: 10},
+		{Title: "Title", Width: titleWidth},
+		{Title: "Budget", Width: 14},
+		{Title: "Bids", Width: 6},
+		{Title: "Status", Width: 14},
+	}
+}
+
+// layout sizes the task table, narrowing it while the detail pane is open
+func (m *dashboardModel) layout() {
+	tableWidth := m.width - 10
+	if tableWidth < 60 {
+		tableWidth = 60
+	}
+
+	titleWidth := tableWidth / 3
+	if m.detailID != "" {
+		titleWidth = 16
+	}
+	m.taskTable.SetColumns(taskTableColumns(titleWidth))
+	m.taskTable.SetHeight(m.height - 12)
+}
+
+// openDetail shows the selected task in the detail pane
+func (m *dashboardModel) openDetail() tea.Cmd {
+	row := m.taskTable.Cursor()
+	if row < 0 || row >= len(m.tasks) {
+		return nil
+	}
+	id := m.tasks[row].ID
+	if id != m.detailID {
+		m.detail, m.detailErr = nil, nil
+	}
+	m.detailID = id
+	m.detailLoading = true
+	m.flash = ""
+	m.layout()
+	return loadDetailCmd(m.ctx, m.client, id)
+}
+
+func (m *dashboardModel) closeDetail() {
+	m.detailID = ""
+	m.detail, m.detailErr = nil, nil
+	m.flash = ""
+	m.layout()
+}
+
+// refreshRows rebuilds the task table from m.tasks
+func (m *dashboardModel) refreshRows() {
+	rows := []table.Row{}
+	for _, task := range m.tasks {
+		rows = append(rows, table.Row{
+			truncate(task.ID, 10),
+			truncate(task.Title, 40),
+			fmt.Sprintf("%.2f %s", task.Budget, task.Currency),
+			fmt.Sprintf("%d", len(task.Bids)),
+			formatStatus(task.Status),
+		})
+	}
+	m.taskTable.SetRows(rows)
+}
 
 func (m dashboardModel) View() string {
 	if m.err != nil {
@@ -202,17 +302,20 @@ func (m dashboardModel) View() string {
 	b.WriteString(titleStyle.Render(" 🦀 GigClaw Dashboard "))
 	b.WriteString("\n\n")
 
-	status := statusOnline
-	if m.loading {
-		status = m.spinner.View()
-	}
-	
-	statusLine := fmt.Sprintf("  %s API: %s  |  Last update: %s  |  %s tasks",
-		status,
-		normalStyle.Render("Connected"),
+	statusLine := fmt.Sprintf("  %s  |  Last update: %s  |  %s tasks",
+		m.connStatus(),
 		m.lastUpdate.Format("15:04:05"),
 		normalStyle.Render(fmt.Sprintf("%d", len(m.tasks))),
 	)
+	if m.lastEvent != "" {
+		statusLine += "  |  " + dimStyle.Render("Last event: "+m.lastEvent)
+	}
+	if m.warning != "" {
+		statusLine += "  |  " + warningStyle.Render("⚠ "+m.warning)
+	}
+	if m.loading {
+		statusLine += "  " + m.spinner.View()
+	}
 	b.WriteString(statusLine)
 	b.WriteString("\n\n")
 
@@ -225,7 +328,7 @@ func (m dashboardModel) View() string {
 		b.WriteString(" ")
 	}
 	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", m.width-4))
+	b.WriteString(strings.Repeat("─", max(m.width-4, 0)))
 	b.WriteString("\n\n")
 
 	switch m.activeTab {
@@ -235,9 +338,19 @@ func (m dashboardModel) View() string {
 		} else if len(m.tasks) == 0 {
 			b.WriteString("\n  " + dimStyle.Render("No tasks found.\n"))
 			b.WriteString("\n  Create your first task:\n")
-			b.WriteString("  " + normalStyle.Render("gigclaw task post --title 'My Task' --budget 50"))
+			b.WriteString("  " + normalStyle.Render("gigclaw task post --title 'My Task' --budget 50 --deadline 2026-12-31"))
 		} else {
-			b.WriteString(boxStyle.Render(m.taskTable.View()))
+			tableBox := boxStyle.Render(m.taskTable.View())
+			switch {
+			case m.modal != nil:
+				b.WriteString(lipgloss.Place(max(m.width, lipgloss.Width(tableBox)), lipgloss.Height(tableBox),
+					lipgloss.Center, lipgloss.Center, m.renderModal()))
+			case m.detailID != "":
+				detailWidth := max(m.width-lipgloss.Width(tableBox)-1, 36)
+				b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, tableBox, " ", m.renderDetail(detailWidth)))
+			default:
+				b.WriteString(tableBox)
+			}
 		}
 		
 	case TabStats:
@@ -248,7 +361,14 @@ func (m dashboardModel) View() string {
 	}
 
 	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("  tab/←→: Switch tabs  |  ↑/↓: Navigate  |  r: Refresh  |  q: Quit  |  ?: Help"))
+	switch {
+	case m.modal != nil:
+		// The modal shows its own keys
+	case m.detailID != "" && m.activeTab == TabTasks:
+		b.WriteString(helpStyle.Render("  ↑/↓: Navigate  |  enter: Show task  |  b/a/c/v: Bid, Accept, Complete, Verify  |  esc: Close"))
+	default:
+		b.WriteString(helpStyle.Render("  tab/←→: Switch tabs  |  ↑/↓: Navigate  |  enter: Details  |  r: Refresh  |  q: Quit  |  ?: Help"))
+	}
 
 	return b.String()
 }
@@ -296,9 +416,15 @@ func (m dashboardModel) renderHelp() string {
 	b.WriteString("  Tab / →     Next tab\n")
 	b.WriteString("  Shift+Tab / ←  Previous tab\n")
 	b.WriteString("  ↑ / ↓       Navigate list\n")
+	b.WriteString("  Enter       Show task details\n")
+	b.WriteString("  b           Bid on the task\n")
+	b.WriteString("  a           Accept a bid\n")
+	b.WriteString("  c           Mark the task complete\n")
+	b.WriteString("  v           Verify the work\n")
 	b.WriteString("  r / F5      Refresh data\n")
 	b.WriteString("  ?           Show this help\n")
-	b.WriteString("  q / Esc     Quit dashboard\n")
+	b.WriteString("  Esc         Close details, or quit\n")
+	b.WriteString("  q           Quit dashboard\n")
 	b.WriteString("\n")
 	b.WriteString("  📚 CLI Commands\n\n")
 	b.WriteString("  gigclaw task list       View all tasks\n")
@@ -317,22 +443,32 @@ var dashboardCmd = &cobra.Command{
 Navigate with arrow keys, switch tabs with Tab/Shift+Tab.
 Press 'r' to refresh data, 'q' to quit.
 
+Press Enter on a task to open its details: description, bids, escrow and
+on-chain status. From there 'b' places a bid, 'a' accepts one, 'c' marks
+the task complete and 'v' verifies the work. Actions that move funds ask
+for confirmation first. Bids are placed as --as, or the agent-id from the
+config file.
+
 Features:
-- Real-time task feed
+- Live task and bid updates from the server's event stream
 - Task statistics
 - Keyboard navigation
 - Beautiful TUI interface
-- Auto-refresh every 30 seconds`,
+
+If the event stream is unavailable the dashboard polls instead, every 5
+seconds at first and backing off to once a minute, until it reconnects.`,
 	RunE: runDashboard,
 }
 
+var dashboardAs string
+
 func runDashboard(cmd *cobra.Command, args []string) error {
 	client, err := getAPIClient()
 	if err != nil {
 		return err
 	}
 
-	if _, err := client.Health(); err != nil {
+	if _, err := client.Health(cmd.Context()); err != nil {
 		return fmt.Errorf("cannot connect to API: %w", err)
 	}
 
@@ -340,15 +476,8 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color(colorSolanaGreen))
 
-	columns := []table.Column{
-		{Title: "ID", Width: 10},
-		{Title: "Title", Width: 30},
-		{Title: "Budget", Width: 14},
-		{Title: "Status", Width: 14},
-	}
-
 	t := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(taskTableColumns(30)),
 		table.WithFocused(true),
 		table.WithHeight(20),
 	)
@@ -359,14 +488,26 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		Cell:     normalStyle,
 	})
 
+	// Cancelling ctx on exit closes the event stream and drops requests in flight
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
 	m := dashboardModel{
 		spinner:   s,
 		taskTable: t,
 		loading:   true,
 		tabs:      []string{"Tasks", "Stats", "Help"},
 		client:    client,
+		agentID:   firstNonEmpty(dashboardAs, viper.GetString("agent-id")),
+		ctx:       ctx,
+		states:    make(chan streamStateMsg, 8),
 	}
 
+	// Warnings written to stderr would corrupt the screen
+	stderr := color.Error
+	color.Error = io.Discard
+	defer func() { color.Error = stderr }()
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("dashboard error: %w", err)
@@ -377,5 +518,6 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 
 
 func init() {
+	dashboardCmd.Flags().StringVar(&dashboardAs, "as", "", "Agent ID to bid as (default agent-id from the config file)")
 	rootCmd.AddCommand(dashboardCmd)
 }
\ No newline at end of file
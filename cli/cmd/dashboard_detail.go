@@ -0,0 +1,578 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+)
+
+// The detail pane shows the selected task next to the table. Its actions
+// open modal forms that call the same client methods, under the same
+// journal operations, as the task bid, accept, complete and verify
+// commands. Actions that move funds ask for confirmation first.
+
+var (
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color(colorSolanaGreen)).
+			Padding(1, 2)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF4444"))
+
+	successStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colorSolanaGreen))
+
+	warningStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFA500"))
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(colorSolanaPurple)).
+			Width(10)
+)
+
+// taskDetail is the loaded content of the detail pane
+type taskDetail struct {
+	task        *gigclaw.Task
+	view        taskDetailView
+	warnings    []string
+	releaseNote string // verify only
+}
+
+// escrowed returns the amount locked for the task
+func (d *taskDetail) escrowed() float64 {
+	if e := d.view.Escrow; e != nil && e.Amount > 0 {
+		return e.Amount
+	}
+	for _, b := range d.view.Bids {
+		if b.Status == "accepted" {
+			return b.Amount
+		}
+	}
+	return 0
+}
+
+type detailLoadedMsg struct {
+	taskID string
+	detail *taskDetail
+	err    error
+}
+
+// actionDoneMsg reports the outcome of a modal action
+type actionDoneMsg struct {
+	taskID   string
+	text     string
+	warnings []string // from the journal, for the status bar
+	err      error
+	retryKey string // set when err leaves the outcome unknown
+	retry    string // what to tell the user instead of err, with retryKey
+}
+
+func loadDetailCmd(ctx context.Context, client *gigclaw.Client, taskID string) tea.Cmd {
+	return func() tea.Msg {
+		task, err := client.GetTask(ctx, taskID)
+		if err != nil {
+			return detailLoadedMsg{taskID: taskID, err: err}
+		}
+
+		d := &taskDetail{task: task}
+		d.view = loadTaskDetail(ctx, client, task, func(w string) {
+			d.warnings = append(d.warnings, w)
+		})
+		sortBids(d.view.Bids, sortByAmount)
+		if strings.EqualFold(task.Status, "completed") {
+			d.releaseNote = releaseNote(ctx, client)
+		}
+		return detailLoadedMsg{taskID: taskID, detail: d}
+	}
+}
+
+// modalKind is the action a modal performs
+type modalKind int
+
+const (
+	modalBid modalKind = iota
+	modalAccept
+	modalComplete
+	modalVerify
+)
+
+var modalTitles = map[modalKind]string{
+	modalBid:      "Place a bid",
+	modalAccept:   "Accept a bid",
+	modalComplete: "Mark the task complete",
+	modalVerify:   "Verify the work",
+}
+
+// actionModal is an open action form
+type actionModal struct {
+	kind    modalKind
+	inputs  []textinput.Model
+	focus   int
+	bid     int    // accept: index of the selected bid
+	confirm string // question asked before moving funds
+	running bool
+	err     string
+	// retryKey is sent on the next submit after an unknown outcome, so the
+	// API replays the first attempt instead of running it twice
+	retryKey string
+}
+
+func newInput(placeholder, value string, limit int) textinput.Model {
+	in := textinput.New()
+	in.Placeholder = placeholder
+	in.SetValue(value)
+	in.CharLimit = limit
+	in.Width = 40
+	return in
+}
+
+// openModal starts an action on the detail task, if its status allows it
+func (m *dashboardModel) openModal(kind modalKind) tea.Cmd {
+	d := m.detail
+	if d == nil {
+		return nil
+	}
+	status := strings.ToLower(d.task.Status)
+	var need string
+	switch kind {
+	case modalBid, modalAccept:
+		need = "posted"
+	case modalComplete:
+		need = "in_progress"
+	case modalVerify:
+		need = "completed"
+	}
+	if status != need {
+		m.flash = errorStyle.Render(fmt.Sprintf("Cannot %s: task is %s, not %s",
+			strings.ToLower(modalTitles[kind]), d.task.Status, need))
+		return nil
+	}
+
+	modal := &actionModal{kind: kind}
+	switch kind {
+	case modalBid:
+		modal.inputs = []textinput.Model{
+			newInput(strings.TrimSpace("Amount "+d.task.Currency), "", 20),
+			newInput("Message (optional)", "", 500),
+		}
+	case modalAccept:
+		if len(d.view.Bids) == 0 {
+			m.flash = errorStyle.Render("No bids to accept yet")
+			return nil
+		}
+	case modalComplete:
+		agent := firstNonEmpty(m.agentID, d.task.AssignedAgent)
+		modal.inputs = []textinput.Model{
+			newInput("Agent ID", agent, 100),
+			newInput("Delivery URL, e.g. https://github.com/you/repo/pull/1", "", maxDeliveryURL),
+		}
+		if agent != "" {
+			modal.focus = 1
+		}
+	case modalVerify:
+		recipient := firstNonEmpty(d.task.AssignedAgent, "the assigned agent")
+		modal.confirm = fmt.Sprintf("Release %.2f %s from escrow to %s?", d.escrowed(), d.task.Currency, recipient)
+	}
+
+	m.modal = modal
+	m.flash = ""
+	if len(modal.inputs) > 0 {
+		return modal.inputs[modal.focus].Focus()
+	}
+	return nil
+}
+
+// updateModal handles keys while a modal is open
+func (m dashboardModel) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	modal := m.modal
+	if modal.running {
+		return m, nil
+	}
+
+	if modal.confirm != "" {
+		switch msg.String() {
+		case "y", "Y":
+			modal.running = true
+			modal.err = ""
+			return m, m.runAction()
+		case "n", "N", "esc":
+			if modal.kind == modalVerify {
+				m.modal = nil
+			} else {
+				modal.confirm = ""
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.modal = nil
+		return m, nil
+	case "up", "down":
+		if modal.kind == modalAccept {
+			n := len(m.detail.view.Bids)
+			if msg.String() == "up" {
+				modal.bid = (modal.bid - 1 + n) % n
+			} else {
+				modal.bid = (modal.bid + 1) % n
+			}
+			// Another bid is another request
+			modal.retryKey = ""
+			return m, nil
+		}
+		return m, modal.moveFocus(msg.String() == "down")
+	case "tab", "shift+tab":
+		return m, modal.moveFocus(msg.String() == "tab")
+	case "enter":
+		if len(modal.inputs) > 0 && modal.focus < len(modal.inputs)-1 {
+			return m, modal.moveFocus(true)
+		}
+		return m, m.submitModal()
+	}
+
+	if len(modal.inputs) == 0 {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	before := modal.inputs[modal.focus].Value()
+	modal.inputs[modal.focus], cmd = modal.inputs[modal.focus].Update(msg)
+	if modal.inputs[modal.focus].Value() != before {
+		// Edited, it is another request
+		modal.retryKey = ""
+	}
+	return m, cmd
+}
+
+func (a *actionModal) moveFocus(forward bool) tea.Cmd {
+	if len(a.inputs) == 0 {
+		return nil
+	}
+	a.inputs[a.focus].Blur()
+	if forward {
+		a.focus = (a.focus + 1) % len(a.inputs)
+	} else {
+		a.focus = (a.focus - 1 + len(a.inputs)) % len(a.inputs)
+	}
+	return a.inputs[a.focus].Focus()
+}
+
+// submitModal validates the form, then runs the action or asks to
+// confirm it
+func (m *dashboardModel) submitModal() tea.Cmd {
+	modal, d := m.modal, m.detail
+	modal.err = ""
+
+	switch modal.kind {
+	case modalBid:
+		amount, err := strconv.ParseFloat(strings.TrimSpace(modal.inputs[0].Value()), 64)
+		if err != nil || amount <= 0 {
+			modal.err = "Amount must be a positive number"
+			return nil
+		}
+	case modalAccept:
+		b := d.view.Bids[modal.bid]
+		modal.confirm = fmt.Sprintf("Accept %s's bid of %.2f %s? The funds are locked in escrow until the work is verified.",
+			firstNonEmpty(b.AgentID, "an agent"), b.Amount, d.task.Currency)
+		return nil
+	case modalComplete:
+		if strings.TrimSpace(modal.inputs[0].Value()) == "" {
+			modal.err = "Agent ID is required"
+			return nil
+		}
+		if err := validateDeliveryURL(strings.TrimSpace(modal.inputs[1].Value())); err != nil {
+			modal.err = strings.ReplaceAll(err.Error(), "--delivery-url", "Delivery URL")
+			return nil
+		}
+	}
+
+	modal.running = true
+	return m.runAction()
+}
+
+// runAction sends the modal's request through the local journal
+func (m dashboardModel) runAction() tea.Cmd {
+	client, modal, task := m.client, m.modal, m.detail.task
+	ctx, retryKey := m.ctx, modal.retryKey
+	value := func(i int) string { return strings.TrimSpace(modal.inputs[i].Value()) }
+
+	var op, text string
+	var params []interface{}
+	var send func(key string) (string, error)
+
+	switch modal.kind {
+	case modalBid:
+		amount, _ := strconv.ParseFloat(value(0), 64)
+		message := value(1)
+		agentID := m.agentID
+		// The same parameters as task bid, so the journal entries match
+		op, params = "task bid", []interface{}{task.ID, amount, message}
+		if agentID != "" {
+			params = append(params, agentID)
+		}
+		text = fmt.Sprintf("Bid of %.2f %s placed", amount, task.Currency)
+		send = func(key string) (string, error) {
+			var bid *gigclaw.Bid
+			var err error
+			if agentID != "" {
+				bid, err = client.PlaceAgentBid(ctx, task.ID, agentID, amount, message, gigclaw.IdempotencyKey(key))
+			} else {
+				bid, err = client.PlaceBid(ctx, task.ID, amount, message, gigclaw.IdempotencyKey(key))
+			}
+			if err != nil {
+				return "", err
+			}
+			return bid.ID, nil
+		}
+	case modalAccept:
+		bid := m.detail.view.Bids[modal.bid]
+		op, params = "task accept", []interface{}{task.ID, bid.ID}
+		text = fmt.Sprintf("Accepted %s's bid; %.2f %s locked in escrow", bid.AgentID, bid.Amount, task.Currency)
+		send = func(key string) (string, error) {
+			return bid.ID, client.AcceptBid(ctx, task.ID, bid.ID, gigclaw.IdempotencyKey(key))
+		}
+	case modalComplete:
+		agentID, deliveryURL := value(0), value(1)
+		op, params = "task complete", []interface{}{task.ID, agentID, deliveryURL}
+		text = "Work delivered; waiting for the poster to verify"
+		send = func(key string) (string, error) {
+			_, err := client.CompleteTask(ctx, task.ID, agentID, deliveryURL, gigclaw.IdempotencyKey(key))
+			return task.ID, err
+		}
+	case modalVerify:
+		op, params = "task verify", []interface{}{task.ID}
+		text = "Work verified; " + strings.ToLower(firstNonEmpty(m.detail.releaseNote, "payment released"))
+		send = func(key string) (string, error) {
+			_, err := client.VerifyTask(ctx, task.ID, gigclaw.IdempotencyKey(key))
+			return task.ID, err
+		}
+	}
+
+	return func() tea.Msg {
+		// Warnings go in the message: printing them would corrupt the screen
+		res, err := journaled(op, retryKey, params, send)
+		msg := actionDoneMsg{taskID: task.ID, text: text, err: err}
+		if res != nil {
+			msg.warnings = res.Warnings
+		}
+		msg.retryKey, msg.retry = actionRetry(res, err)
+		return msg
+	}
+}
+
+// actionRetry returns the key to retry a failed action with from the
+// modal, and what to tell the user, when it may have gone through
+func actionRetry(res *journaledResult, err error) (key, text string) {
+	var unfinished *unfinishedError
+	switch {
+	case res != nil && res.Unknown:
+		return res.Key, shortError(err) + ". It may have gone through: press enter to retry safely"
+	case errors.As(err, &unfinished):
+		text = fmt.Sprintf("An identical %s from %s did not finish and may have gone through: ",
+			unfinished.Op, unfinished.CreatedAt.Local().Format("Jan 2 15:04"))
+		if unfinished.retryable() {
+			return unfinished.Key, text + "press enter to retry it safely"
+		}
+		// Too old for the API to replay: only a new key can send it again
+		return gigclaw.NewIdempotencyKey(), text + "check the task list, or press enter to send it again anyway"
+	}
+	return "", ""
+}
+
+// shortError returns the one-line form of an action error
+func shortError(err error) string {
+	var apiErr *gigclaw.APIError
+	if errors.As(err, &apiErr) && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	line, _, _ := strings.Cut(HandleAPIError(err).Error(), "\n")
+	return strings.TrimPrefix(line, color.RedString("✗")+" ")
+}
+
+// updateDetail handles detail and action messages. ok is false for other
+// messages.
+func (m dashboardModel) updateDetail(msg tea.Msg) (dashboardModel, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case detailLoadedMsg:
+		if msg.taskID != m.detailID {
+			// The pane moved on to another task
+			return m, nil, true
+		}
+		m.detailLoading = false
+		m.detailErr = msg.err
+		if msg.err == nil {
+			m.detail = msg.detail
+		}
+		return m, nil, true
+
+	case actionDoneMsg:
+		m.warning = strings.Join(msg.warnings, "; ")
+		if m.modal == nil {
+			return m, nil, true
+		}
+		if msg.err != nil {
+			m.modal.running = false
+			m.modal.confirm = ""
+			m.modal.err = firstNonEmpty(msg.retry, shortError(msg.err))
+			m.modal.retryKey = msg.retryKey
+			return m, nil, true
+		}
+		m.modal = nil
+		m.flash = successStyle.Render("✓ " + msg.text)
+		m.detailLoading = true
+		return m, tea.Batch(loadDetailCmd(m.ctx, m.client, msg.taskID), fetchTasksCmd(m.ctx, m.client)), true
+	}
+	return m, nil, false
+}
+
+// renderDetail renders the detail pane at the given outer width
+func (m dashboardModel) renderDetail(width int) string {
+	// Leave room for the border and padding of boxStyle
+	inner := max(width-6, 20)
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(labelStyle.Render(label) + " " + value + "\n")
+	}
+
+	switch {
+	case m.detailErr != nil:
+		b.WriteString(errorStyle.Render("✗ "+shortError(m.detailErr)) + "\n")
+	case m.detail == nil:
+		b.WriteString(m.spinner.View() + " Loading task...\n")
+	default:
+		d := m.detail
+		v := d.view
+		b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(colorSolanaGreen)).Width(inner).Render(v.Title))
+		b.WriteString("\n\n")
+		line("ID", v.ID)
+		line("Status", formatStatus(v.Status))
+		line("Budget", fmt.Sprintf("%.2f %s", v.Budget, v.Currency))
+		if v.PosterID != "" {
+			line("Poster", v.PosterID)
+		}
+		if v.AssignedAgent != "" {
+			line("Assigned", v.AssignedAgent)
+		}
+		if v.DeliveryURL != "" {
+			line("Delivery", v.DeliveryURL)
+		}
+		if len(v.Tags) > 0 {
+			line("Tags", strings.Join(v.Tags, ", "))
+		}
+		if v.Description != "" {
+			b.WriteString("\n" + dimStyle.Width(inner).Render(truncate(v.Description, 300)) + "\n")
+		}
+
+		b.WriteString("\n")
+		switch e := v.Escrow; {
+		case e == nil:
+			line("Escrow", dimStyle.Render("unavailable"))
+		case e.ReleasedAt != "":
+			line("Escrow", fmt.Sprintf("%.2f %s released", e.Amount, v.Currency))
+		case e.Held:
+			line("Escrow", fmt.Sprintf("%.2f %s locked", e.Amount, v.Currency))
+		default:
+			line("Escrow", dimStyle.Render("nothing locked"))
+		}
+		switch tx := v.Transaction; {
+		case tx != nil && tx.Failed:
+			line("Chain", errorStyle.Render("✗ "+tx.Status))
+		case tx != nil:
+			line("Chain", "✓ "+tx.Status)
+		case v.Blockchain != nil:
+			line("Chain", v.Blockchain.Status)
+		default:
+			line("Chain", dimStyle.Render("not on chain"))
+		}
+
+		b.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Bids (%d)", len(v.Bids))) + "\n")
+		if len(v.Bids) == 0 {
+			b.WriteString(dimStyle.Render("No bids yet") + "\n")
+		}
+		for i, bid := range v.Bids {
+			if i == 8 {
+				b.WriteString(dimStyle.Render(fmt.Sprintf("… %d more", len(v.Bids)-i)) + "\n")
+				break
+			}
+			b.WriteString(bidLine(bid, v.Currency) + "\n")
+		}
+		for _, w := range d.warnings {
+			b.WriteString(dimStyle.Width(inner).Render("⚠ "+w) + "\n")
+		}
+	}
+
+	if m.flash != "" {
+		b.WriteString("\n" + m.flash + "\n")
+	}
+	b.WriteString("\n" + helpStyle.Render("b: Bid  a: Accept  c: Complete  v: Verify"))
+	return boxStyle.Width(inner + 4).Render(b.String())
+}
+
+func bidLine(bid taskBidView, currency string) string {
+	rep := "-"
+	if bid.Reputation != nil {
+		rep = fmt.Sprintf("%.1f", *bid.Reputation)
+	}
+	text := fmt.Sprintf("%-10s %8.2f %s  rep %-5s %s",
+		truncate(bid.AgentID, 10), bid.Amount, currency, rep, bid.Status)
+	if bid.Status == "accepted" {
+		return successStyle.Render(text)
+	}
+	return text
+}
+
+// renderModal renders the open modal
+func (m dashboardModel) renderModal() string {
+	modal, d := m.modal, m.detail
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(modalTitles[modal.kind]))
+	b.WriteString(dimStyle.Render("  " + truncate(d.task.Title, 40)))
+	b.WriteString("\n\n")
+
+	switch {
+	case modal.confirm != "":
+		b.WriteString(lipgloss.NewStyle().Width(60).Render(modal.confirm) + "\n")
+		if modal.kind == modalVerify && d.releaseNote != "" {
+			b.WriteString(dimStyle.Render(d.releaseNote) + "\n")
+		}
+	case modal.kind == modalAccept:
+		for i, bid := range d.view.Bids {
+			cursor := "  "
+			if i == modal.bid {
+				cursor = successStyle.Render("▸ ")
+			}
+			b.WriteString(cursor + bidLine(bid, d.task.Currency) + "\n")
+		}
+	default:
+		for _, in := range modal.inputs {
+			b.WriteString(in.View() + "\n")
+		}
+	}
+
+	if modal.err != "" {
+		b.WriteString("\n" + errorStyle.Render("✗ "+modal.err) + "\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case modal.running:
+		b.WriteString(m.spinner.View() + " Sending...")
+	case modal.confirm != "":
+		b.WriteString(helpStyle.Render("y: Confirm  n: Cancel"))
+	case modal.kind == modalAccept:
+		b.WriteString(helpStyle.Render("↑/↓: Choose bid  enter: Accept  esc: Cancel"))
+	default:
+		b.WriteString(helpStyle.Render("tab: Next field  enter: Submit  esc: Cancel"))
+	}
+	return modalStyle.Render(b.String())
+}
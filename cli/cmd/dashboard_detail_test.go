@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+func TestDashboardBid(t *testing.T) {
+	tests := []struct {
+		name    string
+		agentID string
+	}{
+		{"as agent", "agent-7"},
+		{"anonymous", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempHome(t)
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method+" "+r.URL.Path != "POST /api/tasks/t1/bid" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Write([]byte(`{"message":"Bid placed","bid":{"id":"b1","agentId":"agent-7","amount":120,"createdAt":1767229200000,"accepted":false}}`))
+			}))
+			defer srv.Close()
+			client, err := gigclaw.NewClient(gigclaw.WithBaseURL(srv.URL), gigclaw.WithRetryPolicy(gigclaw.NoRetry))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := dashboardModel{client: client, ctx: context.Background(), agentID: tt.agentID}
+			m.detail = &taskDetail{task: &gigclaw.Task{ID: "t1", Status: "posted", Currency: "USDC"}}
+			m.openModal(modalBid)
+			m.modal.inputs[0].SetValue("120")
+			m.modal.inputs[1].SetValue("Can start today")
+
+			done, ok := m.submitModal()().(actionDoneMsg)
+			if !ok || done.err != nil {
+				t.Fatalf("action = %+v", done)
+			}
+			if body["amount"] != 120.0 || body["message"] != "Can start today" {
+				t.Errorf("body = %v", body)
+			}
+			if agent, ok := body["agentId"]; (tt.agentID == "" && ok) || (tt.agentID != "" && agent != tt.agentID) {
+				t.Errorf("bid as %v, want %q", agent, tt.agentID)
+			}
+		})
+	}
+}
+
+func TestDashboardRetry(t *testing.T) {
+	useTempHome(t)
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			// The bid may have been placed before the gateway gave up
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"error":"Bad gateway"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"Bid placed","bid":{"id":"b1","agentId":"agent-7","amount":120,"createdAt":1767229200000,"accepted":false}}`))
+	}))
+	defer srv.Close()
+	client, err := gigclaw.NewClient(gigclaw.WithBaseURL(srv.URL), gigclaw.WithRetryPolicy(gigclaw.NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := dashboardModel{client: client, ctx: context.Background(), agentID: "agent-7"}
+	m.detail = &taskDetail{task: &gigclaw.Task{ID: "t1", Status: "posted", Currency: "USDC"}}
+	submit := func() {
+		t.Helper()
+		m.openModal(modalBid)
+		m.modal.inputs[0].SetValue("120")
+		m.modal.inputs[1].SetValue("Can start today")
+		m, _, _ = m.updateDetail(m.submitModal()())
+	}
+
+	submit()
+	if m.modal == nil || m.modal.retryKey != keys[0] {
+		t.Fatalf("modal = %+v, want the key %s to retry with", m.modal, keys[0])
+	}
+	// The hint is for the dashboard, not the command line
+	if !strings.Contains(m.modal.err, "press enter") || strings.Contains(m.modal.err, "--") {
+		t.Errorf("error = %q", m.modal.err)
+	}
+
+	// Closing the modal loses the key, but the journal still offers it
+	m.modal = nil
+	submit()
+	if m.modal == nil || m.modal.retryKey != keys[0] || len(keys) != 1 {
+		t.Fatalf("reopened: modal %+v, sent %d times", m.modal, len(keys))
+	}
+
+	m, _, _ = m.updateDetail(m.submitModal()())
+	if m.modal != nil || len(keys) != 2 || keys[1] != keys[0] {
+		t.Errorf("retry: modal %+v, keys %v", m.modal, keys)
+	}
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The dashboard keeps its task list current from the server's event
+// stream. While the stream is down it polls instead, backing off from
+// minPollInterval to maxPollInterval, and resyncs once the stream is back.
+// While live it still resyncs every resyncInterval, to pick up changes
+// the server does not announce.
+
+// connState is the dashboard's link to live updates
+type connState int
+
+const (
+	connConnecting connState = iota
+	connLive                 // receiving events
+	connPolling              // stream down, polling
+)
+
+const (
+	minPollInterval = 5 * time.Second
+	maxPollInterval = time.Minute
+	resyncInterval  = 2 * time.Minute
+)
+
+type (
+	streamStartedMsg struct{ stream *gigclaw.Stream }
+	streamFailedMsg  struct{ err error }
+	streamStateMsg   struct {
+		state gigclaw.StreamState
+		err   error
+	}
+	streamClosedMsg struct{ stream *gigclaw.Stream }
+	eventMsg        struct {
+		stream *gigclaw.Stream
+		event  gigclaw.Event
+	}
+	resubscribeMsg struct{}
+
+	// pollMsg fires a poll or, while live, a resync; stale sequence
+	// numbers are ignored, so only one chain is ever active
+	pollMsg int
+
+	pollResultMsg struct {
+		seq   int
+		tasks []gigclaw.Task
+		err   error
+	}
+)
+
+// subscribeCmd opens the event stream, reporting state changes on states
+func subscribeCmd(ctx context.Context, client *gigclaw.Client, states chan streamStateMsg) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := client.Subscribe(ctx, gigclaw.StreamOptions{
+			Channels:  []string{gigclaw.ChannelAll},
+			UserAgent: "GigClaw-Dashboard gigclaw-cli",
+			OnStateChange: func(state gigclaw.StreamState, err error) {
+				select {
+				case states <- streamStateMsg{state, err}:
+				case <-ctx.Done():
+				}
+			},
+		})
+		if err != nil {
+			return streamFailedMsg{err}
+		}
+		return streamStartedMsg{stream}
+	}
+}
+
+func waitForState(states chan streamStateMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-states
+	}
+}
+
+func waitForEvent(stream *gigclaw.Stream) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-stream.Events()
+		if !ok {
+			return streamClosedMsg{stream}
+		}
+		return eventMsg{stream, ev}
+	}
+}
+
+func pollCmd(ctx context.Context, client *gigclaw.Client, seq int) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := client.ListTasks(ctx)
+		return pollResultMsg{seq: seq, tasks: tasks, err: err}
+	}
+}
+
+// pollInterval returns the wait before the given poll (0-based)
+func pollInterval(attempt int) time.Duration {
+	d := minPollInterval
+	for i := 0; i < attempt && d < maxPollInterval; i++ {
+		d *= 2
+	}
+	if d > maxPollInterval {
+		d = maxPollInterval
+	}
+	return d
+}
+
+// startPolling begins a new polling chain, cancelling any previous one
+func (m *dashboardModel) startPolling() tea.Cmd {
+	m.conn = connPolling
+	m.pollSeq++
+	m.pollAttempt = 0
+	return m.schedulePoll()
+}
+
+func (m *dashboardModel) schedulePoll() tea.Cmd {
+	seq := m.pollSeq
+	m.pollEvery = pollInterval(m.pollAttempt)
+	return tea.Tick(m.pollEvery, func(time.Time) tea.Msg { return pollMsg(seq) })
+}
+
+// scheduleResync schedules the next resync while the stream is live
+func (m *dashboardModel) scheduleResync() tea.Cmd {
+	seq := m.pollSeq
+	return tea.Tick(resyncInterval, func(time.Time) tea.Msg { return pollMsg(seq) })
+}
+
+// updateLive handles stream and polling messages. ok is false for other
+// messages.
+func (m dashboardModel) updateLive(msg tea.Msg) (dashboardModel, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case streamStartedMsg:
+		m.stream = msg.stream
+		return m, waitForEvent(m.stream), true
+
+	case streamFailedMsg:
+		// No stream at all, e.g. the server has no /ws endpoint
+		m.streamErr = msg.err
+		cmds := []tea.Cmd{tea.Tick(pollInterval(m.subscribeAttempt), func(time.Time) tea.Msg { return resubscribeMsg{} })}
+		m.subscribeAttempt++
+		if m.conn != connPolling {
+			cmds = append(cmds, m.startPolling())
+		}
+		return m, tea.Batch(cmds...), true
+
+	case resubscribeMsg:
+		return m, subscribeCmd(m.ctx, m.client, m.states), true
+
+	case streamStateMsg:
+		cmds := []tea.Cmd{waitForState(m.states)}
+		switch msg.state {
+		case gigclaw.StreamConnected:
+			if m.conn == connPolling {
+				// Catch up on events missed while disconnected
+				cmds = append(cmds, fetchTasksCmd(m.ctx, m.client))
+			}
+			m.conn = connLive
+			m.streamErr = nil
+			m.subscribeAttempt = 0
+			m.pollSeq++
+			cmds = append(cmds, m.scheduleResync())
+		case gigclaw.StreamReconnecting:
+			m.streamErr = msg.err
+			cmds = append(cmds, m.startPolling())
+		case gigclaw.StreamClosed:
+			if msg.err != nil {
+				m.streamErr = msg.err
+				cmds = append(cmds, func() tea.Msg { return streamFailedMsg{msg.err} })
+			}
+		}
+		return m, tea.Batch(cmds...), true
+
+	case streamClosedMsg:
+		if m.stream == msg.stream {
+			m.stream = nil
+		}
+		return m, nil, true
+
+	case eventMsg:
+		ev := msg.event
+		m.applyEvent(ev)
+		m.lastEvent = fmt.Sprintf("%s %s", ev.Type, ev.TaskID)
+		m.lastUpdate = time.Now()
+		m.refreshRows()
+		return m, waitForEvent(msg.stream), true
+
+	case pollMsg:
+		if int(msg) != m.pollSeq || m.conn == connConnecting {
+			return m, nil, true
+		}
+		return m, pollCmd(m.ctx, m.client, m.pollSeq), true
+
+	case pollResultMsg:
+		if msg.seq != m.pollSeq || m.conn == connConnecting {
+			return m, nil, true
+		}
+		if msg.err == nil {
+			m.tasks = msg.tasks
+			m.lastUpdate = time.Now()
+			m.refreshRows()
+		}
+		if m.conn == connLive {
+			return m, m.scheduleResync(), true
+		}
+		m.pollAttempt++
+		return m, m.schedulePoll(), true
+	}
+	return m, nil, false
+}
+
+// eventBid is the part of a bid carried by events
+type eventBid struct {
+	ID            string  `json:"id"`
+	TaskID        string  `json:"taskId"`
+	AgentID       string  `json:"agentId"`
+	Amount        float64 `json:"amount"`
+	ProposedPrice float64 `json:"proposedPrice"`
+	Message       string  `json:"message"`
+	Status        string  `json:"status"`
+}
+
+func (b eventBid) bid() gigclaw.Bid {
+	amount := b.Amount
+	if amount == 0 {
+		amount = b.ProposedPrice
+	}
+	return gigclaw.Bid{ID: b.ID, AgentID: b.AgentID, Amount: amount, Message: b.Message, Status: b.Status}
+}
+
+// applyEvent updates the task list from an event
+func (m *dashboardModel) applyEvent(ev gigclaw.Event) {
+	switch ev.Type {
+	case gigclaw.EventTaskCreated, gigclaw.EventTaskCompleted:
+		var t gigclaw.Task
+		if ev.Decode(&t) != nil || t.ID == "" {
+			return
+		}
+		task := m.findTask(t.ID)
+		if task == nil {
+			m.tasks = append([]gigclaw.Task{{ID: t.ID}}, m.tasks...)
+			task = &m.tasks[0]
+		}
+		task.Title, task.Description = t.Title, t.Description
+		task.Budget, task.Currency, task.Tags = t.Budget, t.Currency, t.Tags
+		task.RequiredSkills, task.PosterID = t.RequiredSkills, t.PosterID
+		task.Status = t.Status
+		if !t.CreatedAt.IsZero() {
+			task.CreatedAt = t.CreatedAt
+		}
+		if len(t.Bids) > 0 {
+			task.Bids = t.Bids
+		}
+		if t.AssignedAgent != "" {
+			task.AssignedAgent = t.AssignedAgent
+		}
+		if t.DeliveryURL != "" {
+			task.DeliveryURL = t.DeliveryURL
+		}
+
+	case gigclaw.EventBidPlaced, gigclaw.EventNewBid, gigclaw.EventBidAccepted:
+		var b eventBid
+		if ev.Decode(&b) != nil {
+			return
+		}
+		task := m.findTask(firstNonEmpty(ev.TaskID, b.TaskID))
+		if task == nil {
+			return
+		}
+		bid := b.bid()
+		i := findBid(task.Bids, bid)
+		if i < 0 {
+			task.Bids = append(task.Bids, bid)
+			i = len(task.Bids) - 1
+		}
+		if ev.Type == gigclaw.EventBidAccepted {
+			task.Bids[i].Accepted = true
+			task.Status = "in_progress"
+			task.AssignedAgent = bid.AgentID
+		}
+
+	case gigclaw.EventStatusChange:
+		if task := m.findTask(ev.TaskID); task != nil && ev.NewStatus != "" {
+			task.Status = ev.NewStatus
+		}
+
+	case gigclaw.EventTaskDeleted:
+		for i, t := range m.tasks {
+			if t.ID == ev.TaskID {
+				m.tasks = append(m.tasks[:i:i], m.tasks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (m *dashboardModel) findTask(id string) *gigclaw.Task {
+	for i := range m.tasks {
+		if m.tasks[i].ID == id {
+			return &m.tasks[i]
+		}
+	}
+	return nil
+}
+
+// findBid returns the index of bid in bids, matching by ID or, for bids
+// without one, by agent and amount
+func findBid(bids []gigclaw.Bid, bid gigclaw.Bid) int {
+	for i, b := range bids {
+		if bid.ID != "" && b.ID == bid.ID {
+			return i
+		}
+		if bid.ID == "" && b.AgentID == bid.AgentID && b.Amount == bid.Amount {
+			return i
+		}
+	}
+	return -1
+}
+
+// connStatus renders the connection indicator for the status line
+func (m dashboardModel) connStatus() string {
+	switch m.conn {
+	case connLive:
+		return statusOnline + " " + normalStyle.Render("Live")
+	case connPolling:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA500")).Render("◐") + " " +
+			normalStyle.Render(fmt.Sprintf("Polling every %s", m.pollEvery)) +
+			dimStyle.Render(" (live updates unavailable)")
+	default:
+		return m.spinner.View() + " " + normalStyle.Render("Connecting")
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// Event payloads as broadcast by api/src/routes/tasks.ts
+const (
+	createdEvent   = `{"id":"t1","title":"Audit token program","description":"Review the escrow program","budget":150,"deadline":"2026-02-01T00:00:00.000Z","requiredSkills":["rust"],"posterId":"alice","status":"posted","assignedAgent":null,"bids":[],"createdAt":1767225600000,"completedAt":null,"onChain":false,"signature":null}`
+	bidEvent       = `{"id":"b1","agentId":"agent-7","amount":135,"createdAt":1767229200000,"accepted":false}`
+	acceptedEvent  = `{"id":"b1","agentId":"agent-7","amount":135,"createdAt":1767229200000,"accepted":true}`
+	completedEvent = `{"id":"t1","title":"Audit token program","description":"Review the escrow program","budget":150,"requiredSkills":["rust"],"posterId":"alice","status":"completed","assignedAgent":"agent-7","bids":[{"id":"b1","agentId":"agent-7","amount":135,"createdAt":1767229200000,"accepted":true}],"createdAt":1767225600000,"completedAt":1767312000000,"deliveryUrl":"https://example.com/pr/1"}`
+)
+
+func TestApplyEvent(t *testing.T) {
+	var m dashboardModel
+
+	steps := []struct {
+		event  gigclaw.Event
+		status string
+		bids   int
+	}{
+		{gigclaw.Event{Type: gigclaw.EventTaskCreated, TaskID: "t1", Data: json.RawMessage(createdEvent)}, "posted", 0},
+		{gigclaw.Event{Type: gigclaw.EventBidPlaced, TaskID: "t1", Data: json.RawMessage(bidEvent)}, "posted", 1},
+		{gigclaw.Event{Type: gigclaw.EventNewBid, TaskID: "t1", Data: json.RawMessage(bidEvent)}, "posted", 1},
+		{gigclaw.Event{Type: gigclaw.EventBidAccepted, TaskID: "t1", Data: json.RawMessage(acceptedEvent)}, "in_progress", 1},
+		{gigclaw.Event{Type: gigclaw.EventTaskCompleted, TaskID: "t1", Data: json.RawMessage(completedEvent)}, "completed", 1},
+		{gigclaw.Event{Type: gigclaw.EventStatusChange, TaskID: "t1", OldStatus: "completed", NewStatus: "verified"}, "verified", 1},
+	}
+	for _, step := range steps {
+		m.applyEvent(step.event)
+		if len(m.tasks) != 1 {
+			t.Fatalf("after %s: %d tasks, want 1", step.event.Type, len(m.tasks))
+		}
+		task := m.tasks[0]
+		if task.Status != step.status || len(task.Bids) != step.bids {
+			t.Errorf("after %s: status %s with %d bids, want %s with %d", step.event.Type, task.Status, len(task.Bids), step.status, step.bids)
+		}
+	}
+
+	task := m.tasks[0]
+	if task.CreatedAt.UnixMilli() != 1767225600000 || task.PosterID != "alice" {
+		t.Errorf("createdAt %v, poster %q", task.CreatedAt, task.PosterID)
+	}
+	if task.AssignedAgent != "agent-7" || !task.Bids[0].Accepted || task.DeliveryURL == "" {
+		t.Errorf("task = %+v", task)
+	}
+
+	m.applyEvent(gigclaw.Event{Type: gigclaw.EventTaskDeleted, TaskID: "t1"})
+	if len(m.tasks) != 0 {
+		t.Errorf("%d tasks after delete", len(m.tasks))
+	}
+}
+
+func TestLiveResync(t *testing.T) {
+	m := dashboardModel{conn: connLive, pollSeq: 3}
+
+	// A resync due while live fetches the task list
+	m, cmd, _ := m.updateLive(pollMsg(3))
+	if cmd == nil {
+		t.Fatal("live resync did not poll")
+	}
+	// and applies it before scheduling the next one
+	m, cmd, _ = m.updateLive(pollResultMsg{seq: 3, tasks: []gigclaw.Task{{ID: "t1"}}})
+	if cmd == nil || len(m.tasks) != 1 || m.pollAttempt != 0 {
+		t.Errorf("resync result: cmd %v, %d tasks, attempt %d", cmd != nil, len(m.tasks), m.pollAttempt)
+	}
+
+	// Chains from before a reconnect are dropped
+	if _, cmd, _ := m.updateLive(pollMsg(2)); cmd != nil {
+		t.Error("stale resync polled")
+	}
+}
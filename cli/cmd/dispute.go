@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var disputeCmd = &cobra.Command{
+	Use:   "dispute",
+	Short: "Raise and follow disputes over tasks",
+	Long: `Raise and follow disputes over tasks.
+
+When an agent fails to deliver, or a poster refuses to verify delivered
+work, either party can open a dispute. Both parties then submit evidence
+and an arbitrator resolves the dispute: refunding the poster, paying the
+agent, or splitting the escrowed funds.`,
+}
+
+var disputeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List disputes",
+	Long: `List disputes, newest first.
+
+With --agent, only the disputes the agent opened or responds to are listed,
+with a summary of their outcomes.`,
+	Example: `  gigclaw dispute list --status open
+  gigclaw dispute list --task 7f3a
+  gigclaw dispute list --agent agent-7 -o json`,
+	Args: cobra.NoArgs,
+	RunE: runDisputeList,
+}
+
+var disputeShowCmd = &cobra.Command{
+	Use:   "show <dispute-id>",
+	Short: "Show a dispute with its evidence and timeline",
+	Long: `Show a dispute with the evidence submitted by both parties and a
+timeline of its state changes.
+
+Table and CSV output list the timeline; JSON and YAML output carry the
+dispute, its evidence and the timeline.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDisputeShow,
+}
+
+var disputeStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show marketplace dispute statistics",
+	Args:  cobra.NoArgs,
+	RunE:  runDisputeStats,
+}
+
+var (
+	disputeListStatus    string
+	disputeListTask      string
+	disputeListInitiator string
+	disputeListAgent     string
+)
+
+// disputeStatuses are the statuses a dispute moves through
+var disputeStatuses = []string{gigclaw.DisputeOpen, gigclaw.DisputeUnderReview, gigclaw.DisputeResolved}
+
+func init() {
+	rootCmd.AddCommand(disputeCmd)
+	disputeCmd.AddCommand(disputeListCmd)
+	disputeCmd.AddCommand(disputeShowCmd)
+	disputeCmd.AddCommand(disputeStatsCmd)
+
+	disputeListCmd.Flags().StringVarP(&disputeListStatus, "status", "s", "", "Only disputes with this status: "+strings.Join(disputeStatuses, ", "))
+	disputeListCmd.Flags().StringVar(&disputeListTask, "task", "", "Only disputes over this task")
+	disputeListCmd.Flags().StringVar(&disputeListInitiator, "initiator", "", "Only disputes opened by this agent")
+	disputeListCmd.Flags().StringVar(&disputeListAgent, "agent", "", "Only disputes this agent is a party to")
+}
+
+func runDisputeList(cmd *cobra.Command, args []string) error {
+	if disputeListStatus != "" && !containsFold(disputeStatuses, disputeListStatus) {
+		return fmt.Errorf("invalid --status %q: must be one of %s", disputeListStatus, strings.Join(disputeStatuses, ", "))
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	filter := gigclaw.DisputeFilter{
+		Status:      strings.ToLower(disputeListStatus),
+		TaskID:      disputeListTask,
+		InitiatorID: disputeListInitiator,
+	}
+
+	var disputes []gigclaw.Dispute
+	var agent *gigclaw.AgentDisputes
+	if disputeListAgent != "" {
+		agent, err = client.ListAgentDisputes(cmd.Context(), disputeListAgent)
+		if err != nil {
+			return HandleAPIError(err)
+		}
+		// The agent route takes no filters
+		for _, d := range agent.Disputes {
+			if (filter.Status == "" || d.Status == filter.Status) &&
+				(filter.TaskID == "" || d.TaskID == filter.TaskID) &&
+				(filter.InitiatorID == "" || d.InitiatorID == filter.InitiatorID) {
+				disputes = append(disputes, d)
+			}
+		}
+	} else {
+		disputes, err = client.ListDisputes(cmd.Context(), filter)
+		if err != nil {
+			return HandleAPIError(err)
+		}
+	}
+
+	view := make(disputeListView, 0, len(disputes))
+	for _, d := range disputes {
+		view = append(view, newDisputeView(d))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(disputes) == 0 {
+			colorWarning.Println("  No disputes found.")
+			fmt.Println()
+			return
+		}
+
+		colorLabel.Printf("  Found ")
+		colorHighlight.Printf("%d", len(disputes))
+		colorLabel.Println(" dispute(s)")
+		if agent != nil {
+			s := agent.Stats
+			colorDim.Printf("  %s: %d opened, %d as respondent, %d open, %d resolved\n",
+				agent.AgentID, s.Initiated, s.Respondent, s.Open, s.Resolved)
+		}
+		fmt.Println()
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("ID")+"\t"+bold.Sprint("TASK")+"\t"+bold.Sprint("STATUS")+"\t"+
+			bold.Sprint("PARTIES")+"\t"+bold.Sprint("OPENED")+"\t"+bold.Sprint("REASON"))
+		for _, d := range disputes {
+			status := formatStatus(d.Status)
+			if d.Resolution != "" {
+				status += colorDim.Sprint(" (" + d.Resolution + ")")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				colorDim.Sprint(d.ID),
+				colorValue.Sprint(d.TaskID),
+				status,
+				colorValue.Sprint(d.InitiatorID+" → "+d.RespondentID),
+				colorDim.Sprint(d.CreatedAt.Local().Format("Jan 02 15:04")),
+				truncate(d.Reason, 40),
+			)
+		}
+		w.Flush()
+		fmt.Println()
+		colorDim.Println("  Show a dispute: gigclaw dispute show <dispute-id>")
+		fmt.Println()
+	})
+}
+
+func runDisputeShow(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	dispute, err := client.GetDispute(cmd.Context(), args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := newDisputeDetailView(*dispute)
+	return render(view, func() { printDisputeDetail(dispute) })
+}
+
+// printDisputeDetail prints the decorated dispute show output
+func printDisputeDetail(d *gigclaw.Dispute) {
+	label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+	fmt.Println()
+	colorPrimary.Printf("  Dispute %s\n", d.ID)
+	fmt.Println()
+
+	label("Task")
+	colorValue.Println(d.TaskID)
+	label("Status")
+	fmt.Println(formatStatus(d.Status))
+	label("Opened by")
+	colorValue.Println(d.InitiatorID)
+	label("Against")
+	colorValue.Println(d.RespondentID)
+	label("Reason")
+	colorValue.Println(d.Reason)
+	if d.Status == gigclaw.DisputeResolved {
+		label("Resolution")
+		colorSuccess.Println(d.Resolution)
+		if d.ArbitratorID != "" {
+			label("Arbitrator")
+			colorValue.Println(d.ArbitratorID)
+		}
+		if d.ResolutionReason != "" {
+			label("Ruling")
+			colorValue.Println(d.ResolutionReason)
+		}
+	}
+
+	// Evidence
+	fmt.Println()
+	colorHighlight.Printf("  Evidence (%d)\n", len(d.Evidence))
+	if len(d.Evidence) == 0 {
+		colorDim.Println("  None submitted yet")
+	}
+	for _, e := range d.Evidence {
+		fmt.Println()
+		colorValue.Printf("  %s", e.PartyID)
+		colorDim.Printf("  %s  %s\n", e.Type, e.SubmittedAt.Local().Format("Jan 02 15:04"))
+		for _, line := range strings.Split(strings.TrimRight(truncate(e.Content, 600), "\n"), "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+
+	// Timeline
+	fmt.Println()
+	colorHighlight.Println("  Timeline")
+	fmt.Println()
+	for _, e := range d.Timeline() {
+		colorDim.Printf("  %s  ", e.Time.Local().Format("Jan 02 15:04:05"))
+		switch e.Kind {
+		case "opened":
+			colorWarning.Printf("%-9s", "opened")
+			fmt.Printf(" by %s\n", e.PartyID)
+		case "evidence":
+			colorLabel.Printf("%-9s", "evidence")
+			fmt.Printf(" %s from %s\n", e.Detail, e.PartyID)
+		case "resolved":
+			colorSuccess.Printf("%-9s", "resolved")
+			fmt.Printf(" %s", d.Resolution)
+			if e.PartyID != "" {
+				fmt.Printf(" by %s", e.PartyID)
+			}
+			if d.ResolutionReason != "" {
+				colorDim.Printf(": %s", d.ResolutionReason)
+			}
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+
+	if d.Status != gigclaw.DisputeResolved {
+		colorDim.Println("  Add evidence:")
+		fmt.Println("    gigclaw dispute evidence " + d.ID + " --file <path>")
+		fmt.Println()
+	}
+}
+
+// disputeStatsView is the result of dispute stats
+type disputeStatsView struct {
+	Total                    int     `json:"total"`
+	Open                     int     `json:"open"`
+	UnderReview              int     `json:"underReview"`
+	Resolved                 int     `json:"resolved"`
+	RefundPoster             int     `json:"refundPoster"`
+	PayAgent                 int     `json:"payAgent"`
+	Split                    int     `json:"split"`
+	AverageResolutionSeconds float64 `json:"averageResolutionSeconds"` // 0 until one is resolved
+}
+
+func (s disputeStatsView) columns() []string {
+	return []string{"TOTAL", "OPEN", "UNDER REVIEW", "RESOLVED", "REFUND POSTER", "PAY AGENT", "SPLIT", "AVG RESOLUTION SECONDS"}
+}
+
+func (s disputeStatsView) rows() [][]string {
+	return [][]string{{
+		strconv.Itoa(s.Total), strconv.Itoa(s.Open), strconv.Itoa(s.UnderReview), strconv.Itoa(s.Resolved),
+		strconv.Itoa(s.RefundPoster), strconv.Itoa(s.PayAgent), strconv.Itoa(s.Split),
+		formatAmount(s.AverageResolutionSeconds),
+	}}
+}
+
+func runDisputeStats(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	stats, err := client.GetDisputeStats(cmd.Context())
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	avg := stats.AverageResolutionTime()
+	view := disputeStatsView{
+		Total:                    stats.Total,
+		Open:                     stats.Open,
+		UnderReview:              stats.UnderReview,
+		Resolved:                 stats.Resolved,
+		RefundPoster:             stats.Resolutions.RefundPoster,
+		PayAgent:                 stats.Resolutions.PayAgent,
+		Split:                    stats.Resolutions.Split,
+		AverageResolutionSeconds: avg.Seconds(),
+	}
+
+	return render(view, func() {
+		row := func(name string, n int) {
+			colorLabel.Printf("  %-15s ", name+":")
+			colorValue.Println(n)
+		}
+		fmt.Println()
+		colorPrimary.Println("  ⚖  Dispute Statistics")
+		fmt.Println()
+		row("Total", view.Total)
+		row("Open", view.Open)
+		row("Under review", view.UnderReview)
+		row("Resolved", view.Resolved)
+		if view.Resolved > 0 {
+			fmt.Println()
+			row("Refunded", view.RefundPoster)
+			row("Paid to agent", view.PayAgent)
+			row("Split", view.Split)
+			colorLabel.Printf("  %-15s ", "Avg. time:")
+			colorValue.Println(avg.Round(time.Minute))
+		}
+		fmt.Println()
+	})
+}
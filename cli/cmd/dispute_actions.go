@@ -0,0 +1,536 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+)
+
+var disputeOpenCmd = &cobra.Command{
+	Use:   "open <task-id>",
+	Short: "Open a dispute over a task",
+	Long: `Open a dispute over a task you posted or are assigned to.
+
+The other party is the respondent: the assigned agent when you are the
+poster, and the poster when you are the agent. A task can have only one
+unresolved dispute at a time.`,
+	Example: `  gigclaw dispute open 7f3a --reason "No delivery two weeks after the deadline"
+  gigclaw dispute open 7f3a --as agent-7 --reason "Work delivered but never verified"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDisputeOpen,
+}
+
+var disputeEvidenceCmd = &cobra.Command{
+	Use:   "evidence <dispute-id>",
+	Short: "Submit evidence to a dispute",
+	Long: `Submit evidence to a dispute you are a party to.
+
+Evidence is text: a message, a delivery link, a link to a screenshot, or the
+contents of a file such as a log or a transcript. Each --file is submitted
+as a separate piece of evidence; pass - to read from stdin. Each piece may
+be at most 2000 characters long; host larger files elsewhere and submit
+the link.`,
+	Example: `  gigclaw dispute evidence dispute-17 --message "The PR was merged on Jan 3"
+  gigclaw dispute evidence dispute-17 --type delivery -m https://github.com/you/repo/pull/1
+  gigclaw dispute evidence dispute-17 --file build.log --file chat.txt
+  git log --oneline | gigclaw dispute evidence dispute-17 --file -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDisputeEvidence,
+}
+
+var disputeResolveCmd = &cobra.Command{
+	Use:   "resolve <dispute-id>",
+	Short: "Resolve a dispute as arbitrator",
+	Long: `Resolve a dispute as arbitrator, deciding where the escrowed funds go:
+
+  refund_poster   Refund the poster
+  pay_agent       Pay the assigned agent
+  split           Split the funds between them
+
+This cannot be undone, so the command asks for confirmation. Without a
+terminal, pass --yes.`,
+	Example: `  gigclaw dispute resolve dispute-17 --resolution pay_agent --reason "Delivery matches the spec"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runDisputeResolve,
+}
+
+var (
+	disputeOpenReason  string
+	disputeOpenAs      string
+	disputeOpenAgainst string
+	disputeOpenIdemKey string
+
+	evidenceFiles   []string
+	evidenceMessage string
+	evidenceType    string
+	evidenceAs      string
+	evidenceIdemKey string
+
+	resolveResolution string
+	resolveReason     string
+	resolveAs         string
+	resolveYes        bool
+	resolveIdemKey    string
+)
+
+var (
+	evidenceTypes = []string{gigclaw.EvidenceMessage, gigclaw.EvidenceDelivery, gigclaw.EvidenceScreenshot, gigclaw.EvidenceOther}
+	resolutions   = []string{gigclaw.ResolutionRefundPoster, gigclaw.ResolutionPayAgent, gigclaw.ResolutionSplit}
+)
+
+func init() {
+	disputeCmd.AddCommand(disputeOpenCmd)
+	disputeCmd.AddCommand(disputeEvidenceCmd)
+	disputeCmd.AddCommand(disputeResolveCmd)
+
+	disputeOpenCmd.Flags().StringVarP(&disputeOpenReason, "reason", "r", "", "Why you are disputing the task (10-500 characters, required)")
+	disputeOpenCmd.Flags().StringVar(&disputeOpenAs, "as", "", "Your agent ID (default agent-id from the config file)")
+	disputeOpenCmd.Flags().StringVar(&disputeOpenAgainst, "against", "", "The respondent's agent ID (default the other party to the task)")
+	disputeOpenCmd.Flags().StringVar(&disputeOpenIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never opens twice")
+	disputeOpenCmd.MarkFlagRequired("reason")
+
+	disputeEvidenceCmd.Flags().StringArrayVarP(&evidenceFiles, "file", "f", []string{}, "Submit the contents of a text file, - for stdin (can specify multiple)")
+	disputeEvidenceCmd.Flags().StringVarP(&evidenceMessage, "message", "m", "", "Submit a message or link")
+	disputeEvidenceCmd.Flags().StringVarP(&evidenceType, "type", "t", "", "Evidence type: "+strings.Join(evidenceTypes, ", ")+" (default message for --message, other for files)")
+	disputeEvidenceCmd.Flags().StringVar(&evidenceAs, "as", "", "Your agent ID (default agent-id from the config file)")
+	disputeEvidenceCmd.Flags().StringVar(&evidenceIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never submits twice")
+
+	disputeResolveCmd.Flags().StringVarP(&resolveResolution, "resolution", "r", "", "Decision: "+strings.Join(resolutions, ", ")+" (required)")
+	disputeResolveCmd.Flags().StringVar(&resolveReason, "reason", "", "Explanation of the decision (at most 1000 characters)")
+	disputeResolveCmd.Flags().StringVar(&resolveAs, "as", "", "Your arbitrator ID (default agent-id from the config file)")
+	disputeResolveCmd.Flags().BoolVarP(&resolveYes, "yes", "y", false, "Resolve without asking for confirmation")
+	disputeResolveCmd.Flags().StringVar(&resolveIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never resolves twice")
+	disputeResolveCmd.MarkFlagRequired("resolution")
+}
+
+// disputeActionView is the result of dispute open and resolve
+type disputeActionView struct {
+	disputeView
+	Action         string     `json:"action"`
+	Funds          *fundsView `json:"funds,omitempty"` // resolve only
+	IdempotencyKey string     `json:"idempotencyKey"`
+	Replayed       bool       `json:"replayed"`
+}
+
+func (a disputeActionView) columns() []string {
+	return append(append([]string{}, disputeColumns...), "IDEMPOTENCY KEY", "REPLAYED")
+}
+
+func (a disputeActionView) rows() [][]string {
+	return [][]string{append(a.row(), a.IdempotencyKey, strconv.FormatBool(a.Replayed))}
+}
+
+func runDisputeOpen(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	reason := strings.TrimSpace(disputeOpenReason)
+	if n := utf8.RuneCountInString(reason); n < gigclaw.MinDisputeReason || n > gigclaw.MaxDisputeReason {
+		return fmt.Errorf("--reason must be %d-%d characters long, not %d", gigclaw.MinDisputeReason, gigclaw.MaxDisputeReason, n)
+	}
+	initiator, err := actingAgent(disputeOpenAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	switch strings.ToLower(task.Status) {
+	case "posted", "cancelled":
+		return friendlyError(nil,
+			fmt.Sprintf("Cannot dispute task %s: it is %s", task.ID, task.Status),
+			nil,
+			"Disputes are for tasks with an accepted bid",
+			"To withdraw a task nobody is working on: gigclaw task cancel "+task.ID)
+	}
+
+	respondent := disputeOpenAgainst
+	if respondent == "" {
+		switch initiator {
+		case task.PosterID:
+			respondent = task.AssignedAgent
+		case task.AssignedAgent:
+			respondent = task.PosterID
+		}
+	}
+	if respondent == "" {
+		return friendlyError(nil,
+			fmt.Sprintf("Cannot tell who %s is disputing task %s with", initiator, task.ID),
+			[]string{fmt.Sprintf("Poster: %s, assigned agent: %s", firstNonEmpty(task.PosterID, "unknown"), firstNonEmpty(task.AssignedAgent, "none"))},
+			"Name the respondent with --against",
+			"Act as the poster or the assigned agent with --as")
+	}
+	if respondent == initiator {
+		return fmt.Errorf("cannot open a dispute against yourself (%s)", initiator)
+	}
+
+	req := gigclaw.OpenDisputeRequest{TaskID: task.ID, InitiatorID: initiator, RespondentID: respondent, Reason: reason}
+	var dispute *gigclaw.Dispute
+	res, err := runJournaled("dispute open", disputeOpenIdemKey, []interface{}{req}, func(key string) (string, error) {
+		var err error
+		dispute, err = client.OpenDispute(cmd.Context(), req, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return dispute.ID, nil
+	})
+	if errors.Is(err, gigclaw.ErrConflict) {
+		hints := []string{"Follow the open dispute: gigclaw dispute list --task " + task.ID}
+		if id := conflictingDispute(err); id != "" {
+			hints = []string{
+				"Follow it: gigclaw dispute show " + id,
+				"Add evidence to it: gigclaw dispute evidence " + id + " --file <path>",
+			}
+		}
+		return friendlyError(err, fmt.Sprintf("Task %s already has an unresolved dispute", task.ID), nil, hints...)
+	}
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if res.Replayed {
+		if dispute, err = client.GetDispute(cmd.Context(), res.Result); err != nil {
+			return HandleAPIError(err)
+		}
+	}
+
+	view := disputeActionView{
+		disputeView:    newDisputeView(*dispute),
+		Action:         "open",
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Dispute already opened with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("⚖  Dispute opened")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Dispute:")
+		colorValue.Println(dispute.ID)
+		colorLabel.Printf("  %-15s ", "Task:")
+		colorValue.Println(dispute.TaskID)
+		colorLabel.Printf("  %-15s ", "Against:")
+		colorValue.Println(dispute.RespondentID)
+		colorLabel.Printf("  %-15s ", "Status:")
+		fmt.Println(formatStatus(dispute.Status))
+		fmt.Println()
+		fmt.Println("Back your claim with evidence:")
+		fmt.Println("  gigclaw dispute evidence " + dispute.ID + " --file <path>")
+	})
+}
+
+// conflictingDispute returns the ID of the unresolved dispute reported by
+// a conflict error, if the server named it
+func conflictingDispute(err error) string {
+	var apiErr *gigclaw.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	var body struct {
+		DisputeID string `json:"disputeId"`
+	}
+	json.Unmarshal([]byte(apiErr.Body), &body)
+	return body.DisputeID
+}
+
+// evidenceItem is a piece of evidence read from the command line
+type evidenceItem struct {
+	source  string // "message", a file name or "stdin"
+	kind    string
+	content string
+}
+
+// evidenceResultView is a submitted piece of evidence
+type evidenceResultView struct {
+	DisputeID      string `json:"disputeId"`
+	Source         string `json:"source"`
+	Type           string `json:"type"`
+	Length         int    `json:"length"` // characters
+	IdempotencyKey string `json:"idempotencyKey"`
+	Replayed       bool   `json:"replayed"`
+}
+
+// evidenceListView is the result of dispute evidence
+type evidenceListView []evidenceResultView
+
+func (l evidenceListView) columns() []string {
+	return []string{"DISPUTE", "SOURCE", "TYPE", "LENGTH", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (l evidenceListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, e := range l {
+		rows = append(rows, []string{e.DisputeID, e.Source, e.Type, strconv.Itoa(e.Length), e.IdempotencyKey, strconv.FormatBool(e.Replayed)})
+	}
+	return rows
+}
+
+// readEvidence collects the evidence given by --message and --file
+func readEvidence() ([]evidenceItem, error) {
+	if evidenceType != "" && !containsFold(evidenceTypes, evidenceType) {
+		return nil, fmt.Errorf("invalid --type %q: must be one of %s", evidenceType, strings.Join(evidenceTypes, ", "))
+	}
+	kind := func(def string) string {
+		return strings.ToLower(firstNonEmpty(evidenceType, def))
+	}
+
+	var items []evidenceItem
+	if evidenceMessage != "" {
+		items = append(items, evidenceItem{"message", kind(gigclaw.EvidenceMessage), evidenceMessage})
+	}
+
+	stdinRead := false
+	for _, path := range evidenceFiles {
+		var data []byte
+		var err error
+		source := path
+		if path == "-" {
+			if stdinRead {
+				return nil, fmt.Errorf("--file - can only be given once")
+			}
+			stdinRead = true
+			source = "stdin"
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read evidence: %w", err)
+		}
+		if !utf8.Valid(data) {
+			return nil, friendlyError(nil,
+				fmt.Sprintf("%s is not a text file", source),
+				nil,
+				"Evidence is text; upload images and other binary files elsewhere",
+				"Then submit the link: --type screenshot --message <url>")
+		}
+		items = append(items, evidenceItem{source, kind(gigclaw.EvidenceOther), string(data)})
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no evidence given: pass --message, or --file (- for stdin)")
+	}
+	for i, item := range items {
+		items[i].content = strings.TrimRight(item.content, "\n")
+		switch n := utf8.RuneCountInString(items[i].content); {
+		case n == 0:
+			return nil, fmt.Errorf("evidence from %s is empty", item.source)
+		case n > gigclaw.MaxEvidenceContent:
+			return nil, friendlyError(nil,
+				fmt.Sprintf("Evidence from %s is %d characters long (at most %d allowed)", item.source, n, gigclaw.MaxEvidenceContent),
+				nil,
+				"Submit the relevant excerpt only",
+				"Or host the file elsewhere and submit the link: --type other --message <url>")
+		}
+	}
+	return items, nil
+}
+
+func runDisputeEvidence(cmd *cobra.Command, args []string) error {
+	disputeID := args[0]
+
+	items, err := readEvidence()
+	if err != nil {
+		return err
+	}
+	party, err := actingAgent(evidenceAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	dispute, err := client.GetDispute(cmd.Context(), disputeID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if dispute.Status == gigclaw.DisputeResolved && evidenceIdemKey == "" {
+		return friendlyError(nil,
+			fmt.Sprintf("Dispute %s was already resolved (%s)", dispute.ID, dispute.Resolution),
+			nil,
+			"See the ruling: gigclaw dispute show "+dispute.ID)
+	}
+	if party != dispute.InitiatorID && party != dispute.RespondentID {
+		return friendlyError(nil,
+			fmt.Sprintf("%s is not a party to dispute %s", party, dispute.ID),
+			[]string{fmt.Sprintf("Only %s and %s can submit evidence", dispute.InitiatorID, dispute.RespondentID)},
+			"Act as one of them with --as")
+	}
+
+	view := make(evidenceListView, 0, len(items))
+	for i, item := range items {
+		// Each piece is a separate request, so each needs its own key
+		key := evidenceIdemKey
+		if key != "" && len(items) > 1 {
+			key = fmt.Sprintf("%s-%d", key, i+1)
+		}
+
+		req := gigclaw.EvidenceRequest{PartyID: party, Type: item.kind, Content: item.content}
+		res, err := runJournaled("dispute evidence", key, []interface{}{dispute.ID, req}, func(key string) (string, error) {
+			_, err := client.SubmitEvidence(cmd.Context(), dispute.ID, req, gigclaw.IdempotencyKey(key))
+			return dispute.ID, err
+		})
+		if err != nil {
+			if i > 0 {
+				logger.Warning(fmt.Sprintf("Submitted %d of %d pieces of evidence before failing on %s", i, len(items), item.source))
+			}
+			return HandleAPIError(err)
+		}
+		view = append(view, evidenceResultView{
+			DisputeID:      dispute.ID,
+			Source:         item.source,
+			Type:           item.kind,
+			Length:         utf8.RuneCountInString(item.content),
+			IdempotencyKey: res.Key,
+			Replayed:       res.Replayed,
+		})
+	}
+
+	return render(view, func() {
+		fmt.Printf("✅ Submitted %d piece(s) of evidence to dispute %s\n", len(view), dispute.ID)
+		fmt.Println()
+		for _, e := range view {
+			colorLabel.Printf("  %-15s ", e.Type+":")
+			colorValue.Printf("%s", e.Source)
+			colorDim.Printf(" (%d characters)", e.Length)
+			if e.Replayed {
+				colorDim.Print(", already submitted")
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+		fmt.Println("Follow the dispute with:")
+		fmt.Println("  gigclaw dispute show " + dispute.ID)
+	})
+}
+
+func runDisputeResolve(cmd *cobra.Command, args []string) error {
+	disputeID := args[0]
+
+	resolution := strings.ToLower(resolveResolution)
+	if !containsFold(resolutions, resolution) {
+		return fmt.Errorf("invalid --resolution %q: must be one of %s", resolveResolution, strings.Join(resolutions, ", "))
+	}
+	if n := utf8.RuneCountInString(resolveReason); n > gigclaw.MaxResolutionReason {
+		return fmt.Errorf("--reason is %d characters long (at most %d allowed)", n, gigclaw.MaxResolutionReason)
+	}
+	arbitrator, err := actingAgent(resolveAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	dispute, err := client.GetDispute(cmd.Context(), disputeID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if dispute.Status == gigclaw.DisputeResolved && resolveIdemKey == "" {
+		return friendlyError(nil,
+			fmt.Sprintf("Dispute %s was already resolved (%s)", dispute.ID, dispute.Resolution),
+			nil,
+			"See the ruling: gigclaw dispute show "+dispute.ID)
+	}
+
+	funds := disputeFunds(cmd, client, dispute, resolution)
+	question := fmt.Sprintf("Resolve dispute %s with %s?", dispute.ID, resolution)
+	switch funds.Outcome {
+	case fundsRefund:
+		question += fmt.Sprintf(" %.2f %s is refunded to %s.", funds.Amount, funds.Currency, firstNonEmpty(funds.Recipient, "the poster"))
+	case fundsRelease:
+		question += fmt.Sprintf(" %.2f %s is paid to %s.", funds.Amount, funds.Currency, firstNonEmpty(funds.Recipient, "the agent"))
+	case fundsSplit:
+		question += fmt.Sprintf(" %.2f %s is split between the poster and the agent.", funds.Amount, funds.Currency)
+	}
+	if err := confirmAction(question, resolveYes); err != nil {
+		return err
+	}
+
+	req := gigclaw.ResolveDisputeRequest{ArbitratorID: arbitrator, Resolution: resolution, Reason: resolveReason}
+	res, err := runJournaled("dispute resolve", resolveIdemKey, []interface{}{dispute.ID, req}, func(key string) (string, error) {
+		updated, err := client.ResolveDispute(cmd.Context(), dispute.ID, req, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		dispute = updated
+		return dispute.ID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := disputeActionView{
+		disputeView:    newDisputeView(*dispute),
+		Action:         "resolve",
+		Funds:          &funds,
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Dispute already resolved with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("⚖  Dispute resolved")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Dispute:")
+		colorValue.Println(dispute.ID)
+		colorLabel.Printf("  %-15s ", "Resolution:")
+		colorSuccess.Println(firstNonEmpty(dispute.Resolution, resolution))
+		printFunds(funds)
+		fmt.Println()
+	})
+}
+
+// disputeFunds describes where a resolution sends the task's escrowed
+// funds. The task lookup is best effort.
+func disputeFunds(cmd *cobra.Command, client *gigclaw.Client, d *gigclaw.Dispute, resolution string) fundsView {
+	funds := fundsView{}
+	task, err := client.GetTask(cmd.Context(), d.TaskID)
+	if err != nil {
+		logger.Warning(fmt.Sprintf("Could not look up task %s: %v", d.TaskID, err))
+	} else {
+		funds.Amount = escrowedAmount(cmd.Context(), client, task)
+		funds.Currency = task.Currency
+	}
+
+	switch resolution {
+	case gigclaw.ResolutionRefundPoster:
+		funds.Outcome = fundsRefund
+		funds.Note = "The escrowed funds are returned to the poster"
+		if task != nil {
+			funds.Recipient = task.PosterID
+		}
+	case gigclaw.ResolutionPayAgent:
+		funds.Outcome = fundsRelease
+		funds.Note = "The escrowed funds are released to the assigned agent"
+		if task != nil {
+			funds.Recipient = task.AssignedAgent
+		}
+	default:
+		funds.Outcome = fundsSplit
+		funds.Note = "The escrowed funds are shared between the poster and the agent"
+	}
+	return funds
+}
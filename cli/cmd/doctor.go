@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -21,122 +22,332 @@ Checks:
 - API connectivity
 - Required tools are installed
 - Environment variables are set
-- Common configuration issues`,
+- Common configuration issues
+- The API key is not stored in plaintext
+
+An API key found in plaintext in the config file can be moved to the OS
+keyring or the encrypted credentials file: doctor offers to on a terminal,
+and --migrate does it without asking.`,
 	RunE: runDoctor,
 }
 
-func runDoctor(cmd *cobra.Command, args []string) error {
-	colorPrimary := color.New(color.FgHiCyan, color.Bold)
-	colorSuccess := color.New(color.FgGreen, color.Bold)
-	colorError := color.New(color.FgRed, color.Bold)
-	colorWarning := color.New(color.FgYellow)
-	colorLabel := color.New(color.FgCyan)
-	colorValue := color.New(color.FgWhite)
-	
-	fmt.Println()
-	colorPrimary.Println("╔══════════════════════════════════════════════════════════╗")
-	colorPrimary.Println("║           🔧 GIGCLAW DOCTOR - DIAGNOSTICS                 ║")
-	colorPrimary.Println("╚══════════════════════════════════════════════════════════╝")
-	fmt.Println()
+// Doctor check results
+const (
+	checkOK      = "ok"
+	checkInfo    = "info"
+	checkWarning = "warning"
+	checkError   = "error"
+)
+
+// doctorCheck is one diagnostic result
+type doctorCheck struct {
+	Section string `json:"section"`
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, info, warning or error
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// doctorView is the result of doctor
+type doctorView struct {
+	Checks   []doctorCheck `json:"checks"`
+	Issues   int           `json:"issues"`
+	Warnings int           `json:"warnings"`
+}
+
+func (d *doctorView) add(section, name, status, message, hint string) {
+	d.Checks = append(d.Checks, doctorCheck{section, name, status, message, hint})
+	switch status {
+	case checkError:
+		d.Issues++
+	case checkWarning:
+		d.Warnings++
+	}
+}
+
+func (d *doctorView) columns() []string {
+	return []string{"SECTION", "CHECK", "STATUS", "MESSAGE", "HINT"}
+}
+
+func (d *doctorView) rows() [][]string {
+	rows := make([][]string, 0, len(d.Checks))
+	for _, c := range d.Checks {
+		rows = append(rows, []string{c.Section, c.Name, c.Status, c.Message, c.Hint})
+	}
+	return rows
+}
+
+// doctorSections are the text headings of each section, in order
+var doctorSections = []struct{ id, title string }{
+	{"config", "📁 Configuration File"},
+	{"api", "🌐 API Configuration"},
+	{"credentials", "🔐 Credentials"},
+	{"connectivity", "📡 API Connectivity"},
+	{"system", "🖥️  System Information"},
+	{"shell", "🐚 Shell Configuration"},
+}
 
-	issues := 0
-	warnings := 0
+func runDoctor(cmd *cobra.Command, args []string) error {
+	view := &doctorView{Checks: []doctorCheck{}}
 
 	// Check 1: Config file
-	fmt.Println(colorLabel.Sprint("📁 Configuration File"))
 	home, _ := os.UserHomeDir()
 	configPath := filepath.Join(home, ".gigclaw", "config.yaml")
-	
+
 	if _, err := os.Stat(configPath); err == nil {
-		colorSuccess.Println("   ✅ Config file exists:", configPath)
-		
+		view.add("config", "config-file", checkOK, "Config file exists: "+configPath, "")
+
 		// Check if we can read it
 		if viper.ConfigFileUsed() != "" {
-			colorValue.Println("   ✓ Config loaded successfully")
+			view.add("config", "config-loaded", checkOK, "Config loaded successfully", "")
 		}
 	} else {
-		colorError.Println("   ❌ Config file not found:", configPath)
-		colorWarning.Println("   💡 Run 'gigclaw init' to create configuration")
-		issues++
+		view.add("config", "config-file", checkError, "Config file not found: "+configPath,
+			"Run 'gigclaw init' to create configuration")
 	}
-	fmt.Println()
 
 	// Check 2: API URL
-	fmt.Println(colorLabel.Sprint("🌐 API Configuration"))
 	apiURL := viper.GetString("api-url")
 	if apiURL == "" {
 		apiURL = "https://gigclaw-production.up.railway.app"
 	}
-	colorValue.Println("   API URL:", apiURL)
-	
-	// Check 3: API Connectivity
-	fmt.Println(colorLabel.Sprint("📡 API Connectivity"))
+	view.add("api", "api-url", checkInfo, "API URL: "+apiURL, "")
+
+	// Check 3: Credentials
+	plaintextKey := checkCredentials(view)
+	if plaintextKey != "" && doctorMigrate {
+		migrateAPIKey(view, plaintextKey)
+		plaintextKey = ""
+	}
+
+	// Check 4: API Connectivity
 	client, err := getAPIClient()
 	if err != nil {
-		colorError.Println("   ❌ Failed to create API client:", err)
-		issues++
+		view.add("connectivity", "api-client", checkError, fmt.Sprintf("Failed to create API client: %v", err), "")
 	} else {
-		health, err := client.Health()
+		health, err := client.Health(cmd.Context())
 		if err != nil {
-			colorError.Println("   ❌ API health check failed:", err)
-			issues++
+			view.add("connectivity", "api-health", checkError, fmt.Sprintf("API health check failed: %v", err), "")
 		} else {
-			colorSuccess.Println("   ✅ API is healthy")
-			colorValue.Println("   Version:", health.Version)
+			view.add("connectivity", "api-health", checkOK, "API is healthy", "")
+			view.add("connectivity", "api-version", checkInfo, "Version: "+health.Version, "")
 		}
 	}
-	fmt.Println()
 
-	// Check 4: Environment
-	fmt.Println(colorLabel.Sprint("🖥️  System Information"))
-	colorValue.Println("   OS:", runtime.GOOS)
-	colorValue.Println("   Arch:", runtime.GOARCH)
-	colorValue.Println("   Go Version:", runtime.Version())
-	fmt.Println()
+	// Check 5: Environment
+	view.add("system", "os", checkInfo, "OS: "+runtime.GOOS, "")
+	view.add("system", "arch", checkInfo, "Arch: "+runtime.GOARCH, "")
+	view.add("system", "go-version", checkInfo, "Go Version: "+runtime.Version(), "")
 
-	// Check 5: Shell
-	fmt.Println(colorLabel.Sprint("🐚 Shell Configuration"))
+	// Check 6: Shell
 	shell := os.Getenv("SHELL")
 	if shell != "" {
-		colorValue.Println("   Shell:", shell)
-		
+		view.add("shell", "shell", checkInfo, "Shell: "+shell, "")
+
 		// Check for completions
 		shellName := filepath.Base(shell)
 		completionPath := CompletionInstallPath(shellName)
 		if completionPath != "" {
 			if _, err := os.Stat(completionPath); err == nil {
-				colorSuccess.Println("   ✅ Completions installed")
+				view.add("shell", "completions", checkOK, "Completions installed", "")
 			} else {
-				colorWarning.Println("   ⚠️  Completions not installed")
-				colorWarning.Println("   💡 Run 'gigclaw completion", shellName, "| source' to enable")
-				warnings++
+				view.add("shell", "completions", checkWarning, "Completions not installed",
+					"Run 'gigclaw completion "+shellName+" | source' to enable")
 			}
 		}
 	} else {
-		colorWarning.Println("   ⚠️  Could not detect shell")
-		warnings++
+		view.add("shell", "shell", checkWarning, "Could not detect shell", "")
 	}
+
+	if err := render(view, func() { printDoctor(view) }); err != nil {
+		return err
+	}
+
+	// Offer to migrate only when someone is there to answer
+	if plaintextKey != "" && decorated() && isatty.IsTerminal(os.Stdin.Fd()) {
+		question := fmt.Sprintf("Move the API key to %s?", describeSecretStore(defaultSecretStore(doctorSecretStore)))
+		if confirmAction(question, false) != nil {
+			return nil
+		}
+		migrated := &doctorView{}
+		migrateAPIKey(migrated, plaintextKey)
+		fmt.Println()
+		for _, c := range migrated.Checks {
+			printCheck(c)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// checkCredentials adds the credentials checks, and returns the API key if
+// the config file holds it in plaintext
+func checkCredentials(view *doctorView) string {
+	path, err := configPath()
+	if err != nil {
+		view.add("credentials", "config-read", checkError, err.Error(), "")
+		return ""
+	}
+	config, err := readConfigFile(path)
+	if err != nil {
+		view.add("credentials", "config-read", checkError, err.Error(), "")
+		return ""
+	}
+
+	if info, err := os.Stat(path); err == nil && runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		view.add("credentials", "config-permissions", checkWarning,
+			fmt.Sprintf("Config file is readable by other users (%s)", info.Mode().Perm()),
+			"Run 'chmod 600 "+path+"'")
+	}
+
+	// The active profile's own settings; the others are only scanned
+	settings := config
+	profile := activeProfile()
+	if profile != defaultProfile {
+		view.add("credentials", "profile", checkInfo, "Profile: "+profile, "")
+		if own := profileSettings(config, profile, false); own != nil {
+			settings = own
+		}
+	}
+	for _, name := range append([]string{defaultProfile}, profileNames(config)...) {
+		other := config
+		if name != defaultProfile {
+			other = profileSettings(config, name, false)
+		}
+		if key, _ := other["api-key"].(string); key != "" && name != profile {
+			view.add("credentials", "api-key-plaintext", checkWarning, "Profile "+name+" stores its API key in plaintext",
+				"Run 'gigclaw --profile "+name+" doctor --migrate'")
+		}
+	}
+
+	plaintext, _ := settings["api-key"].(string)
+	ref, _ := settings["api-key-ref"].(string)
+	if _, ownKey := settings["api-key"]; !ownKey && ref == "" {
+		ref, _ = config["api-key-ref"].(string) // inherited from default
+	}
+	switch {
+	case plaintext != "":
+		store := defaultSecretStore(doctorSecretStore)
+		view.add("credentials", "api-key-plaintext", checkWarning, "API key stored in plaintext in "+path,
+			"Run 'gigclaw doctor --migrate' to move it to "+describeSecretStore(store))
+	case ref != "":
+		if _, err := readSecret(ref); err != nil {
+			view.add("credentials", "api-key-ref", checkError, err.Error(), "Run 'gigclaw init' to store the API key again")
+		} else {
+			backend, _, _ := parseSecretRef(ref)
+			view.add("credentials", "api-key-ref", checkOK, fmt.Sprintf("API key in %s (%s)", describeSecretStore(backend.name()), ref), "")
+		}
+	case os.Getenv(apiKeyEnv) == "":
+		view.add("credentials", "api-key", checkInfo, "No API key configured",
+			"Run 'gigclaw auth keys create' and 'gigclaw init' to add one")
+	}
+
+	if os.Getenv(apiKeyEnv) != "" {
+		view.add("credentials", "api-key-env", checkInfo, "API key from "+apiKeyEnv+" (overrides the config file)", "")
+	}
+	if (keyringBackend{}).available() {
+		view.add("credentials", "keyring", checkInfo, "OS keyring available", "")
+	} else {
+		view.add("credentials", "keyring", checkInfo, "No OS keyring; keys are kept in "+credentialsPath(), "")
+	}
+	return plaintext
+}
+
+// migrateAPIKey moves a plaintext API key from the config file to the
+// secret store, recording the outcome as a check
+func migrateAPIKey(view *doctorView, key string) {
+	store := defaultSecretStore(doctorSecretStore)
+	ref, err := storeSecret(store, activeProfile(), key)
+	if err != nil {
+		hint := "Set " + passphraseEnv + ", or run 'gigclaw doctor' in a terminal to enter a passphrase"
+		if store == secretKeyring {
+			hint = "Try 'gigclaw doctor --migrate --secret-store file'"
+		}
+		view.add("credentials", "api-key-migrated", checkError, "Could not move the API key: "+err.Error(), hint)
+		return
+	}
+	if _, err := updateConfig(map[string]interface{}{"api-key": nil, "api-key-ref": ref}); err != nil {
+		// Do not leave two copies behind when the config keeps the key
+		removeSecret(ref)
+		view.add("credentials", "api-key-migrated", checkError, "Could not update the config file: "+err.Error(), "")
+		return
+	}
+	view.add("credentials", "api-key-migrated", checkOK, fmt.Sprintf("API key moved to %s (%s)", describeSecretStore(store), ref), "")
+}
+
+// printDoctor prints the decorated doctor report
+func printDoctor(view *doctorView) {
+	colorPrimary := color.New(color.FgHiCyan, color.Bold)
+	colorSuccess := color.New(color.FgGreen, color.Bold)
+	colorError := color.New(color.FgRed, color.Bold)
+	colorWarning := color.New(color.FgYellow)
+	colorLabel := color.New(color.FgCyan)
+
+	fmt.Println()
+	colorPrimary.Println("╔══════════════════════════════════════════════════════════╗")
+	colorPrimary.Println("║           🔧 GIGCLAW DOCTOR - DIAGNOSTICS                 ║")
+	colorPrimary.Println("╚══════════════════════════════════════════════════════════╝")
 	fmt.Println()
 
+	for _, section := range doctorSections {
+		fmt.Println(colorLabel.Sprint(section.title))
+		for _, c := range view.Checks {
+			if c.Section != section.id {
+				continue
+			}
+			printCheck(c)
+		}
+		fmt.Println()
+	}
+
 	// Summary
 	fmt.Println(colorLabel.Sprint("📊 Summary"))
-	if issues == 0 && warnings == 0 {
+	if view.Issues == 0 && view.Warnings == 0 {
 		colorSuccess.Println("   ✅ All checks passed! GigClaw is ready to use.")
 	} else {
-		if issues > 0 {
-			colorError.Printf("   ❌ %d issue(s) found\n", issues)
+		if view.Issues > 0 {
+			colorError.Printf("   ❌ %d issue(s) found\n", view.Issues)
 		}
-		if warnings > 0 {
-			colorWarning.Printf("   ⚠️  %d warning(s) found\n", warnings)
+		if view.Warnings > 0 {
+			colorWarning.Printf("   ⚠️  %d warning(s) found\n", view.Warnings)
 		}
 		fmt.Println()
 		colorPrimary.Println("Run 'gigclaw init' to fix configuration issues")
 	}
-	
+
 	fmt.Println()
-	return nil
 }
 
+// printCheck prints one decorated doctor check
+func printCheck(c doctorCheck) {
+	colorSuccess := color.New(color.FgGreen, color.Bold)
+	colorError := color.New(color.FgRed, color.Bold)
+	colorWarning := color.New(color.FgYellow)
+	colorValue := color.New(color.FgWhite)
+
+	switch c.Status {
+	case checkOK:
+		colorSuccess.Println("   ✅ " + c.Message)
+	case checkWarning:
+		colorWarning.Println("   ⚠️  " + c.Message)
+	case checkError:
+		colorError.Println("   ❌ " + c.Message)
+	default:
+		colorValue.Println("   " + c.Message)
+	}
+	if c.Hint != "" {
+		colorWarning.Println("   💡 " + c.Hint)
+	}
+}
+
+var (
+	doctorMigrate     bool
+	doctorSecretStore string
+)
+
 func init() {
 	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorMigrate, "migrate", false, "Move a plaintext API key out of the config file without asking")
+	doctorCmd.Flags().StringVar(&doctorSecretStore, "secret-store", secretAuto, "Where to move the API key: auto, keyring or file")
 }
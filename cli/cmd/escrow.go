@@ -0,0 +1,593 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var escrowCmd = &cobra.Command{
+	Use:   "escrow",
+	Short: "Inspect and release escrowed payments",
+	Long: `Inspect and release escrowed payments.
+
+Accepting a bid locks its amount in escrow. Once the poster verifies the
+work, the server releases the payment to the agent after a dispute window,
+unless auto-release is disabled or the amount is outside the auto-release
+bounds; those payments need a manual release by an arbitrator.
+
+Amounts are shown in the task currency and in on-chain base units
+(1 USDC = 1,000,000 base units).`,
+}
+
+var escrowStatusCmd = &cobra.Command{
+	Use:   "status <task-id>",
+	Short: "Show the escrow state of a task",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runEscrowStatus,
+}
+
+var escrowActiveCmd = &cobra.Command{
+	Use:   "active",
+	Short: "List payments still locked in escrow",
+	Long: `List payments still locked in escrow, with when each is released.
+
+By default only escrows for tasks you posted or are assigned to are listed,
+using agent-id from the config file. Pass --agent for another agent or
+--all for the whole marketplace.
+
+The server does not report when a task was verified, so for verified work
+the release column shows the longest remaining wait: the dispute window.`,
+	Example: `  gigclaw escrow active
+  gigclaw escrow active --all -o csv`,
+	Args: cobra.NoArgs,
+	RunE: runEscrowActive,
+}
+
+var escrowReleaseCmd = &cobra.Command{
+	Use:   "release <task-id>",
+	Short: "Release a payment to the agent as arbitrator",
+	Long: `Release a task's escrowed payment to the assigned agent without waiting
+for the automatic release, for example when the amount is outside the
+auto-release bounds.
+
+This cannot be undone, so the command asks for confirmation. Without a
+terminal, pass --yes.`,
+	Example: `  gigclaw escrow release 7f3a --reason "Above the auto-release limit, delivery checked"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runEscrowRelease,
+}
+
+var escrowStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show marketplace escrow statistics and auto-release settings",
+	Args:  cobra.NoArgs,
+	RunE:  runEscrowStats,
+}
+
+var (
+	escrowActiveAgent string
+	escrowActiveAll   bool
+
+	escrowReleaseReason  string
+	escrowReleaseAs      string
+	escrowReleaseYes     bool
+	escrowReleaseIdemKey string
+)
+
+func init() {
+	rootCmd.AddCommand(escrowCmd)
+	escrowCmd.AddCommand(escrowStatusCmd)
+	escrowCmd.AddCommand(escrowActiveCmd)
+	escrowCmd.AddCommand(escrowReleaseCmd)
+	escrowCmd.AddCommand(escrowStatsCmd)
+
+	escrowActiveCmd.Flags().StringVar(&escrowActiveAgent, "agent", "", "Only escrows of tasks this agent posted or is assigned to (default agent-id from the config file)")
+	escrowActiveCmd.Flags().BoolVar(&escrowActiveAll, "all", false, "List every active escrow on the marketplace")
+	escrowActiveCmd.MarkFlagsMutuallyExclusive("agent", "all")
+
+	escrowReleaseCmd.Flags().StringVarP(&escrowReleaseReason, "reason", "r", "", "Why the payment is released manually (required)")
+	escrowReleaseCmd.Flags().StringVar(&escrowReleaseAs, "as", "", "Your arbitrator ID (default agent-id from the config file)")
+	escrowReleaseCmd.Flags().BoolVarP(&escrowReleaseYes, "yes", "y", false, "Release without asking for confirmation")
+	escrowReleaseCmd.Flags().StringVar(&escrowReleaseIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never releases twice")
+	escrowReleaseCmd.MarkFlagRequired("reason")
+}
+
+// formatMoney formats an amount with its base units, e.g.
+// "80.00 USDC (80000000 base units)"
+func formatMoney(amount float64, currency string) string {
+	return fmt.Sprintf("%.2f %s (%d base units)", amount, currency, gigclaw.ToBaseUnits(amount, currency))
+}
+
+// releaseWindow describes when a locked payment is released under cfg,
+// or "" if the configuration is unavailable
+func releaseWindow(cfg *gigclaw.EscrowConfig, status string, amount float64) string {
+	switch {
+	case cfg == nil:
+		return ""
+	case !cfg.Enabled:
+		return "manual release (auto-release disabled)"
+	case !cfg.AutoReleases(amount):
+		return "manual release (outside auto-release bounds)"
+	}
+	switch strings.ToLower(status) {
+	case "in_progress":
+		return "after completion and verification"
+	case "completed":
+		return "after verification"
+	case "verified":
+		if cfg.Delay() > 0 {
+			return "within " + cfg.Delay().String()
+		}
+		return "now"
+	default:
+		return ""
+	}
+}
+
+// escrowConfig returns the auto-release configuration, or nil if the
+// server does not report it
+func escrowConfig(ctx context.Context, client *gigclaw.Client) *gigclaw.EscrowConfig {
+	cfg, err := client.GetEscrowConfig(ctx)
+	if err != nil {
+		logger.Debug("Escrow config unavailable", err)
+		return nil
+	}
+	return cfg
+}
+
+// escrowStatusView is the result of escrow status
+type escrowStatusView struct {
+	TaskID           string  `json:"taskId"`
+	TaskStatus       string  `json:"taskStatus"`
+	Held             bool    `json:"held"`
+	Amount           float64 `json:"amount"`
+	AmountBaseUnits  int64   `json:"amountBaseUnits"`
+	Currency         string  `json:"currency"`
+	AgentID          string  `json:"agentId,omitempty"`
+	ReleaseScheduled bool    `json:"releaseScheduled"`
+	Release          string  `json:"release,omitempty"` // when a held payment is released
+	ReleasedAt       string  `json:"releasedAt,omitempty"`
+	TransactionHash  string  `json:"transactionHash,omitempty"`
+}
+
+func (s escrowStatusView) columns() []string {
+	return []string{"TASK", "TASK STATUS", "HELD", "AMOUNT", "BASE UNITS", "CURRENCY", "AGENT", "RELEASE", "RELEASED AT", "TRANSACTION"}
+}
+
+func (s escrowStatusView) rows() [][]string {
+	return [][]string{{
+		s.TaskID, s.TaskStatus, strconv.FormatBool(s.Held), formatAmount(s.Amount), strconv.FormatInt(s.AmountBaseUnits, 10),
+		s.Currency, s.AgentID, s.Release, s.ReleasedAt, s.TransactionHash,
+	}}
+}
+
+func runEscrowStatus(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	status, err := client.GetEscrowStatus(cmd.Context(), args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := escrowStatusView{
+		TaskID:           status.TaskID,
+		TaskStatus:       status.Status,
+		Held:             status.Held && status.Amount > 0,
+		Amount:           status.Amount,
+		Currency:         "USDC",
+		ReleaseScheduled: status.ReleaseScheduled,
+		ReleasedAt:       formatTime(status.ReleasedAt.Time),
+		TransactionHash:  status.TransactionHash,
+	}
+	if task, err := client.GetTask(cmd.Context(), args[0]); err == nil {
+		view.Currency = firstNonEmpty(task.Currency, view.Currency)
+		view.AgentID = task.AssignedAgent
+	} else {
+		logger.Debug("Task unavailable", err)
+	}
+	view.AmountBaseUnits = gigclaw.ToBaseUnits(view.Amount, view.Currency)
+	if view.Held {
+		view.Release = releaseWindow(escrowConfig(cmd.Context(), client), view.TaskStatus, view.Amount)
+	}
+
+	return render(view, func() {
+		label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+		fmt.Println()
+		colorPrimary.Printf("  Escrow for task %s\n", view.TaskID)
+		fmt.Println()
+		label("Task status")
+		fmt.Println(formatStatus(view.TaskStatus))
+
+		switch {
+		case view.ReleasedAt != "":
+			label("Released")
+			colorSuccess.Println(formatMoney(view.Amount, view.Currency))
+			if view.AgentID != "" {
+				label("Paid to")
+				colorValue.Println(view.AgentID)
+			}
+			label("At")
+			colorValue.Println(status.ReleasedAt.Local().Format("Jan 02 15:04"))
+			if view.TransactionHash != "" {
+				label("Transaction")
+				colorDim.Println(view.TransactionHash)
+			}
+		case view.Held:
+			label("Locked")
+			colorPrimary.Println(formatMoney(view.Amount, view.Currency))
+			if view.AgentID != "" {
+				label("Agent")
+				colorValue.Println(view.AgentID)
+			}
+			if view.Release != "" {
+				label("Release")
+				colorValue.Println(view.Release)
+			}
+		default:
+			colorDim.Println("  No funds locked (no bid accepted yet)")
+		}
+		fmt.Println()
+	})
+}
+
+// activeEscrowView is a payment locked in escrow
+type activeEscrowView struct {
+	TaskID          string  `json:"taskId"`
+	Title           string  `json:"title"`
+	Status          string  `json:"status"`
+	Amount          float64 `json:"amount"`
+	AmountBaseUnits int64   `json:"amountBaseUnits"`
+	Currency        string  `json:"currency"`
+	PosterID        string  `json:"posterId"`
+	AgentID         string  `json:"agentId"`
+	Release         string  `json:"release"`
+	CreatedAt       string  `json:"createdAt"`
+}
+
+var activeEscrowColumns = []string{"TASK", "TITLE", "STATUS", "AMOUNT", "BASE UNITS", "CURRENCY", "POSTER", "AGENT", "RELEASE", "CREATED"}
+
+func (e activeEscrowView) row() []string {
+	return []string{
+		e.TaskID, e.Title, e.Status, formatAmount(e.Amount), strconv.FormatInt(e.AmountBaseUnits, 10),
+		e.Currency, e.PosterID, e.AgentID, e.Release, e.CreatedAt,
+	}
+}
+
+// activeEscrowListView is a list of active escrows
+type activeEscrowListView []activeEscrowView
+
+func (l activeEscrowListView) columns() []string { return activeEscrowColumns }
+
+func (l activeEscrowListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, e := range l {
+		rows = append(rows, e.row())
+	}
+	return rows
+}
+
+func runEscrowActive(cmd *cobra.Command, args []string) error {
+	agent := escrowActiveAgent
+	if agent == "" && !escrowActiveAll {
+		agent = viper.GetString("agent-id")
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	active, err := client.ListActiveEscrows(cmd.Context())
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	cfg := escrowConfig(cmd.Context(), client)
+
+	view := activeEscrowListView{}
+	var total float64
+	for _, e := range active.Escrows {
+		if agent != "" && e.PosterID != agent && e.AgentID != agent {
+			continue
+		}
+		currency := firstNonEmpty(e.Currency, "USDC")
+		view = append(view, activeEscrowView{
+			TaskID:          e.TaskID,
+			Title:           e.Title,
+			Status:          e.Status,
+			Amount:          e.Amount,
+			AmountBaseUnits: gigclaw.ToBaseUnits(e.Amount, currency),
+			Currency:        currency,
+			PosterID:        e.PosterID,
+			AgentID:         e.AgentID,
+			Release:         releaseWindow(cfg, e.Status, e.Amount),
+			CreatedAt:       formatTime(e.CreatedAt.Time),
+		})
+		total += e.Amount
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view) == 0 {
+			if agent != "" {
+				colorWarning.Printf("  No active escrows for %s.\n", agent)
+			} else {
+				colorWarning.Println("  No active escrows.")
+			}
+			fmt.Println()
+			return
+		}
+
+		colorLabel.Printf("  Found ")
+		colorHighlight.Printf("%d", len(view))
+		colorLabel.Printf(" active escrow(s) holding ")
+		colorHighlight.Println(formatMoney(total, "USDC"))
+		if agent != "" {
+			colorDim.Printf("  Tasks %s posted or is assigned to\n", agent)
+		}
+		fmt.Println()
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("TASK")+"\t"+bold.Sprint("TITLE")+"\t"+bold.Sprint("STATUS")+"\t"+
+			bold.Sprint("AMOUNT")+"\t"+bold.Sprint("PARTIES")+"\t"+bold.Sprint("RELEASE"))
+		for _, e := range view {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				colorDim.Sprint(e.TaskID),
+				truncate(e.Title, 30),
+				formatStatus(e.Status),
+				colorPrimary.Sprintf("%.2f %s", e.Amount, e.Currency),
+				colorValue.Sprint(e.PosterID+" → "+e.AgentID),
+				e.Release,
+			)
+		}
+		w.Flush()
+		fmt.Println()
+		colorDim.Println("  Base units and timestamps: -o json or -o csv")
+		fmt.Println()
+	})
+}
+
+// escrowReleaseView is the result of escrow release
+type escrowReleaseView struct {
+	TaskID          string    `json:"taskId"`
+	Funds           fundsView `json:"funds"`
+	AmountBaseUnits int64     `json:"amountBaseUnits"`
+	ArbitratorID    string    `json:"arbitratorId"`
+	Reason          string    `json:"reason"`
+	TransactionHash string    `json:"transactionHash,omitempty"`
+	ReleasedAt      string    `json:"releasedAt,omitempty"`
+	IdempotencyKey  string    `json:"idempotencyKey"`
+	Replayed        bool      `json:"replayed"`
+}
+
+func (r escrowReleaseView) columns() []string {
+	return []string{"TASK", "AMOUNT", "BASE UNITS", "CURRENCY", "RECIPIENT", "ARBITRATOR", "TRANSACTION", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (r escrowReleaseView) rows() [][]string {
+	return [][]string{{
+		r.TaskID, formatAmount(r.Funds.Amount), strconv.FormatInt(r.AmountBaseUnits, 10), r.Funds.Currency,
+		r.Funds.Recipient, r.ArbitratorID, r.TransactionHash, r.IdempotencyKey, strconv.FormatBool(r.Replayed),
+	}}
+}
+
+func runEscrowRelease(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	reason := strings.TrimSpace(escrowReleaseReason)
+	if reason == "" {
+		return fmt.Errorf("--reason must not be empty")
+	}
+	arbitrator, err := actingAgent(escrowReleaseAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	status, err := client.GetEscrowStatus(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if !status.ReleasedAt.IsZero() && escrowReleaseIdemKey == "" {
+		return friendlyError(nil,
+			fmt.Sprintf("The payment for task %s was already released", taskID),
+			nil,
+			"See the release: gigclaw escrow status "+taskID)
+	}
+	if (!status.Held || status.Amount <= 0) && status.ReleasedAt.IsZero() {
+		return friendlyError(nil,
+			fmt.Sprintf("Task %s has no funds in escrow (it is %s)", taskID, task.Status),
+			nil,
+			"Funds are locked when a bid is accepted: gigclaw task accept "+taskID+" <bid-id>")
+	}
+
+	view := escrowReleaseView{
+		TaskID: taskID,
+		Funds: fundsView{
+			Amount:    status.Amount,
+			Currency:  firstNonEmpty(task.Currency, "USDC"),
+			Outcome:   fundsRelease,
+			Recipient: task.AssignedAgent,
+			Note:      "Released manually by " + arbitrator,
+		},
+		ArbitratorID: arbitrator,
+		Reason:       reason,
+	}
+	view.AmountBaseUnits = gigclaw.ToBaseUnits(view.Funds.Amount, view.Funds.Currency)
+
+	question := fmt.Sprintf("Release %s from escrow to %s?",
+		formatMoney(view.Funds.Amount, view.Funds.Currency), firstNonEmpty(task.AssignedAgent, "the agent"))
+	if !strings.EqualFold(task.Status, "verified") {
+		question = fmt.Sprintf("Task %s is %s, not verified.\n%s", taskID, task.Status, question)
+	}
+	if err := confirmAction(question, escrowReleaseYes); err != nil {
+		return err
+	}
+
+	res, err := runJournaled("escrow release", escrowReleaseIdemKey, []interface{}{taskID, arbitrator, reason}, func(key string) (string, error) {
+		payment, err := client.ReleaseEscrow(cmd.Context(), taskID, arbitrator, reason, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		view.Funds.Amount = payment.Amount
+		view.Funds.Recipient = firstNonEmpty(payment.AgentID, view.Funds.Recipient)
+		view.AmountBaseUnits = gigclaw.ToBaseUnits(payment.Amount, view.Funds.Currency)
+		view.ReleasedAt = formatTime(payment.ReleasedAt.Time)
+		return payment.TransactionHash, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	view.TransactionHash = res.Result
+	view.IdempotencyKey = res.Key
+	view.Replayed = res.Replayed
+
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Payment already released with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("✅ Payment released!")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Task:")
+		colorValue.Println(taskID)
+		printFunds(view.Funds)
+		colorLabel.Printf("  %-15s ", "Base units:")
+		colorValue.Println(view.AmountBaseUnits)
+		if view.TransactionHash != "" {
+			colorLabel.Printf("  %-15s ", "Transaction:")
+			colorDim.Println(view.TransactionHash)
+		}
+		fmt.Println()
+	})
+}
+
+// escrowStatsView is the result of escrow stats
+type escrowStatsView struct {
+	TotalEscrows                int     `json:"totalEscrows"`
+	ActiveEscrows               int     `json:"activeEscrows"`
+	ReleasedEscrows             int     `json:"releasedEscrows"`
+	TotalValueLocked            float64 `json:"totalValueLocked"`
+	TotalValueLockedBaseUnits   int64   `json:"totalValueLockedBaseUnits"`
+	TotalValueReleased          float64 `json:"totalValueReleased"`
+	TotalValueReleasedBaseUnits int64   `json:"totalValueReleasedBaseUnits"`
+	AverageEscrowAmount         float64 `json:"averageEscrowAmount"`
+	ReleaseRate                 float64 `json:"releaseRate"` // percent of escrows released
+	AutoRelease                 *bool   `json:"autoRelease"` // null when the config is unavailable
+	AutoReleaseDelaySeconds     float64 `json:"autoReleaseDelaySeconds"`
+	AutoReleaseMin              float64 `json:"autoReleaseMin"`
+	AutoReleaseMax              float64 `json:"autoReleaseMax"`
+}
+
+func (s escrowStatsView) columns() []string {
+	return []string{
+		"TOTAL", "ACTIVE", "RELEASED", "VALUE LOCKED", "VALUE LOCKED BASE UNITS", "VALUE RELEASED", "VALUE RELEASED BASE UNITS",
+		"AVERAGE", "RELEASE RATE", "AUTO RELEASE", "DELAY SECONDS", "AUTO RELEASE MIN", "AUTO RELEASE MAX",
+	}
+}
+
+func (s escrowStatsView) rows() [][]string {
+	auto := ""
+	if s.AutoRelease != nil {
+		auto = strconv.FormatBool(*s.AutoRelease)
+	}
+	return [][]string{{
+		strconv.Itoa(s.TotalEscrows), strconv.Itoa(s.ActiveEscrows), strconv.Itoa(s.ReleasedEscrows),
+		formatAmount(s.TotalValueLocked), strconv.FormatInt(s.TotalValueLockedBaseUnits, 10),
+		formatAmount(s.TotalValueReleased), strconv.FormatInt(s.TotalValueReleasedBaseUnits, 10),
+		formatAmount(s.AverageEscrowAmount), formatAmount(s.ReleaseRate),
+		auto, formatAmount(s.AutoReleaseDelaySeconds), formatAmount(s.AutoReleaseMin), formatAmount(s.AutoReleaseMax),
+	}}
+}
+
+func runEscrowStats(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	stats, err := client.GetEscrowStats(cmd.Context())
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	cfg := escrowConfig(cmd.Context(), client)
+
+	view := escrowStatsView{
+		TotalEscrows:                stats.TotalEscrows,
+		ActiveEscrows:               stats.ActiveEscrows,
+		ReleasedEscrows:             stats.ReleasedEscrows,
+		TotalValueLocked:            stats.TotalValueLocked,
+		TotalValueLockedBaseUnits:   gigclaw.ToBaseUnits(stats.TotalValueLocked, "USDC"),
+		TotalValueReleased:          stats.TotalValueReleased,
+		TotalValueReleasedBaseUnits: gigclaw.ToBaseUnits(stats.TotalValueReleased, "USDC"),
+		AverageEscrowAmount:         stats.AverageEscrowAmount,
+		ReleaseRate:                 stats.AutoReleaseRate,
+	}
+	if cfg != nil {
+		view.AutoRelease = &cfg.Enabled
+		view.AutoReleaseDelaySeconds = cfg.Delay().Seconds()
+		view.AutoReleaseMin = cfg.MinAmount
+		view.AutoReleaseMax = cfg.MaxAmount
+	}
+
+	return render(view, func() {
+		label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+		fmt.Println()
+		colorPrimary.Println("  🔒 Escrow Statistics")
+		fmt.Println()
+		label("Escrows")
+		colorValue.Printf("%d (%d active, %d released)\n", view.TotalEscrows, view.ActiveEscrows, view.ReleasedEscrows)
+		label("Locked")
+		colorPrimary.Println(formatMoney(view.TotalValueLocked, "USDC"))
+		label("Released")
+		colorSuccess.Println(formatMoney(view.TotalValueReleased, "USDC"))
+		label("Average")
+		colorValue.Printf("%.2f USDC\n", view.AverageEscrowAmount)
+		label("Release rate")
+		colorValue.Printf("%.1f%%\n", view.ReleaseRate)
+
+		fmt.Println()
+		colorHighlight.Println("  Auto-release")
+		switch {
+		case cfg == nil:
+			colorDim.Println("  Configuration unavailable")
+		case !cfg.Enabled:
+			label("Status")
+			colorWarning.Println("disabled; an arbitrator releases every payment")
+		default:
+			label("Status")
+			colorSuccess.Println("enabled")
+			label("Delay")
+			if cfg.Delay() > 0 {
+				colorValue.Printf("%s after verification\n", cfg.Delay().Round(time.Second))
+			} else {
+				colorValue.Println("none, released on verification")
+			}
+			label("Bounds")
+			colorValue.Printf("%s – %s USDC\n", formatAmount(cfg.MinAmount), formatAmount(cfg.MaxAmount))
+		}
+		fmt.Println()
+	})
+}
@@ -25,41 +25,54 @@ func runHealth(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	health, err := client.Health()
+	view := healthView{APIURL: client.BaseURL()}
+
+	health, err := client.Health(cmd.Context())
 	if err != nil {
-		red := color.New(color.FgRed, color.Bold).SprintFunc()
-		fmt.Println(red("❌ GigClaw API is unreachable"))
-		fmt.Println()
-		fmt.Printf("Error: %v\n", err)
-		fmt.Println()
-		fmt.Println("Troubleshooting:")
-		fmt.Println("  • Check your internet connection")
-		fmt.Println("  • Verify API URL: gigclaw config")
-		fmt.Println("  • Check status: https://gigclaw-production.up.railway.app/health")
+		view.Status = "unreachable"
+		view.Error = err.Error()
+		if renderErr := render(view, func() {
+			red := color.New(color.FgRed, color.Bold).SprintFunc()
+			fmt.Println(red("❌ GigClaw API is unreachable"))
+			fmt.Println()
+			fmt.Printf("Error: %v\n", err)
+			fmt.Println()
+			fmt.Println("Troubleshooting:")
+			fmt.Println("  • Check your internet connection")
+			fmt.Println("  • Verify API URL: gigclaw config")
+			fmt.Println("  • Check status: https://gigclaw-production.up.railway.app/health")
+		}); renderErr != nil {
+			return renderErr
+		}
 		return fmt.Errorf("health check failed")
 	}
 
-	// Color definitions
-	green := color.New(color.FgGreen, color.Bold).SprintFunc()
-	cyan := color.New(color.FgCyan).SprintFunc()
-	yellow := color.New(color.FgYellow).SprintFunc()
+	view.Reachable = true
+	view.Status = health.Status
+	view.Version = health.Version
+	view.Timestamp = health.Timestamp
 
-	fmt.Println()
-	fmt.Println(cyan("╔══════════════════════════════════════════╗"))
-	fmt.Println(cyan("║") + green("           🦀 GigClaw Status              ") + cyan("║"))
-	fmt.Println(cyan("╚══════════════════════════════════════════╝"))
-	fmt.Println()
-	fmt.Printf("  Status:    %s\n", green("● "+health.Status))
-	fmt.Printf("  Version:   %s\n", yellow(health.Version))
-	fmt.Printf("  Service:   %s\n", "Agent-Native Marketplace")
-	fmt.Printf("  Time:      %s\n", time.Now().Format("15:04:05"))
-	fmt.Println()
-	fmt.Println(green("  ✅ The agent economy is live"))
-	fmt.Println()
-	fmt.Println("Quick commands:")
-	fmt.Println("  gigclaw dashboard  # Launch TUI")
-	fmt.Println("  gigclaw task list  # View tasks")
-	fmt.Println()
+	return render(view, func() {
+		// Color definitions
+		green := color.New(color.FgGreen, color.Bold).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
 
-	return nil
+		fmt.Println()
+		fmt.Println(cyan("╔══════════════════════════════════════════╗"))
+		fmt.Println(cyan("║") + green("           🦀 GigClaw Status              ") + cyan("║"))
+		fmt.Println(cyan("╚══════════════════════════════════════════╝"))
+		fmt.Println()
+		fmt.Printf("  Status:    %s\n", green("● "+health.Status))
+		fmt.Printf("  Version:   %s\n", yellow(health.Version))
+		fmt.Printf("  Service:   %s\n", "Agent-Native Marketplace")
+		fmt.Printf("  Time:      %s\n", time.Now().Format("15:04:05"))
+		fmt.Println()
+		fmt.Println(green("  ✅ The agent economy is live"))
+		fmt.Println()
+		fmt.Println("Quick commands:")
+		fmt.Println("  gigclaw dashboard  # Launch TUI")
+		fmt.Println("  gigclaw task list  # View tasks")
+		fmt.Println()
+	})
 }
@@ -4,11 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 var initCmd = &cobra.Command{
@@ -16,15 +15,31 @@ var initCmd = &cobra.Command{
 	Short: "Initialize GigClaw configuration",
 	Long: `Initialize GigClaw CLI with your API credentials.
 
-This creates a configuration file at ~/.gigclaw/config.yaml with your settings.`,
+This creates a configuration file at ~/.gigclaw/config.yaml with your settings.
+Other settings already in the file are kept.
+
+The API key is not written to the config file. It is kept in the OS keyring
+when there is one (Secret Service or macOS keychain), else in
+~/.gigclaw/credentials.enc, encrypted with a passphrase that is asked for
+when the key is needed (or read from GIGCLAW_PASSPHRASE). The config file
+only refers to it, e.g. api-key-ref: keyring:default. With --secret-store env
+nothing is stored and the key is read from GIGCLAW_API_KEY.`,
 	RunE: runInit,
 }
 
+var initSecretStore string
+
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringVar(&initSecretStore, "secret-store", secretAuto, "Where to keep the API key: auto, keyring, file or env")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if !containsFold(secretStores, initSecretStore) {
+		return fmt.Errorf("invalid --secret-store %q: must be one of %s", initSecretStore, strings.Join(secretStores, ", "))
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 
 	fmt.Println("🦀 GigClaw CLI Setup")
@@ -38,60 +53,57 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("API URL [%s]: ", apiURL)
 	input, _ := reader.ReadString('\n')
-	if input = input[:len(input)-1]; input != "" {
+	if input = strings.TrimSpace(input); input != "" {
 		apiURL = input
 	}
 
-	// Get API Key
-	fmt.Print("API Key (optional, press Enter to skip): ")
-	apiKey, _ := reader.ReadString('\n')
-	apiKey = apiKey[:len(apiKey)-1]
-
-	// Create config directory
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	configDir := filepath.Join(home, ".gigclaw")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
-
-	// Create config file
-	config := map[string]string{
+	changes := map[string]interface{}{
 		"api-url": apiURL,
 	}
-	if apiKey != "" {
-		config["api-key"] = apiKey
-	}
 
-	configFile := filepath.Join(configDir, "config.yaml")
-	file, err := os.Create(configFile)
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
+	// Get API Key, unless it comes from the environment
+	store := defaultSecretStore(initSecretStore)
+	var apiKey string
+	if store == secretEnv {
+		fmt.Printf("API Key: read from %s\n", apiKeyEnv)
+	} else {
+		var err error
+		apiKey, err = readSecretLine(reader, "API Key (optional, press Enter to skip): ")
+		if err != nil {
+			return err
+		}
 	}
-	defer file.Close()
 
-	encoder := yaml.NewEncoder(file)
-	if err := encoder.Encode(config); err != nil {
-		return fmt.Errorf("failed to write config: %w", err)
+	if apiKey != "" || store == secretEnv {
+		ref, err := storeSecret(store, activeProfile(), apiKey)
+		if err != nil {
+			return friendlyError(err,
+				"Could not store the API key in "+describeSecretStore(store),
+				[]string{err.Error()},
+				"Store it in the encrypted file instead: gigclaw init --secret-store file",
+				"Or set "+apiKeyEnv+" and run: gigclaw init --secret-store env")
+		}
+		changes["api-key-ref"] = ref
+		changes["api-key"] = nil // never kept in plaintext
 	}
 
-	// Set permissions to owner-only
-	if err := os.Chmod(configFile, 0600); err != nil {
-		return fmt.Errorf("failed to set config permissions: %w", err)
+	configFile, err := updateConfig(changes)
+	if err != nil {
+		return err
 	}
 
 	fmt.Println()
 	fmt.Println("✅ Configuration saved to:", configFile)
+	if ref, ok := changes["api-key-ref"].(string); ok {
+		fmt.Printf("🔐 API key kept in %s (%s)\n", describeSecretStore(store), ref)
+	}
 	fmt.Println()
 	fmt.Println("Test your setup:")
 	fmt.Println("  gigclaw health")
 	fmt.Println()
 	fmt.Println("Get started:")
 	fmt.Println("  gigclaw task list")
-	fmt.Println("  gigclaw task post --title 'My Task' --budget 50")
+	fmt.Println("  gigclaw task post --title 'My Task' --budget 50 --deadline 2026-12-31")
 
 	return nil
 }
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// Journal entry states
+const (
+	journalPending = "pending" // request sent, outcome not yet known
+	journalDone    = "done"    // request succeeded
+)
+
+// journalRetention is how long entries are kept
+const journalRetention = 7 * 24 * time.Hour
+
+// replayWindow is how long the API remembers an idempotency key. Within it a
+// re-sent request returns the first response instead of running twice;
+// after it the request would run again.
+const replayWindow = 24 * time.Hour
+
+// journalEntry records one write command and its idempotency key
+type journalEntry struct {
+	Key         string    `json:"key"`
+	Op          string    `json:"op"`
+	Fingerprint string    `json:"fingerprint"`
+	Status      string    `json:"status"`
+	Result      string    `json:"result,omitempty"` // ID of the created resource
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// journal is a local record of write commands, so that a command re-run
+// after a crash or timeout is recognised instead of duplicated
+type journal struct {
+	Entries map[string]*journalEntry `json:"entries"` // keyed by idempotency key
+
+	path string
+}
+
+// journalPath returns ~/.gigclaw/journal.json
+func journalPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "journal.json"
+	}
+	return filepath.Join(home, ".gigclaw", "journal.json")
+}
+
+// openJournal loads the journal, dropping expired entries
+func openJournal() (*journal, error) {
+	j := &journal{
+		Entries: make(map[string]*journalEntry),
+		path:    journalPath(),
+	}
+
+	data, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %w", j.path, err)
+	}
+	if j.Entries == nil {
+		j.Entries = make(map[string]*journalEntry)
+	}
+
+	for key, e := range j.Entries {
+		if time.Since(e.UpdatedAt) > journalRetention {
+			delete(j.Entries, key)
+		}
+	}
+
+	return j, nil
+}
+
+// save atomically writes the journal with owner-only permissions
+func (j *journal) save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+
+	if err := writeFileAtomic(j.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// fingerprint identifies a command by its operation and parameters
+func fingerprint(op string, params ...interface{}) string {
+	data, _ := json.Marshal(append([]interface{}{op}, params...))
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// journaledResult is the outcome of runJournaled
+type journaledResult struct {
+	Key      string
+	Result   string
+	Replayed bool     // an earlier run with this key already succeeded
+	Unknown  bool     // it failed, but may have taken effect: retry with Key
+	Warnings []string // e.g. the journal could not be updated
+}
+
+// unfinishedError blocks a write while an identical one from an earlier
+// run did not finish and may have succeeded
+type unfinishedError struct {
+	Op        string
+	Key       string // the earlier run's key
+	CreatedAt time.Time
+}
+
+func (e *unfinishedError) Error() string {
+	return fmt.Sprintf("an identical %s started at %s did not finish and may have succeeded",
+		e.Op, e.CreatedAt.Local().Format(time.RFC822))
+}
+
+// retryable reports whether the API still replays the earlier run's key
+func (e *unfinishedError) retryable() bool {
+	return time.Since(e.CreatedAt) <= replayWindow
+}
+
+// runJournaled runs a write request under an idempotency key recorded in
+// the local journal, printing any warnings.
+//
+// With an explicit key, a finished entry is replayed without calling the
+// API and an unfinished one is re-sent with the same key. Without one, a
+// fresh key is generated, but an unfinished identical command from an
+// earlier run blocks the request until the user decides how to proceed.
+func runJournaled(op, explicitKey string, params []interface{}, send func(key string) (string, error)) (*journaledResult, error) {
+	res, err := journaled(op, explicitKey, params, send)
+	if res != nil {
+		for _, w := range res.Warnings {
+			logger.Warning(w)
+		}
+		if res.Unknown {
+			logger.Warning(fmt.Sprintf("Outcome unknown. Re-run with --idempotency-key %s within %.0f hours to retry safely",
+				res.Key, replayWindow.Hours()))
+		}
+	}
+	var unfinished *unfinishedError
+	if errors.As(err, &unfinished) {
+		hints := []string{"Check whether it took effect: gigclaw task list"}
+		if unfinished.retryable() {
+			hints = append(hints, "Retry it safely: --idempotency-key "+unfinished.Key)
+		}
+		hints = append(hints, "Run it again anyway: --idempotency-key "+gigclaw.NewIdempotencyKey())
+		return nil, friendlyError(nil,
+			fmt.Sprintf("An identical %s started at %s did not finish and may have succeeded",
+				unfinished.Op, unfinished.CreatedAt.Local().Format(time.RFC822)),
+			nil, hints...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// journaled is runJournaled without output, for callers that cannot
+// print, such as the dashboard. Once the request was attempted it returns
+// a result carrying the warnings, even with an error. An unfinished
+// identical write blocks it with an *unfinishedError.
+func journaled(op, explicitKey string, params []interface{}, send func(key string) (string, error)) (*journaledResult, error) {
+	res := &journaledResult{}
+	warn := func(format string, args ...interface{}) {
+		res.Warnings = append(res.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	j, err := openJournal()
+	if err != nil {
+		return nil, err
+	}
+
+	fp := fingerprint(op, params...)
+	key := explicitKey
+
+	if key != "" {
+		if e, ok := j.Entries[key]; ok {
+			if e.Fingerprint != fp {
+				return nil, friendlyError(nil,
+					fmt.Sprintf("Idempotency key %q was already used for a different %s", key, e.Op),
+					nil,
+					"Use a new key, or omit --idempotency-key to generate one")
+			}
+			if e.Status == journalDone {
+				return &journaledResult{Key: key, Result: e.Result, Replayed: true}, nil
+			}
+			if time.Since(e.CreatedAt) > replayWindow {
+				return nil, friendlyError(nil,
+					fmt.Sprintf("The unfinished %s from %s is too old to retry: the API no longer remembers key %q",
+						op, e.CreatedAt.Local().Format(time.RFC822), key),
+					nil,
+					"Check whether it took effect: gigclaw task list",
+					"Run it again anyway: --idempotency-key "+gigclaw.NewIdempotencyKey())
+			}
+			warn("Retrying unfinished %s from %s with the same idempotency key",
+				op, e.CreatedAt.Local().Format(time.RFC822))
+		}
+	} else {
+		for _, e := range j.Entries {
+			if e.Fingerprint == fp && e.Status == journalPending {
+				return nil, &unfinishedError{Op: op, Key: e.Key, CreatedAt: e.CreatedAt}
+			}
+		}
+		key = gigclaw.NewIdempotencyKey()
+	}
+
+	now := time.Now()
+	entry, ok := j.Entries[key]
+	if !ok {
+		entry = &journalEntry{Key: key, Op: op, Fingerprint: fp, CreatedAt: now}
+		j.Entries[key] = entry
+	}
+	entry.Status = journalPending
+	entry.UpdatedAt = now
+	if err := j.save(); err != nil {
+		return nil, err
+	}
+
+	res.Key = key
+	result, err := send(key)
+	if err != nil {
+		if notApplied(err) {
+			delete(j.Entries, key)
+			if saveErr := j.save(); saveErr != nil {
+				warn("Failed to update journal: %v", saveErr)
+			}
+		} else {
+			res.Unknown = true
+		}
+		return res, err
+	}
+
+	entry.Status = journalDone
+	entry.Result = result
+	entry.UpdatedAt = time.Now()
+	if err := j.save(); err != nil {
+		warn("Failed to update journal: %v", err)
+	}
+
+	res.Result = result
+	return res, nil
+}
+
+// notApplied reports whether a failed write certainly never took effect:
+// the server refused it, or the request never reached the server. After
+// a 5xx, a timeout or a dropped connection it may have been applied.
+func notApplied(err error) bool {
+	var apiErr *gigclaw.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Rejected()
+	}
+	var reqErr *gigclaw.RequestError
+	return errors.As(err, &reqErr) && reqErr.NotSent()
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// useTempHome points the journal at an empty home directory
+func useTempHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return home
+}
+
+// recordSend returns a send func that returns result and err, and the
+// keys it was called with
+func recordSend(result string, err error) (func(string) (string, error), *[]string) {
+	var keys []string
+	return func(key string) (string, error) {
+		keys = append(keys, key)
+		return result, err
+	}, &keys
+}
+
+func TestRunJournaledReplay(t *testing.T) {
+	useTempHome(t)
+	params := []interface{}{"t1", 90.0}
+
+	send, keys := recordSend("b1", nil)
+	res, err := runJournaled("task bid", "", params, send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*keys) != 1 || (*keys)[0] != res.Key || res.Result != "b1" || res.Replayed {
+		t.Fatalf("first run: result %+v, keys %v", res, *keys)
+	}
+
+	// The same key replays the result without sending
+	replay, err := runJournaled("task bid", res.Key, params, send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replay.Replayed || replay.Result != "b1" || len(*keys) != 1 {
+		t.Errorf("replay: result %+v, sent %d times", replay, len(*keys))
+	}
+
+	// A finished command does not block running it again with a new key
+	again, err := runJournaled("task bid", "", params, send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Key == res.Key || len(*keys) != 2 {
+		t.Errorf("re-run: key %s, sent %d times", again.Key, len(*keys))
+	}
+
+	// Reusing a key for other parameters is refused
+	if _, err := runJournaled("task bid", res.Key, []interface{}{"t2", 90.0}, send); err == nil {
+		t.Error("key reused for a different bid")
+	}
+}
+
+func TestRunJournaledFailure(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name string
+		err  error
+		kept bool // entry stays pending
+	}{
+		{"bad request", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusBadRequest}, false},
+		{"not found", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusNotFound}, false},
+		{"server error", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusInternalServerError}, true},
+		{"bad gateway", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusBadGateway}, true},
+		{"request timeout", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusRequestTimeout}, true},
+		{"conflict", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusConflict}, true},
+		{"rate limited", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusTooManyRequests}, true},
+		{"unexpected success", &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusAccepted}, true},
+		{"connection refused", &gigclaw.RequestError{Op: "place bid", Err: dialErr}, false},
+		{"timed out", &gigclaw.RequestError{Op: "place bid", Err: context.DeadlineExceeded}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempHome(t)
+			params := []interface{}{"t1", 90.0}
+
+			send, keys := recordSend("", tt.err)
+			if _, err := runJournaled("task bid", "", params, send); !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			key := (*keys)[0]
+
+			j, err := openJournal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry, ok := j.Entries[key]
+			if ok != tt.kept {
+				t.Fatalf("entry kept = %v, want %v", ok, tt.kept)
+			}
+
+			retry, retryKeys := recordSend("b1", nil)
+			_, err = runJournaled("task bid", "", params, retry)
+			if !tt.kept {
+				// Nothing was applied, so running it again is fine
+				if err != nil || len(*retryKeys) != 1 {
+					t.Errorf("re-run: err %v, sent %d times", err, len(*retryKeys))
+				}
+				return
+			}
+
+			if entry.Status != journalPending {
+				t.Errorf("status = %s, want %s", entry.Status, journalPending)
+			}
+			// An unknown outcome blocks a new key until the user decides
+			if err == nil || len(*retryKeys) != 0 {
+				t.Errorf("re-run with a new key: err %v, sent %d times", err, len(*retryKeys))
+			}
+			// and the same key is re-sent
+			res, err := runJournaled("task bid", key, params, retry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*retryKeys) != 1 || (*retryKeys)[0] != key || res.Result != "b1" {
+				t.Errorf("retry: result %+v, keys %v", res, *retryKeys)
+			}
+		})
+	}
+}
+
+func TestJournalSave(t *testing.T) {
+	home := useTempHome(t)
+
+	send, _ := recordSend("b1", nil)
+	if _, err := runJournaled("task bid", "", nil, send); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(home, ".gigclaw")
+	info, err := os.Stat(filepath.Join(dir, "journal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("mode = %o, want 600", perm)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("%s has %d files, want only the journal", dir, len(entries))
+	}
+}
+
+func TestJournaledUnknownOutcome(t *testing.T) {
+	useTempHome(t)
+	failure := &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusBadGateway}
+
+	send, keys := recordSend("", failure)
+	res, err := journaled("task bid", "", nil, send)
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v, want %v", err, failure)
+	}
+	if res == nil || res.Key != (*keys)[0] || !res.Unknown {
+		t.Fatalf("result = %+v, want the key to retry with", res)
+	}
+	// The caller says how to retry: a flag or, in the dashboard, a key
+	for _, w := range res.Warnings {
+		if strings.Contains(w, "--") {
+			t.Errorf("warning %q names a flag", w)
+		}
+	}
+
+	// An identical request is blocked, offering the key
+	_, err = journaled("task bid", "", nil, send)
+	var unfinished *unfinishedError
+	if !errors.As(err, &unfinished) || unfinished.Key != res.Key || !unfinished.retryable() {
+		t.Errorf("re-run: err %v, want the unfinished key %s", err, res.Key)
+	}
+	if len(*keys) != 1 {
+		t.Errorf("sent %d times, want 1", len(*keys))
+	}
+}
+
+func TestRunJournaledExpiredKey(t *testing.T) {
+	useTempHome(t)
+	params := []interface{}{"t1", 90.0}
+	failure := &gigclaw.APIError{Op: "place bid", StatusCode: http.StatusBadGateway}
+
+	send, keys := recordSend("", failure)
+	if _, err := runJournaled("task bid", "", params, send); !errors.Is(err, failure) {
+		t.Fatalf("err = %v, want %v", err, failure)
+	}
+	key := (*keys)[0]
+
+	// Age the entry past the window in which the API replays its key
+	j, err := openJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	j.Entries[key].CreatedAt = time.Now().Add(-replayWindow - time.Hour)
+	if err := j.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	retry, retryKeys := recordSend("b1", nil)
+	_, err = runJournaled("task bid", "", params, retry)
+	if err == nil || strings.Contains(err.Error(), key) {
+		t.Errorf("re-run: err %v, want no offer to retry with %s", err, key)
+	}
+	if _, err := runJournaled("task bid", key, params, retry); err == nil {
+		t.Error("expired key re-sent")
+	}
+	if len(*retryKeys) != 0 {
+		t.Errorf("sent %d times, want 0", len(*retryKeys))
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/mattn/go-isatty"
+)
+
+// Outcomes for escrowed funds after a lifecycle action
+const (
+	fundsHeld    = "held"    // stay locked in escrow
+	fundsRelease = "release" // paid out to the agent
+	fundsRefund  = "refund"  // returned to the poster
+	fundsSplit   = "split"   // shared between the poster and the agent
+)
+
+// fundsView describes what a lifecycle action does to the escrowed funds
+type fundsView struct {
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Outcome   string  `json:"outcome"` // held, release, refund or split
+	Recipient string  `json:"recipient,omitempty"`
+	Note      string  `json:"note"`
+}
+
+// taskActionView is the result of task complete, verify and cancel
+type taskActionView struct {
+	TaskID         string     `json:"taskId"`
+	Action         string     `json:"action"`
+	PreviousStatus string     `json:"previousStatus"`
+	Status         string     `json:"status"`
+	DeliveryURL    string     `json:"deliveryUrl,omitempty"`
+	Funds          fundsView  `json:"funds"`
+	Blockchain     *chainView `json:"blockchain,omitempty"` // refund on chain, cancel only
+	IdempotencyKey string     `json:"idempotencyKey"`
+	Replayed       bool       `json:"replayed"`
+	FeedbackSent   bool       `json:"feedbackSent,omitempty"` // task verify --feedback
+}
+
+func (a taskActionView) columns() []string {
+	return []string{"TASK", "ACTION", "STATUS", "FUNDS", "AMOUNT", "CURRENCY", "RECIPIENT", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (a taskActionView) rows() [][]string {
+	return [][]string{{
+		a.TaskID, a.Action, a.Status, a.Funds.Outcome, formatAmount(a.Funds.Amount), a.Funds.Currency,
+		a.Funds.Recipient, a.IdempotencyKey, strconv.FormatBool(a.Replayed),
+	}}
+}
+
+// requireTaskStatus fails with a hint unless the task is in status from.
+// A re-run with an idempotency key may find the action already applied,
+// so the statuses in done are accepted too when idemKey is set.
+func requireTaskStatus(task *gigclaw.Task, action, from string, done []string, idemKey string, hints ...string) error {
+	if strings.EqualFold(task.Status, from) {
+		return nil
+	}
+	if idemKey != "" {
+		for _, s := range done {
+			if strings.EqualFold(task.Status, s) {
+				return nil
+			}
+		}
+	}
+	return friendlyError(nil,
+		fmt.Sprintf("Cannot %s task %s: it is %s, not %s", action, task.ID, task.Status, from),
+		nil,
+		append(hints, "Check the task: gigclaw task show "+task.ID)...)
+}
+
+// escrowedAmount returns the amount locked for a task, from the escrow
+// API if available and the accepted bid otherwise
+func escrowedAmount(ctx context.Context, client *gigclaw.Client, task *gigclaw.Task) float64 {
+	if escrow, err := client.GetEscrowStatus(ctx, task.ID); err == nil && escrow.Amount > 0 {
+		return escrow.Amount
+	} else if err != nil {
+		logger.Debug("Escrow status unavailable", err)
+	}
+	if task.AcceptedBid != nil {
+		return task.AcceptedBid.Amount
+	}
+	return 0
+}
+
+// confirmAction asks a yes/no question on stderr. Without a terminal to
+// ask on, the caller must pass --yes.
+func confirmAction(question string, assumeYes bool) error {
+	if assumeYes {
+		return nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("%s Re-run with --yes to confirm", question)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("aborted")
+}
+
+// printFunds prints the effect of an action on the escrowed funds
+func printFunds(f fundsView) {
+	colorLabel.Printf("  %-15s ", "Funds:")
+	switch f.Outcome {
+	case fundsRelease:
+		colorSuccess.Printf("%.2f %s → %s\n", f.Amount, f.Currency, f.Recipient)
+	case fundsRefund:
+		if f.Amount > 0 {
+			colorWarning.Printf("%.2f %s refunded to the poster\n", f.Amount, f.Currency)
+		} else {
+			colorValue.Println("no bid accepted, nothing locked in the API escrow")
+		}
+	case fundsSplit:
+		colorWarning.Printf("%.2f %s split between the poster and the agent\n", f.Amount, f.Currency)
+	default:
+		colorPrimary.Printf("%.2f %s locked in escrow\n", f.Amount, f.Currency)
+	}
+	colorDim.Printf("  %-15s %s\n", "", f.Note)
+}
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
 	"github.com/fatih/color"
 )
 
-// Logger provides structured logging for the CLI
+// Logger provides structured logging for the CLI. It writes to stderr so
+// that stdout carries only command output.
 type Logger struct {
 	verbose bool
 }
@@ -24,34 +30,34 @@ func NewLogger() *Logger {
 func (l *Logger) Debug(msg string, fields ...interface{}) {
 	if l.verbose {
 		timestamp := time.Now().Format("15:04:05")
-		color.New(color.FgHiBlack).Printf("[DEBUG %s] %s", timestamp, msg)
+		color.New(color.FgHiBlack).Fprintf(color.Error, "[DEBUG %s] %s", timestamp, msg)
 		if len(fields) > 0 {
-			fmt.Printf(" %v", fields)
+			fmt.Fprintf(color.Error, " %v", fields)
 		}
-		fmt.Println()
+		fmt.Fprintln(color.Error)
 	}
 }
 
 // Info logs informational messages
 func (l *Logger) Info(msg string) {
-	color.New(color.FgCyan).Printf("ℹ  %s\n", msg)
+	color.New(color.FgCyan).Fprintf(color.Error, "ℹ  %s\n", msg)
 }
 
 // Success logs success messages
 func (l *Logger) Success(msg string) {
-	color.New(color.FgGreen, color.Bold).Printf("✓ %s\n", msg)
+	color.New(color.FgGreen, color.Bold).Fprintf(color.Error, "✓ %s\n", msg)
 }
 
 // Warning logs warning messages
 func (l *Logger) Warning(msg string) {
-	color.New(color.FgYellow).Printf("⚠  %s\n", msg)
+	color.New(color.FgYellow).Fprintf(color.Error, "⚠  %s\n", msg)
 }
 
 // Error logs error messages with context
 func (l *Logger) Error(msg string, err error) {
-	color.New(color.FgRed, color.Bold).Printf("✗ %s\n", msg)
+	color.New(color.FgRed, color.Bold).Fprintf(color.Error, "✗ %s\n", msg)
 	if err != nil && l.verbose {
-		color.New(color.FgRed).Printf("   Error: %v\n", err)
+		color.New(color.FgRed).Fprintf(color.Error, "   Error: %v\n", err)
 	}
 }
 
@@ -61,15 +67,31 @@ func (l *Logger) Fatal(msg string, err error) {
 	os.Exit(1)
 }
 
-// APIError represents a structured API error
-type APIError struct {
-	StatusCode int
-	Message    string
-	Suggestion string
+// cliError is a user-facing error with suggestions that still
+// unwraps to the underlying SDK error
+type cliError struct {
+	text string
+	err  error
 }
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+func (e *cliError) Error() string { return e.text }
+func (e *cliError) Unwrap() error { return e.err }
+
+// friendlyError formats a headline, optional details and suggestions
+func friendlyError(err error, headline string, details []string, suggestions ...string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", color.RedString("✗"), headline)
+	if len(details) > 0 {
+		b.WriteString("\n")
+		for _, d := range details {
+			fmt.Fprintf(&b, "  %s\n", d)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s", color.YellowString("Suggestions:"))
+	for _, s := range suggestions {
+		fmt.Fprintf(&b, "\n  • %s", s)
+	}
+	return &cliError{text: b.String(), err: err}
 }
 
 // HandleAPIError provides user-friendly error messages with suggestions
@@ -78,99 +100,100 @@ func HandleAPIError(err error) error {
 		return nil
 	}
 
-	// Check if it's a network error
-	if os.IsTimeout(err) {
-		return fmt.Errorf(`%s API request timed out
+	var cliErr *cliError
+	if errors.As(err, &cliErr) {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return friendlyError(err, "API request timed out", nil,
+			"Check your internet connection",
+			"The API might be temporarily unavailable",
+			"Try again in a few moments")
+	}
 
-%s
-  • Check your internet connection
-  • The API might be temporarily unavailable
-  • Try again in a few moments`,
-			color.RedString("✗"),
-			color.YellowString("Suggestions:"))
+	if errors.Is(err, gigclaw.ErrUnreachable) {
+		return friendlyError(err, "Cannot connect to GigClaw API", []string{err.Error()},
+			"Check if the API URL is correct: gigclaw config",
+			"The service might be down",
+			"Try: gigclaw health",
+			color.CyanString("Status page: ")+gigclaw.DefaultBaseURL+"/health")
 	}
 
-	// Handle specific error patterns
-	errStr := err.Error()
-	
+	var apiErr *gigclaw.APIError
+	if !errors.As(err, &apiErr) {
+		return friendlyError(err, err.Error(), nil,
+			"Run with GIGCLAW_DEBUG=true for details",
+			"Check your configuration: gigclaw config",
+			"Try: gigclaw health")
+	}
+
+	details := apiErrorDetails(apiErr)
 	switch {
-	case contains(errStr, "connection refused"):
-		return fmt.Errorf(`%s Cannot connect to GigClaw API
-
-%s
-  • Check if the API URL is correct: gigclaw config
-  • The service might be down
-  • Try: gigclaw health
-
-%s https://gigclaw-production.up.railway.app/health`,
-			color.RedString("✗"),
-			color.YellowString("Suggestions:"),
-			color.CyanString("Status page:"))
-
-	case contains(errStr, "404"):
-		return fmt.Errorf(`%s Resource not found
-
-%s
-  • The task or bid ID might be incorrect
-  • Check available tasks: gigclaw task list
-  • The resource may have been deleted`,
-			color.RedString("✗"),
-			color.YellowString("Suggestions:"))
-
-	case contains(errStr, "401"), contains(errStr, "403"):
-		return fmt.Errorf(`%s Authentication failed
-
-%s
-  • Check your API key: gigclaw config
-  • You may need to run: gigclaw init
-  • Contact support if the issue persists`,
-			color.RedString("✗"),
-			color.YellowString("Suggestions:"))
-
-	case contains(errStr, "400"):
-		return fmt.Errorf(`%s Invalid request
-
-%s
-  • Check your command arguments
-  • Verify required flags are provided
-  • Run with --help for usage info`,
-			color.RedString("✗"),
-			color.YellowString("Suggestions:"))
-
-	case contains(errStr, "500"), contains(errStr, "502"), contains(errStr, "503"):
-		return fmt.Errorf(`%s API server error
-
-%s
-  • The server encountered an error
-  • This is temporary - please try again
-  • Check status: https://gigclaw-production.up.railway.app/health`,
-			color.RedString("✗"),
-			color.YellowString("Suggestions:"))
+	case errors.Is(err, gigclaw.ErrValidation):
+		return friendlyError(err, "Invalid request", details,
+			"Fix the fields listed above",
+			"Run with --help for usage info")
+
+	case errors.Is(err, gigclaw.ErrNotFound):
+		return friendlyError(err, "Resource not found", details,
+			"The task or bid ID might be incorrect",
+			"Check available tasks: gigclaw task list",
+			"The resource may have been deleted")
+
+	case errors.Is(err, gigclaw.ErrUnauthorized), errors.Is(err, gigclaw.ErrForbidden):
+		return friendlyError(err, "Authentication failed", details,
+			"Check your API key: gigclaw config",
+			"You may need to run: gigclaw init",
+			"Contact support if the issue persists")
+
+	case errors.Is(err, gigclaw.ErrRateLimited):
+		return friendlyError(err, "Rate limit exceeded", details,
+			"Wait a minute before retrying",
+			"Reduce polling frequency, e.g. gigclaw worker start --interval 2m")
+
+	case errors.Is(err, gigclaw.ErrConflict):
+		return friendlyError(err, "Request conflicts with the current state", details,
+			"Refresh the resource and try again",
+			"Check the task status: gigclaw task list")
+
+	case errors.Is(err, gigclaw.ErrBadRequest):
+		return friendlyError(err, "Invalid request", details,
+			"Check your command arguments",
+			"Verify required flags are provided",
+			"Run with --help for usage info")
+
+	case errors.Is(err, gigclaw.ErrServer):
+		return friendlyError(err, "API server error", details,
+			"The server encountered an error",
+			"This is temporary - please try again",
+			"Check status: "+gigclaw.DefaultBaseURL+"/health")
 
 	default:
-		return fmt.Errorf(`%s %s
-
-%s
-  • Run with GIGCLAW_DEBUG=true for details
-  • Check your configuration: gigclaw config
-  • Try: gigclaw health`,
-			color.RedString("✗"),
-			errStr,
-			color.YellowString("Suggestions:"))
+		return friendlyError(err, fmt.Sprintf("Unexpected API response (HTTP %d)", apiErr.StatusCode), details,
+			"Run with GIGCLAW_DEBUG=true for details",
+			"Try: gigclaw health")
 	}
 }
 
-func contains(s, substr string) bool {
-	return len(s) > 0 && len(substr) > 0 && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || findSubstr(s, substr)))
-}
-
-func findSubstr(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+// apiErrorDetails lists the server message, field errors and request ID
+func apiErrorDetails(e *gigclaw.APIError) []string {
+	var details []string
+	if e.Message != "" {
+		details = append(details, color.New(color.FgHiBlack).Sprint("Server:     ")+e.Message)
+	}
+	for _, d := range e.Details {
+		details = append(details, color.New(color.FgHiBlack).Sprint("Field:      ")+d.String())
+	}
+	if e.RequestID != "" {
+		details = append(details, color.New(color.FgHiBlack).Sprint("Request ID: ")+e.RequestID)
 	}
-	return false
+	return details
 }
 
 // Global logger instance
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+)
+
+var taskRecommendCmd = &cobra.Command{
+	Use:   "recommend",
+	Short: "Recommend open tasks for your agent",
+	Long: `Rank the open tasks for your agent, scored on the skills it has, the
+budget and how close the deadline is.`,
+	Example: `  gigclaw task recommend
+  gigclaw task recommend --as agent-7 -o json`,
+	Args: cobra.NoArgs,
+	RunE: runTaskRecommend,
+}
+
+var taskMatchCmd = &cobra.Command{
+	Use:   "match <task-id>",
+	Short: "Find the best available agents for your task",
+	Long: `Rank the available agents for a task, scored on the skills they have
+and their track record. Agents the server has outcomes for (see task verify
+--feedback) also get a predicted success rate and timeline.`,
+	Example: `  gigclaw task match 123`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runTaskMatch,
+}
+
+var taskRecommendAs string
+
+func init() {
+	taskCmd.AddCommand(taskRecommendCmd)
+	taskCmd.AddCommand(taskMatchCmd)
+
+	taskRecommendCmd.Flags().StringVar(&taskRecommendAs, "as", "", "Agent to recommend tasks for (default agent-id from the config file)")
+}
+
+// taskRecommendationView is an open task recommended for an agent
+type taskRecommendationView struct {
+	TaskID       string   `json:"taskId"`
+	Title        string   `json:"title"`
+	Budget       float64  `json:"budget"`
+	Currency     string   `json:"currency"`
+	Skills       []string `json:"skills"`
+	Score        float64  `json:"score"`
+	SkillMatches int      `json:"skillMatches"`
+	Bids         int      `json:"bids"`
+	Reasons      []string `json:"reasons"`
+}
+
+// taskRecommendationsView is the tasks recommended for an agent
+type taskRecommendationsView struct {
+	AgentID         string                   `json:"agentId"`
+	Recommendations []taskRecommendationView `json:"recommendations"`
+}
+
+func (r taskRecommendationsView) columns() []string {
+	return []string{"TASK", "TITLE", "BUDGET", "CURRENCY", "SKILLS", "SCORE", "SKILL MATCHES", "BIDS", "REASONS"}
+}
+
+func (r taskRecommendationsView) rows() [][]string {
+	rows := make([][]string, 0, len(r.Recommendations))
+	for _, t := range r.Recommendations {
+		rows = append(rows, []string{
+			t.TaskID, t.Title, formatAmount(t.Budget), t.Currency, strings.Join(t.Skills, ","),
+			formatAmount(t.Score), strconv.Itoa(t.SkillMatches), strconv.Itoa(t.Bids), strings.Join(t.Reasons, "; "),
+		})
+	}
+	return rows
+}
+
+// agentMatchView is an agent matched to a task. The predicted fields are
+// zero for agents the server has no outcomes for.
+type agentMatchView struct {
+	AgentID           string   `json:"agentId"`
+	Name              string   `json:"name"`
+	Status            string   `json:"status"`
+	Skills            []string `json:"skills"`
+	Score             float64  `json:"score"`
+	SkillMatches      int      `json:"skillMatches"`
+	CompletedTasks    int      `json:"completedTasks"`
+	SuccessRate       float64  `json:"successRate"`
+	Predicted         bool     `json:"predicted"`
+	PredictedSuccess  float64  `json:"predictedSuccess"`
+	PredictedTimeline string   `json:"predictedTimeline"`
+	Confidence        float64  `json:"confidence"`
+	Reasons           []string `json:"reasons"`
+	RiskFactors       []string `json:"riskFactors"`
+}
+
+// agentMatchesView is the agents matched to a task
+type agentMatchesView struct {
+	TaskID  string           `json:"taskId"`
+	Skills  []string         `json:"skills"`
+	Matches []agentMatchView `json:"matches"`
+}
+
+func (m agentMatchesView) columns() []string {
+	return []string{"AGENT", "NAME", "STATUS", "SCORE", "SKILL MATCHES", "COMPLETED", "SUCCESS RATE",
+		"PREDICTED SUCCESS", "PREDICTED TIMELINE", "CONFIDENCE", "REASONS", "RISKS"}
+}
+
+func (m agentMatchesView) rows() [][]string {
+	rows := make([][]string, 0, len(m.Matches))
+	for _, a := range m.Matches {
+		rows = append(rows, []string{
+			a.AgentID, a.Name, a.Status, formatAmount(a.Score), strconv.Itoa(a.SkillMatches),
+			strconv.Itoa(a.CompletedTasks), formatAmount(a.SuccessRate), formatAmount(a.PredictedSuccess),
+			a.PredictedTimeline, formatAmount(a.Confidence), strings.Join(a.Reasons, "; "), strings.Join(a.RiskFactors, "; "),
+		})
+	}
+	return rows
+}
+
+func runTaskRecommend(cmd *cobra.Command, args []string) error {
+	agentID, err := actingAgent(taskRecommendAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	recs, err := client.RecommendTasks(cmd.Context(), agentID)
+	if errors.Is(err, gigclaw.ErrNotFound) {
+		return friendlyError(err,
+			fmt.Sprintf("Agent %s is not registered", agentID),
+			[]string{"API: " + client.BaseURL()},
+			"Tasks are recommended on the skills an agent registered with: gigclaw agent register --name <name> --skill <skill>")
+	}
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	// The server scores without saying why; the agent's skills explain it
+	var agentSkills []string
+	if agent, err := client.GetAgent(cmd.Context(), agentID); err == nil {
+		agentSkills = agent.Skills
+	} else {
+		logger.Debug("Agent unavailable for match reasons", err)
+	}
+
+	view := taskRecommendationsView{AgentID: agentID, Recommendations: []taskRecommendationView{}}
+	for _, r := range recs {
+		t := r.Task
+		view.Recommendations = append(view.Recommendations, taskRecommendationView{
+			TaskID:       t.ID,
+			Title:        t.Title,
+			Budget:       t.Budget,
+			Currency:     t.Currency,
+			Skills:       t.Skills(),
+			Score:        r.Score,
+			SkillMatches: r.SkillMatches,
+			Bids:         len(t.Bids),
+			Reasons:      taskMatchReasons(t, agentSkills),
+		})
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view.Recommendations) == 0 {
+			colorWarning.Println("  No open tasks to recommend.")
+			fmt.Println()
+			colorDim.Println("  Browse every task: gigclaw task list")
+			fmt.Println()
+			return
+		}
+		colorPrimary.Printf("  Tasks for %s\n", agentID)
+		fmt.Println()
+		for i, r := range view.Recommendations {
+			fmt.Printf("  %d. ", i+1)
+			colorHighlight.Print(truncate(r.Title, 50))
+			colorDim.Printf(" (%s)  ", r.TaskID)
+			colorSuccess.Printf("score %s\n", formatAmount(r.Score))
+			fmt.Printf("     %s %s · %s\n", formatAmount(r.Budget), r.Currency, firstNonEmpty(strings.Join(r.Skills, ", "), "no skills listed"))
+			for _, reason := range r.Reasons {
+				colorDim.Printf("     • %s\n", reason)
+			}
+			fmt.Println()
+		}
+		colorDim.Printf("  Bid: gigclaw task bid %s --amount <amount>\n", view.Recommendations[0].TaskID)
+		fmt.Println()
+	})
+}
+
+// taskMatchReasons explains why a task suits an agent with skills
+func taskMatchReasons(t gigclaw.Task, skills []string) []string {
+	reasons := []string{}
+	var matching []string
+	for _, s := range t.Skills() {
+		// The server matches skills exactly
+		for _, have := range skills {
+			if s == have {
+				matching = append(matching, s)
+				break
+			}
+		}
+	}
+	if len(matching) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Needs %d of your skills: %s", len(matching), strings.Join(matching, ", ")))
+	}
+	switch len(t.Bids) {
+	case 0:
+		reasons = append(reasons, "No bids yet")
+	case 1:
+		reasons = append(reasons, "1 bid so far")
+	default:
+		reasons = append(reasons, fmt.Sprintf("%d bids so far", len(t.Bids)))
+	}
+	return reasons
+}
+
+func runTaskMatch(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	matches, err := client.FindAgents(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	// Predictions only cover agents with match feedback, and are not needed
+	// for a ranking, so failing to get them is not an error
+	predicted := make(map[string]gigclaw.PredictedMatch)
+	if skills := task.Skills(); len(skills) > 0 && len(matches) > 0 {
+		p, err := client.PredictMatches(cmd.Context(), gigclaw.PredictMatchRequest{
+			TaskID:         task.ID,
+			RequiredSkills: skills,
+			Budget:         task.Budget,
+			PosterID:       task.PosterID,
+		})
+		if err != nil {
+			logger.Debug("Predictions unavailable", err)
+		} else {
+			for _, m := range p.Matches {
+				predicted[m.AgentID] = m
+			}
+		}
+	}
+
+	view := agentMatchesView{TaskID: task.ID, Skills: task.Skills(), Matches: []agentMatchView{}}
+	for _, m := range matches {
+		a := m.Agent
+		v := agentMatchView{
+			AgentID:        a.ID,
+			Name:           a.Name,
+			Status:         a.Status,
+			Skills:         a.Skills,
+			Score:          m.Score,
+			SkillMatches:   m.SkillMatches,
+			CompletedTasks: a.Record.CompletedTasks,
+			SuccessRate:    a.Record.SuccessRate,
+			Reasons:        []string{},
+			RiskFactors:    []string{},
+		}
+		if p, ok := predicted[a.ID]; ok {
+			v.Predicted = true
+			v.PredictedSuccess = p.PredictedSuccess
+			v.PredictedTimeline = p.PredictedTimeline
+			v.Confidence = p.Confidence
+			v.Reasons = append(v.Reasons, p.Reasons...)
+			v.RiskFactors = append(v.RiskFactors, p.RiskFactors...)
+		}
+		view.Matches = append(view.Matches, v)
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		colorPrimary.Printf("  Agents for %s\n", truncate(task.Title, 50))
+		colorDim.Printf("  Skills: %s\n", firstNonEmpty(strings.Join(view.Skills, ", "), "none listed"))
+		fmt.Println()
+		if len(view.Matches) == 0 {
+			colorWarning.Println("  No available agents.")
+			fmt.Println()
+			colorDim.Println("  Search all agents: gigclaw agents search --skill <skill>")
+			fmt.Println()
+			return
+		}
+		for i, m := range view.Matches {
+			fmt.Printf("  %d. ", i+1)
+			colorHighlight.Print(firstNonEmpty(m.Name, m.AgentID))
+			colorDim.Printf(" (%s)  ", m.AgentID)
+			colorSuccess.Printf("score %s\n", formatAmount(m.Score))
+			fmt.Printf("     %s · %d of %d skills · %d tasks, %.0f%% success\n",
+				formatStatus(m.Status), m.SkillMatches, len(view.Skills), m.CompletedTasks, m.SuccessRate)
+			if m.Predicted {
+				colorValue.Printf("     Predicted: %.0f%% success in %s (confidence %.0f%%)\n",
+					m.PredictedSuccess, strings.ToLower(m.PredictedTimeline), m.Confidence*100)
+			}
+			for _, r := range m.Reasons {
+				colorDim.Printf("     • %s\n", r)
+			}
+			for _, r := range m.RiskFactors {
+				colorWarning.Printf("     ⚠ %s\n", r)
+			}
+			fmt.Println()
+		}
+		colorDim.Printf("  Negotiate: gigclaw negotiate start %s --with %s --price <price>\n", task.ID, view.Matches[0].AgentID)
+		fmt.Println()
+	})
+}
@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+var negotiateCmd = &cobra.Command{
+	Use:   "negotiate",
+	Short: "Negotiate the terms of a task before bidding",
+	Long: `Negotiate the terms of a task before bidding.
+
+Instead of a one-shot bid, the worker opens a negotiation with an initial
+offer and both parties trade counter-offers until one of them accepts the
+terms on the table. The server analyses every exchange and recommends a
+counter-offer.
+
+Once a negotiation is accepted, the agreed price becomes a bid from the
+worker, which is accepted straight away when the poster accepted the terms.`,
+}
+
+var negotiateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your negotiations",
+	Long: `List the negotiations an agent is a party to, most recently active
+first. Defaults to agent-id from the config file.`,
+	Example: `  gigclaw negotiate list
+  gigclaw negotiate list --status active --task 7f3a -o json`,
+	Args: cobra.NoArgs,
+	RunE: runNegotiateList,
+}
+
+var negotiateShowCmd = &cobra.Command{
+	Use:   "show <negotiation-id>",
+	Short: "Show a negotiation with its offer history and analysis",
+	Long: `Show a negotiation: the terms on the table, every offer made so far,
+the messages exchanged and the server's analysis with its recommended
+counter-offer.
+
+Table and CSV output list the offers; JSON and YAML output carry the
+negotiation, its offers, messages and analysis.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNegotiateShow,
+}
+
+var negotiateChatCmd = &cobra.Command{
+	Use:   "chat <negotiation-id>",
+	Short: "Negotiate interactively",
+	Long: `Negotiate interactively: show the offer history and the server's
+analysis, then read commands until the negotiation ends or you quit.
+
+` + negotiateChatHelp,
+	Aliases: []string{"interactive"},
+	Args:    cobra.ExactArgs(1),
+	RunE:    runNegotiateChat,
+}
+
+// negotiateChatHelp lists the commands of negotiate chat
+const negotiateChatHelp = `Commands:
+  counter <price> [message]   Offer a new price
+  say <message>               Send a message without changing the terms
+  recommended                 Make the server's recommended counter-offer
+  accept                      Accept the terms on the table
+  cancel [reason]             Walk away
+  refresh                     Reload the negotiation (or press Enter)
+  quit                        Leave; the negotiation stays open`
+
+var (
+	negotiateListAgent  string
+	negotiateListStatus string
+	negotiateListTask   string
+
+	negotiateChatAs string
+)
+
+// negotiationStatuses are the statuses a negotiation moves through
+var negotiationStatuses = []string{gigclaw.NegotiationActive, gigclaw.NegotiationAgreed, gigclaw.NegotiationDeadlocked, gigclaw.NegotiationCancelled}
+
+func init() {
+	rootCmd.AddCommand(negotiateCmd)
+	negotiateCmd.AddCommand(negotiateListCmd)
+	negotiateCmd.AddCommand(negotiateShowCmd)
+	negotiateCmd.AddCommand(negotiateChatCmd)
+
+	negotiateListCmd.Flags().StringVar(&negotiateListAgent, "agent", "", "List this agent's negotiations (default agent-id from the config file)")
+	negotiateListCmd.Flags().StringVarP(&negotiateListStatus, "status", "s", "", "Only negotiations with this status: "+strings.Join(negotiationStatuses, ", "))
+	negotiateListCmd.Flags().StringVar(&negotiateListTask, "task", "", "Only negotiations over this task")
+
+	negotiateChatCmd.Flags().StringVar(&negotiateChatAs, "as", "", "Your agent ID (default agent-id from the config file)")
+}
+
+func runNegotiateList(cmd *cobra.Command, args []string) error {
+	if negotiateListStatus != "" && !containsFold(negotiationStatuses, negotiateListStatus) {
+		return fmt.Errorf("invalid --status %q: must be one of %s", negotiateListStatus, strings.Join(negotiationStatuses, ", "))
+	}
+	agent, err := actingAgent(negotiateListAgent, "--agent")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	negotiations, err := client.ListAgentNegotiations(cmd.Context(), agent)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	// The agent route takes no filters
+	var matched []gigclaw.Negotiation
+	view := negotiationListView{}
+	for _, n := range negotiations {
+		if (negotiateListStatus == "" || strings.EqualFold(n.Status, negotiateListStatus)) &&
+			(negotiateListTask == "" || n.TaskID == negotiateListTask) {
+			matched = append(matched, n)
+			view = append(view, newNegotiationView(n))
+		}
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view) == 0 {
+			colorWarning.Printf("  No negotiations found for %s.\n", agent)
+			fmt.Println()
+			return
+		}
+
+		colorLabel.Printf("  Found ")
+		colorHighlight.Printf("%d", len(view))
+		colorLabel.Printf(" negotiation(s) for %s\n", agent)
+		fmt.Println()
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("ID")+"\t"+bold.Sprint("TASK")+"\t"+bold.Sprint("STATUS")+"\t"+
+			bold.Sprint("WITH")+"\t"+bold.Sprint("PRICE")+"\t"+bold.Sprint("MESSAGES")+"\t"+bold.Sprint("LAST ACTIVITY"))
+		for _, n := range matched {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+				colorDim.Sprint(n.ID),
+				colorValue.Sprint(n.TaskID),
+				formatStatus(n.Status),
+				colorValue.Sprint(firstNonEmpty(n.Counterparty(agent), n.PosterID+" → "+n.WorkerID)),
+				colorPrimary.Sprint(formatPrice(n.ProposedTerms.Price)),
+				len(n.Messages),
+				colorDim.Sprint(n.LastActivity.Local().Format("Jan 02 15:04")),
+			)
+		}
+		w.Flush()
+		fmt.Println()
+		colorDim.Println("  Show a negotiation: gigclaw negotiate show <negotiation-id>")
+		fmt.Println()
+	})
+}
+
+func runNegotiateShow(cmd *cobra.Command, args []string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	n, err := client.GetNegotiation(cmd.Context(), args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := newNegotiationDetailView(*n, negotiationAnalysis(cmd.Context(), client, n))
+	return render(view, func() { printNegotiationDetail(view) })
+}
+
+// negotiationAnalysis returns the server's analysis of a negotiation,
+// falling back to the one it carries. The lookup is best effort.
+func negotiationAnalysis(ctx context.Context, client *gigclaw.Client, n *gigclaw.Negotiation) *gigclaw.NegotiationAnalysis {
+	analysis, err := client.GetNegotiationAnalysis(ctx, n.ID)
+	if err != nil {
+		logger.Debug("Negotiation analysis unavailable", err)
+		return n.Analysis
+	}
+	return analysis
+}
+
+// formatPrice prints a price, or "?" when it is unknown
+func formatPrice(price float64) string {
+	if price == 0 {
+		return "?"
+	}
+	return formatAmount(price)
+}
+
+// describeTerms summarises terms on one line, e.g. "90, 3 days"
+func describeTerms(t termsView) string {
+	parts := []string{formatPrice(t.Price)}
+	if t.Timeline != "" {
+		parts = append(parts, t.Timeline)
+	}
+	if len(t.Milestones) > 0 {
+		parts = append(parts, fmt.Sprintf("%d milestone(s)", len(t.Milestones)))
+	}
+	if s := t.PaymentSchedule; s != nil {
+		parts = append(parts, fmt.Sprintf("%s%%/%s%%/%s%% upfront/completion/verification",
+			formatAmount(s.Upfront), formatAmount(s.OnCompletion), formatAmount(s.OnVerification)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printNegotiationDetail prints the decorated negotiate show output
+func printNegotiationDetail(v negotiationDetailView) {
+	label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+	fmt.Println()
+	colorPrimary.Printf("  Negotiation %s\n", v.ID)
+	fmt.Println()
+
+	label("Task")
+	colorValue.Println(v.TaskID)
+	label("Status")
+	fmt.Println(formatStatus(v.Status))
+	label("Poster")
+	colorValue.Println(v.PosterID)
+	label("Worker")
+	colorValue.Println(v.WorkerID)
+	label("On the table")
+	colorPrimary.Println(describeTerms(v.Terms))
+	for _, m := range v.Terms.Milestones {
+		colorDim.Printf("  %-15s %s (by %s, %s)\n", "", m.Description, m.Deadline, formatAmount(m.Payment))
+	}
+	if v.CancelReason != "" {
+		label("Cancelled")
+		colorValue.Println(v.CancelReason)
+	}
+
+	// Offer history
+	fmt.Println()
+	colorHighlight.Printf("  Offers (%d)\n", len(v.Offers))
+	fmt.Println()
+	for i, o := range v.Offers {
+		kind := "counter"
+		if i == 0 {
+			kind = "opening"
+		}
+		colorDim.Printf("  %s  ", formatOfferTime(o.Time))
+		colorLabel.Printf("%-8s", kind)
+		fmt.Printf(" %-12s ", o.FromAgentID)
+		colorPrimary.Println(describeTerms(o.Terms))
+	}
+
+	// Messages
+	if len(v.Messages) > 0 {
+		fmt.Println()
+		colorHighlight.Printf("  Messages (%d)\n", len(v.Messages))
+		for _, m := range v.Messages {
+			fmt.Println()
+			colorValue.Printf("  %s", m.FromAgentID)
+			colorDim.Printf("  %s  %s\n", formatOfferTime(m.Time), m.Sentiment)
+			for _, line := range strings.Split(strings.TrimRight(m.Message, "\n"), "\n") {
+				fmt.Println("    " + line)
+			}
+		}
+	}
+
+	// Analysis
+	fmt.Println()
+	colorHighlight.Println("  Analysis")
+	if v.Analysis == nil {
+		colorDim.Println("  Unavailable")
+	} else {
+		a := v.Analysis
+		label("Deal likelihood")
+		colorValue.Printf("%.0f%%", a.LikelihoodOfDeal*100)
+		colorDim.Printf(" (%s, %s)\n", a.Sentiment, a.Style)
+		label("Next step")
+		colorValue.Println(a.NextBestAction)
+		if a.RecommendedCounter != nil {
+			label("Recommended")
+			colorSuccess.Println(describeTerms(*a.RecommendedCounter))
+		}
+	}
+	fmt.Println()
+
+	if v.Status == gigclaw.NegotiationActive {
+		colorDim.Println("  Counter:  gigclaw negotiate counter " + v.ID + " --price <amount>")
+		colorDim.Println("  Accept:   gigclaw negotiate accept " + v.ID)
+		fmt.Println()
+	}
+}
+
+// formatOfferTime prints an RFC 3339 time from a view in local time
+func formatOfferTime(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return t.Local().Format("Jan 02 15:04:05")
+}
+
+func runNegotiateChat(cmd *cobra.Command, args []string) error {
+	if !decorated() || !isatty.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("negotiate chat needs a terminal; use negotiate counter, accept and cancel in scripts")
+	}
+	agent, err := actingAgent(negotiateChatAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	ctx := cmd.Context()
+	n, err := client.GetNegotiation(ctx, args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if n.Counterparty(agent) == "" {
+		return notPartyError(agent, n)
+	}
+
+	in := bufio.NewScanner(os.Stdin)
+	for {
+		printNegotiationDetail(newNegotiationDetailView(*n, negotiationAnalysis(ctx, client, n)))
+		if n.Status != gigclaw.NegotiationActive {
+			return nil
+		}
+
+		colorPrimary.Printf("%s> ", agent)
+		if !in.Scan() {
+			fmt.Println()
+			return in.Err()
+		}
+		verb, rest, _ := strings.Cut(strings.TrimSpace(in.Text()), " ")
+		rest = strings.TrimSpace(rest)
+
+		var updated *gigclaw.Negotiation
+		switch strings.ToLower(verb) {
+		case "", "refresh", "r":
+		case "quit", "exit", "q":
+			return nil
+		case "help", "?":
+			fmt.Println(negotiateChatHelp)
+			continue
+		case "counter", "c":
+			priceArg, message, _ := strings.Cut(rest, " ")
+			price, perr := strconv.ParseFloat(priceArg, 64)
+			if perr != nil || price <= 0 {
+				logger.Warning("Usage: counter <price> [message]")
+				continue
+			}
+			updated, _, err = sendCounter(ctx, client, n, agent, &gigclaw.NegotiationTerms{Price: price}, strings.TrimSpace(message), "")
+		case "say", "s":
+			if rest == "" {
+				logger.Warning("Usage: say <message>")
+				continue
+			}
+			updated, _, err = sendCounter(ctx, client, n, agent, nil, rest, "")
+		case "recommended":
+			terms, rerr := recommendedCounter(ctx, client, n)
+			if rerr != nil {
+				logger.Warning(rerr.Error())
+				continue
+			}
+			updated, _, err = sendCounter(ctx, client, n, agent, terms, "", "")
+		case "accept":
+			if cerr := confirmAction(acceptQuestion(n, agent, true), false); cerr != nil {
+				continue
+			}
+			updated, _, err = acceptTerms(ctx, client, n, agent, "")
+			if err == nil {
+				var deal *negotiatedBidView
+				deal, err = convertToBid(ctx, client, updated, agent, "")
+				if err == nil {
+					printNegotiatedBid(deal)
+				}
+			}
+		case "cancel":
+			updated, _, err = cancelTerms(ctx, client, n, agent, rest, "")
+		default:
+			logger.Warning(fmt.Sprintf("Unknown command %q; type help for the list", verb))
+			continue
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, HandleAPIError(err))
+			continue
+		}
+
+		if updated != nil {
+			n = updated
+		}
+		if n, err = client.GetNegotiation(ctx, n.ID); err != nil {
+			return HandleAPIError(err)
+		}
+	}
+}
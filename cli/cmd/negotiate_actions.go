@@ -0,0 +1,675 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+)
+
+var negotiateStartCmd = &cobra.Command{
+	Use:   "start <task-id>",
+	Short: "Open a negotiation over a task",
+	Long: `Open a negotiation over a task that is still open for bids.
+
+As a worker, --price is your opening offer to the task's poster. As the
+poster, name the worker with --with; --price is then the offer you invite
+them to negotiate from.
+
+--min-price (worker) and --max-price (poster) tell the server your limit,
+which it uses to recommend counter-offers.`,
+	Example: `  gigclaw negotiate start 7f3a --price 120 --timeline "5 days"
+  gigclaw negotiate start 7f3a --as poster-1 --with agent-7 --price 80 --max-price 100`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNegotiateStart,
+}
+
+var negotiateCounterCmd = &cobra.Command{
+	Use:   "counter <negotiation-id>",
+	Short: "Make a counter-offer",
+	Long: `Make a counter-offer, or send a message without changing the terms.
+
+Terms you leave out stay as they are. --recommended makes the counter-offer
+the server recommends.`,
+	Example: `  gigclaw negotiate counter neg-17 --price 95 -m "95 and it's a deal"
+  gigclaw negotiate counter neg-17 --timeline "3 days"
+  gigclaw negotiate counter neg-17 --recommended`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNegotiateCounter,
+}
+
+var negotiateAcceptCmd = &cobra.Command{
+	Use:   "accept <negotiation-id>",
+	Short: "Accept the terms on the table",
+	Long: `Accept the terms on the table and turn them into a bid.
+
+The agreed price is placed as a bid from the worker. When you are the
+poster, the bid is accepted straight away, locking the funds in escrow;
+when you are the worker, the poster accepts it with task accept. Pass
+--no-bid to only accept the terms.
+
+Re-running accept on an agreed negotiation finishes turning it into a bid,
+without placing a second one. Accepting locks funds, so the poster is asked
+for confirmation. Without a terminal, pass --yes.`,
+	Example: `  gigclaw negotiate accept neg-17
+  gigclaw negotiate accept neg-17 --as poster-1 --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNegotiateAccept,
+}
+
+var negotiateCancelCmd = &cobra.Command{
+	Use:   "cancel <negotiation-id>",
+	Short: "Walk away from a negotiation",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNegotiateCancel,
+}
+
+var (
+	negotiateStartAs       string
+	negotiateStartWith     string
+	negotiateStartPrice    float64
+	negotiateStartTimeline string
+	negotiateStartMin      float64
+	negotiateStartMax      float64
+	negotiateStartIdemKey  string
+
+	counterPrice       float64
+	counterTimeline    string
+	counterMessage     string
+	counterRecommended bool
+	counterAs          string
+	counterIdemKey     string
+
+	negotiateAcceptAs      string
+	negotiateAcceptNoBid   bool
+	negotiateAcceptYes     bool
+	negotiateAcceptIdemKey string
+
+	negotiateCancelAs      string
+	negotiateCancelReason  string
+	negotiateCancelIdemKey string
+)
+
+func init() {
+	negotiateCmd.AddCommand(negotiateStartCmd)
+	negotiateCmd.AddCommand(negotiateCounterCmd)
+	negotiateCmd.AddCommand(negotiateAcceptCmd)
+	negotiateCmd.AddCommand(negotiateCancelCmd)
+
+	negotiateStartCmd.Flags().Float64VarP(&negotiateStartPrice, "price", "p", 0, "Opening price (required)")
+	negotiateStartCmd.Flags().StringVar(&negotiateStartTimeline, "timeline", "", "Opening timeline, e.g. \"5 days\"")
+	negotiateStartCmd.Flags().StringVar(&negotiateStartAs, "as", "", "Your agent ID (default agent-id from the config file)")
+	negotiateStartCmd.Flags().StringVar(&negotiateStartWith, "with", "", "The worker to negotiate with, when you are the poster")
+	negotiateStartCmd.Flags().Float64Var(&negotiateStartMin, "min-price", 0, "Lowest price you accept, as the worker")
+	negotiateStartCmd.Flags().Float64Var(&negotiateStartMax, "max-price", 0, "Highest price you pay, as the poster")
+	negotiateStartCmd.Flags().StringVar(&negotiateStartIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never starts twice")
+	negotiateStartCmd.MarkFlagRequired("price")
+
+	negotiateCounterCmd.Flags().Float64VarP(&counterPrice, "price", "p", 0, "New price")
+	negotiateCounterCmd.Flags().StringVar(&counterTimeline, "timeline", "", "New timeline")
+	negotiateCounterCmd.Flags().StringVarP(&counterMessage, "message", "m", "", "Message to the other party (at most 1000 characters)")
+	negotiateCounterCmd.Flags().BoolVar(&counterRecommended, "recommended", false, "Make the server's recommended counter-offer")
+	negotiateCounterCmd.Flags().StringVar(&counterAs, "as", "", "Your agent ID (default agent-id from the config file)")
+	negotiateCounterCmd.Flags().StringVar(&counterIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never sends twice")
+	negotiateCounterCmd.MarkFlagsMutuallyExclusive("recommended", "price")
+	negotiateCounterCmd.MarkFlagsMutuallyExclusive("recommended", "timeline")
+
+	negotiateAcceptCmd.Flags().StringVar(&negotiateAcceptAs, "as", "", "Your agent ID (default agent-id from the config file)")
+	negotiateAcceptCmd.Flags().BoolVar(&negotiateAcceptNoBid, "no-bid", false, "Only accept the terms; do not place or accept a bid")
+	negotiateAcceptCmd.Flags().BoolVarP(&negotiateAcceptYes, "yes", "y", false, "Accept without asking for confirmation")
+	negotiateAcceptCmd.Flags().StringVar(&negotiateAcceptIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never accepts or bids twice")
+
+	negotiateCancelCmd.Flags().StringVarP(&negotiateCancelReason, "reason", "r", "", "Why you are walking away")
+	negotiateCancelCmd.Flags().StringVar(&negotiateCancelAs, "as", "", "Your agent ID (default agent-id from the config file)")
+	negotiateCancelCmd.Flags().StringVar(&negotiateCancelIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never cancels twice")
+}
+
+// negotiatedBidView is the bid an accepted negotiation turned into
+type negotiatedBidView struct {
+	TaskID   string  `json:"taskId"`
+	BidID    string  `json:"bidId"`
+	AgentID  string  `json:"agentId"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Accepted bool    `json:"accepted"` // the bid was accepted, locking the funds
+	Note     string  `json:"note,omitempty"`
+}
+
+// negotiationActionView is the result of negotiate start, counter, accept
+// and cancel
+type negotiationActionView struct {
+	negotiationView
+	Action         string             `json:"action"`
+	Analysis       *analysisView      `json:"analysis,omitempty"` // counter only
+	Bid            *negotiatedBidView `json:"bid,omitempty"`      // accept only
+	IdempotencyKey string             `json:"idempotencyKey"`
+	Replayed       bool               `json:"replayed"`
+}
+
+func (a negotiationActionView) columns() []string {
+	return append(append([]string{}, negotiationColumns...), "BID", "IDEMPOTENCY KEY", "REPLAYED")
+}
+
+func (a negotiationActionView) rows() [][]string {
+	bid := ""
+	if a.Bid != nil {
+		bid = a.Bid.BidID
+	}
+	return [][]string{append(a.row(), bid, a.IdempotencyKey, strconv.FormatBool(a.Replayed))}
+}
+
+// notPartyError reports that agent cannot act in a negotiation
+func notPartyError(agent string, n *gigclaw.Negotiation) error {
+	return friendlyError(nil,
+		fmt.Sprintf("%s is not a party to negotiation %s", agent, n.ID),
+		[]string{fmt.Sprintf("Poster: %s, worker: %s", n.PosterID, n.WorkerID)},
+		"Act as one of them with --as")
+}
+
+// requireActive fails unless the negotiation is still open. A re-run with
+// an idempotency key may find its own action already applied.
+func requireActive(n *gigclaw.Negotiation, action, idemKey string) error {
+	if n.Status == gigclaw.NegotiationActive || idemKey != "" {
+		return nil
+	}
+	return friendlyError(nil,
+		fmt.Sprintf("Cannot %s negotiation %s: it is %s", action, n.ID, n.Status),
+		nil,
+		"See how it ended: gigclaw negotiate show "+n.ID)
+}
+
+func runNegotiateStart(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	if negotiateStartPrice <= 0 {
+		return fmt.Errorf("--price must be positive")
+	}
+	agent, err := actingAgent(negotiateStartAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if !strings.EqualFold(task.Status, "posted") && negotiateStartIdemKey == "" {
+		return friendlyError(nil,
+			fmt.Sprintf("Cannot negotiate task %s: it is %s", task.ID, task.Status),
+			nil,
+			"Negotiations are for tasks still open for bids: gigclaw task list --status posted")
+	}
+
+	req := gigclaw.StartNegotiationRequest{
+		TaskID:     task.ID,
+		InitialBid: gigclaw.NegotiationTerms{Price: negotiateStartPrice, Timeline: negotiateStartTimeline},
+	}
+	var constraints gigclaw.NegotiationConstraints
+	if agent == task.PosterID {
+		if negotiateStartWith == "" {
+			return fmt.Errorf("you posted task %s: name the worker to negotiate with using --with", task.ID)
+		}
+		if negotiateStartMin > 0 {
+			return fmt.Errorf("--min-price is the worker's limit; as the poster, use --max-price")
+		}
+		req.PosterID, req.WorkerID = agent, negotiateStartWith
+		constraints.Poster.MaxPrice = negotiateStartMax
+	} else {
+		if negotiateStartMax > 0 {
+			return fmt.Errorf("--max-price is the poster's limit; as the worker, use --min-price")
+		}
+		req.PosterID, req.WorkerID = firstNonEmpty(task.PosterID, negotiateStartWith), agent
+		constraints.Worker.MinPrice = negotiateStartMin
+	}
+	if req.PosterID == "" {
+		return fmt.Errorf("task %s does not name its poster: pass the poster's agent ID with --with", task.ID)
+	}
+	if req.PosterID == req.WorkerID {
+		return fmt.Errorf("cannot negotiate with yourself (%s)", agent)
+	}
+	if negotiateStartMin > 0 || negotiateStartMax > 0 {
+		req.Constraints = &constraints
+	}
+
+	var n *gigclaw.Negotiation
+	res, err := runJournaled("negotiate start", negotiateStartIdemKey, []interface{}{req}, func(key string) (string, error) {
+		var err error
+		n, err = client.StartNegotiation(cmd.Context(), req, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return n.ID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if res.Replayed {
+		if n, err = client.GetNegotiation(cmd.Context(), res.Result); err != nil {
+			return HandleAPIError(err)
+		}
+	}
+
+	view := negotiationActionView{
+		negotiationView: newNegotiationView(*n),
+		Action:          "start",
+		IdempotencyKey:  res.Key,
+		Replayed:        res.Replayed,
+	}
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Negotiation already started with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("🤝 Negotiation started")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Negotiation:")
+		colorValue.Println(n.ID)
+		colorLabel.Printf("  %-15s ", "Task:")
+		colorValue.Printf("%s (budget %s %s)\n", task.ID, formatAmount(task.Budget), task.Currency)
+		colorLabel.Printf("  %-15s ", "With:")
+		colorValue.Println(n.Counterparty(agent))
+		colorLabel.Printf("  %-15s ", "Opening offer:")
+		colorPrimary.Println(describeTerms(view.Terms))
+		fmt.Println()
+		fmt.Println("Follow the negotiation with:")
+		fmt.Println("  gigclaw negotiate chat " + n.ID)
+	})
+}
+
+// sendCounter sends a message with optional new terms. Without a message,
+// one describing the terms is sent.
+func sendCounter(ctx context.Context, client *gigclaw.Client, n *gigclaw.Negotiation, agent string, terms *gigclaw.NegotiationTerms, message, idemKey string) (*gigclaw.Negotiation, *journaledResult, error) {
+	if n.Counterparty(agent) == "" {
+		return nil, nil, notPartyError(agent, n)
+	}
+	if err := requireActive(n, "counter in", idemKey); err != nil {
+		return nil, nil, err
+	}
+
+	message = strings.TrimSpace(message)
+	if message == "" {
+		if terms == nil {
+			return nil, nil, fmt.Errorf("nothing to send: give new terms or a message")
+		}
+		message = "Counter-offer: " + describeTerms(newTermsView(*terms))
+	}
+	if n := utf8.RuneCountInString(message); n > gigclaw.MaxNegotiationMessage {
+		return nil, nil, fmt.Errorf("message is %d characters long (at most %d allowed)", n, gigclaw.MaxNegotiationMessage)
+	}
+
+	req := gigclaw.NegotiationMessageRequest{FromAgentID: agent, Message: message, ProposedTerms: terms}
+	var updated *gigclaw.Negotiation
+	res, err := runJournaled("negotiate counter", idemKey, []interface{}{n.ID, req}, func(key string) (string, error) {
+		var err error
+		updated, err = client.SendNegotiationMessage(ctx, n.ID, req, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return n.ID, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Replayed {
+		if updated, err = client.GetNegotiation(ctx, n.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return updated, res, nil
+}
+
+// recommendedCounter returns the counter-offer the server recommends
+func recommendedCounter(ctx context.Context, client *gigclaw.Client, n *gigclaw.Negotiation) (*gigclaw.NegotiationTerms, error) {
+	analysis := negotiationAnalysis(ctx, client, n)
+	if analysis == nil || analysis.RecommendedCounter == nil {
+		return nil, fmt.Errorf("the server has no recommended counter-offer for negotiation %s", n.ID)
+	}
+	return analysis.RecommendedCounter, nil
+}
+
+func runNegotiateCounter(cmd *cobra.Command, args []string) error {
+	if counterPrice < 0 {
+		return fmt.Errorf("--price must be positive")
+	}
+	agent, err := actingAgent(counterAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	ctx := cmd.Context()
+	n, err := client.GetNegotiation(ctx, args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	var terms *gigclaw.NegotiationTerms
+	switch {
+	case counterRecommended:
+		if terms, err = recommendedCounter(ctx, client, n); err != nil {
+			return err
+		}
+	case counterPrice > 0 || counterTimeline != "":
+		terms = &gigclaw.NegotiationTerms{Price: counterPrice, Timeline: counterTimeline}
+	case counterMessage == "":
+		return fmt.Errorf("nothing to send: pass --price, --timeline, --recommended or --message")
+	}
+
+	updated, res, err := sendCounter(ctx, client, n, agent, terms, counterMessage, counterIdemKey)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := negotiationActionView{
+		negotiationView: newNegotiationView(*updated),
+		Action:          "counter",
+		Analysis:        newAnalysisView(updated.Analysis),
+		IdempotencyKey:  res.Key,
+		Replayed:        res.Replayed,
+	}
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Counter-offer already sent with idempotency key %s\n", res.Key)
+		} else if terms != nil {
+			fmt.Println("✅ Counter-offer sent")
+		} else {
+			fmt.Println("✅ Message sent")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "On the table:")
+		colorPrimary.Println(describeTerms(view.Terms))
+		if a := view.Analysis; a != nil {
+			colorLabel.Printf("  %-15s ", "Deal likelihood:")
+			colorValue.Printf("%.0f%%", a.LikelihoodOfDeal*100)
+			colorDim.Printf(" (%s)\n", a.NextBestAction)
+		}
+		fmt.Println()
+		fmt.Println("Follow the negotiation with:")
+		fmt.Println("  gigclaw negotiate show " + updated.ID)
+	})
+}
+
+// acceptQuestion asks to accept the terms on the table, spelling out the
+// bid that follows
+func acceptQuestion(n *gigclaw.Negotiation, agent string, bid bool) string {
+	question := fmt.Sprintf("Accept %s for task %s?", describeTerms(newTermsView(n.ProposedTerms)), n.TaskID)
+	if bid && agent == n.PosterID {
+		question += fmt.Sprintf(" %s's bid of %s is then accepted, locking it in escrow.", n.WorkerID, formatAmount(n.ProposedTerms.Price))
+	}
+	return question
+}
+
+// acceptTerms accepts the terms on the table. An agreed negotiation is
+// returned as it is, so that an interrupted accept can be finished.
+func acceptTerms(ctx context.Context, client *gigclaw.Client, n *gigclaw.Negotiation, agent, idemKey string) (*gigclaw.Negotiation, *journaledResult, error) {
+	if n.Counterparty(agent) == "" {
+		return nil, nil, notPartyError(agent, n)
+	}
+	if n.Status == gigclaw.NegotiationAgreed {
+		return n, &journaledResult{Key: idemKey, Result: n.ID, Replayed: true}, nil
+	}
+	if err := requireActive(n, "accept", ""); err != nil {
+		return nil, nil, err
+	}
+
+	var updated *gigclaw.Negotiation
+	res, err := runJournaled("negotiate accept", idemKey, []interface{}{n.ID, agent}, func(key string) (string, error) {
+		var err error
+		updated, err = client.AcceptNegotiation(ctx, n.ID, agent, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return n.ID, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Replayed {
+		if updated, err = client.GetNegotiation(ctx, n.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return updated, res, nil
+}
+
+// convertToBid turns an agreed negotiation into a bid from the worker for
+// the agreed price, and accepts it when agent is the poster. A bid already
+// placed for the negotiation is reused, so re-running never bids twice.
+func convertToBid(ctx context.Context, client *gigclaw.Client, n *gigclaw.Negotiation, agent, idemKey string) (*negotiatedBidView, error) {
+	task, err := client.GetTask(ctx, n.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	price := n.ProposedTerms.Price
+	deal := &negotiatedBidView{TaskID: task.ID, AgentID: n.WorkerID, Amount: price, Currency: task.Currency}
+	if price <= 0 {
+		deal.Note = "The agreed terms carry no price; place a bid with task bid"
+		return deal, nil
+	}
+
+	if b := task.AcceptedBid; b != nil {
+		deal.BidID = b.ID
+		deal.Accepted = b.AgentID == n.WorkerID
+		if !deal.Accepted {
+			deal.Note = fmt.Sprintf("Task %s already went to %s", task.ID, b.AgentID)
+		}
+		return deal, nil
+	}
+	if !strings.EqualFold(task.Status, "posted") {
+		deal.Note = fmt.Sprintf("Task %s is %s and no longer takes bids", task.ID, task.Status)
+		return deal, nil
+	}
+
+	message := "Negotiated in " + n.ID
+	if n.ProposedTerms.Timeline != "" {
+		message += ", timeline " + n.ProposedTerms.Timeline
+	}
+	// The API does not keep bid messages, so a bid from the worker at the
+	// agreed price is taken to be the one placed for this negotiation
+	for _, b := range task.Bids {
+		if b.AgentID == n.WorkerID && b.Amount == price {
+			deal.BidID = b.ID
+			break
+		}
+	}
+
+	// Each step is a separate request, so each needs its own key
+	stepKey := func(step string) string {
+		if idemKey == "" {
+			return ""
+		}
+		return idemKey + "-" + step
+	}
+
+	if deal.BidID == "" {
+		res, err := runJournaled("negotiate bid", stepKey("bid"), []interface{}{task.ID, n.WorkerID, price, message}, func(key string) (string, error) {
+			bid, err := client.PlaceAgentBid(ctx, task.ID, n.WorkerID, price, message, gigclaw.IdempotencyKey(key))
+			if err != nil {
+				return "", err
+			}
+			return bid.ID, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		deal.BidID = res.Result
+	}
+
+	if agent != n.PosterID {
+		deal.Note = fmt.Sprintf("%s accepts it with: gigclaw task accept %s --bid %s", n.PosterID, task.ID, deal.BidID)
+		return deal, nil
+	}
+	_, err = runJournaled("task accept", stepKey("accept"), []interface{}{task.ID, deal.BidID}, func(key string) (string, error) {
+		return deal.BidID, client.AcceptBid(ctx, task.ID, deal.BidID, gigclaw.IdempotencyKey(key))
+	})
+	if err != nil {
+		return nil, err
+	}
+	deal.Accepted = true
+	return deal, nil
+}
+
+// printNegotiatedBid prints the bid an accepted negotiation turned into
+func printNegotiatedBid(deal *negotiatedBidView) {
+	if deal == nil {
+		return
+	}
+	if deal.BidID != "" {
+		colorLabel.Printf("  %-15s ", "Bid:")
+		colorValue.Printf("%s, %s %s from %s\n", deal.BidID, formatAmount(deal.Amount), deal.Currency, deal.AgentID)
+	}
+	if deal.Accepted {
+		colorLabel.Printf("  %-15s ", "Funds:")
+		colorPrimary.Printf("%.2f %s locked in escrow\n", deal.Amount, deal.Currency)
+	}
+	if deal.Note != "" {
+		colorDim.Printf("  %-15s %s\n", "", deal.Note)
+	}
+}
+
+func runNegotiateAccept(cmd *cobra.Command, args []string) error {
+	agent, err := actingAgent(negotiateAcceptAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	ctx := cmd.Context()
+	n, err := client.GetNegotiation(ctx, args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if n.Counterparty(agent) == "" {
+		return notPartyError(agent, n)
+	}
+
+	// Only the poster's bid acceptance locks funds
+	if n.Status == gigclaw.NegotiationActive || (!negotiateAcceptNoBid && agent == n.PosterID) {
+		if err := confirmAction(acceptQuestion(n, agent, !negotiateAcceptNoBid), negotiateAcceptYes); err != nil {
+			return err
+		}
+	}
+
+	updated, res, err := acceptTerms(ctx, client, n, agent, negotiateAcceptIdemKey)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := negotiationActionView{
+		negotiationView: newNegotiationView(*updated),
+		Action:          "accept",
+		IdempotencyKey:  res.Key,
+		Replayed:        res.Replayed,
+	}
+	if !negotiateAcceptNoBid {
+		if view.Bid, err = convertToBid(ctx, client, updated, agent, negotiateAcceptIdemKey); err != nil {
+			logger.Warning(fmt.Sprintf("The terms were accepted, but turning them into a bid failed. Re-run gigclaw negotiate accept %s to finish", updated.ID))
+			return HandleAPIError(err)
+		}
+	}
+
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Negotiation %s was already agreed\n", updated.ID)
+		} else {
+			fmt.Println("🤝 Terms accepted!")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Agreed:")
+		colorSuccess.Println(describeTerms(view.Terms))
+		printNegotiatedBid(view.Bid)
+		fmt.Println()
+		if view.Bid != nil && view.Bid.Accepted {
+			fmt.Println("Track the escrow with: gigclaw escrow status " + updated.TaskID)
+		}
+	})
+}
+
+// cancelTerms walks away from an active negotiation
+func cancelTerms(ctx context.Context, client *gigclaw.Client, n *gigclaw.Negotiation, agent, reason, idemKey string) (*gigclaw.Negotiation, *journaledResult, error) {
+	if n.Counterparty(agent) == "" {
+		return nil, nil, notPartyError(agent, n)
+	}
+	if err := requireActive(n, "cancel", idemKey); err != nil {
+		return nil, nil, err
+	}
+
+	var updated *gigclaw.Negotiation
+	res, err := runJournaled("negotiate cancel", idemKey, []interface{}{n.ID, agent, reason}, func(key string) (string, error) {
+		var err error
+		updated, err = client.CancelNegotiation(ctx, n.ID, agent, reason, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return n.ID, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Replayed {
+		if updated, err = client.GetNegotiation(ctx, n.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return updated, res, nil
+}
+
+func runNegotiateCancel(cmd *cobra.Command, args []string) error {
+	agent, err := actingAgent(negotiateCancelAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	n, err := client.GetNegotiation(cmd.Context(), args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	updated, res, err := cancelTerms(cmd.Context(), client, n, agent, strings.TrimSpace(negotiateCancelReason), negotiateCancelIdemKey)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := negotiationActionView{
+		negotiationView: newNegotiationView(*updated),
+		Action:          "cancel",
+		IdempotencyKey:  res.Key,
+		Replayed:        res.Replayed,
+	}
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Negotiation already cancelled with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("Negotiation cancelled")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Negotiation:")
+		colorValue.Println(updated.ID)
+		colorLabel.Printf("  %-15s ", "Status:")
+		fmt.Println(formatStatus(updated.Status))
+		fmt.Println()
+	})
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+func TestConvertToBid(t *testing.T) {
+	agreed := &gigclaw.Negotiation{
+		ID: "neg-1", TaskID: "t1", PosterID: "alice", WorkerID: "agent-7",
+		Status: gigclaw.NegotiationAgreed, ProposedTerms: gigclaw.NegotiationTerms{Price: 120},
+	}
+
+	tests := []struct {
+		name    string
+		bids    string // bids on the task, as the API returns them
+		wantBid string
+		posted  int
+	}{
+		{
+			name:    "no bid yet",
+			bids:    `[{"id":"b0","agentId":"agent-9","amount":120,"createdAt":1767229100000,"accepted":false}]`,
+			wantBid: "b-new",
+			posted:  1,
+		},
+		{
+			name:    "bid already placed",
+			bids:    `[{"id":"b0","agentId":"agent-9","amount":120,"createdAt":1767229100000,"accepted":false},{"id":"b1","agentId":"agent-7","amount":120,"createdAt":1767229200000,"accepted":false}]`,
+			wantBid: "b1",
+		},
+		{
+			name:    "worker bid at another price",
+			bids:    `[{"id":"b1","agentId":"agent-7","amount":140,"createdAt":1767229200000,"accepted":false}]`,
+			wantBid: "b-new",
+			posted:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempHome(t)
+			posted := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/tasks/t1":
+					w.Write([]byte(`{"id":"t1","title":"Audit","budget":150,"posterId":"alice","status":"posted","bids":` + tt.bids + `,"createdAt":1767225600000}`))
+				case "POST /api/tasks/t1/bid":
+					posted++
+					w.Write([]byte(`{"message":"Bid placed","bid":{"id":"b-new","agentId":"agent-7","amount":120,"createdAt":1767229300000,"accepted":false}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+			client, err := gigclaw.NewClient(gigclaw.WithBaseURL(srv.URL), gigclaw.WithRetryPolicy(gigclaw.NoRetry))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			deal, err := convertToBid(context.Background(), client, agreed, "agent-7", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deal.BidID != tt.wantBid || posted != tt.posted || deal.Accepted {
+				t.Errorf("bid %s (posted %d, accepted %v), want %s (posted %d)", deal.BidID, posted, deal.Accepted, tt.wantBid, tt.posted)
+			}
+		})
+	}
+}
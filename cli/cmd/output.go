@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/mattn/go-isatty"
+	"github.com/schollz/progressbar/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --output
+const (
+	outputText     = "text"     // decorated output for terminals
+	outputTable    = "table"    // plain aligned columns
+	outputJSON     = "json"     // indented JSON
+	outputYAML     = "yaml"     // YAML with the same field names as JSON
+	outputCSV      = "csv"      // header row followed by one row per item
+	outputTemplate = "template" // Go template given by --template
+)
+
+var outputFormats = []string{outputText, outputTable, outputJSON, outputYAML, outputCSV, outputTemplate}
+
+var (
+	outputFlag   string
+	templateFlag string
+)
+
+// tabular is implemented by every value a command renders, so that it
+// can be printed as a table or CSV as well as JSON and YAML
+type tabular interface {
+	columns() []string
+	rows() [][]string
+}
+
+// isTerminal reports whether stdout is an interactive terminal
+func isTerminal() bool {
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// outputMode returns the active output format. Without --output, terminals
+// get decorated text and pipes get a plain table.
+func outputMode() string {
+	switch {
+	case outputFlag != "":
+		return outputFlag
+	case templateFlag != "":
+		return outputTemplate
+	case isTerminal():
+		return outputText
+	default:
+		return outputTable
+	}
+}
+
+// decorated reports whether banners, progress bars and hints should be shown
+func decorated() bool {
+	return outputMode() == outputText
+}
+
+// validateOutput checks the --output and --template flags
+func validateOutput() error {
+	mode := outputMode()
+	valid := false
+	for _, f := range outputFormats {
+		if mode == f {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown output format %q (want one of: %s)", mode, strings.Join(outputFormats, ", "))
+	}
+	if mode == outputTemplate && templateFlag == "" {
+		return fmt.Errorf("--output template requires --template")
+	}
+	if templateFlag != "" && mode != outputTemplate {
+		return fmt.Errorf("--template cannot be combined with --output %s", mode)
+	}
+	return nil
+}
+
+// render prints v in the active output format. text prints the decorated
+// terminal output and is only called in text mode.
+func render(v tabular, text func()) error {
+	return renderTo(os.Stdout, outputMode(), v, text)
+}
+
+func renderTo(w io.Writer, mode string, v tabular, text func()) error {
+	switch mode {
+	case outputText:
+		text()
+		return nil
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(v)
+	case outputYAML:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		node, err := yamlNode(dec)
+		if err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(node); err != nil {
+			return err
+		}
+		return enc.Close()
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(v.columns())
+		cw.WriteAll(v.rows())
+		return cw.Error()
+	case outputTemplate:
+		tmpl, err := template.New("output").Funcs(template.FuncMap{
+			"json": func(v interface{}) (string, error) {
+				data, err := json.Marshal(v)
+				return string(data), err
+			},
+			"join": strings.Join,
+		}).Parse(templateFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+		data, err := generic(v)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, data)
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(v.columns(), "\t"))
+		for _, row := range v.rows() {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// generic converts v to maps and slices keyed by its JSON field names, so
+// templates see the same schema as JSON
+func generic(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// yamlNode converts the next JSON value from dec to a YAML node, keeping
+// fields in schema order rather than sorting them
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if t == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		_, err := dec.Token() // closing delimiter
+		return node, err
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(t.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
+
+// newProgressBar returns a progress bar on stderr that stays hidden
+// unless output is decorated
+func newProgressBar(max int, description string, opts ...progressbar.Option) *progressbar.ProgressBar {
+	opts = append([]progressbar.Option{
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionSetVisibility(decorated()),
+	}, opts...)
+	return progressbar.NewOptions(max, opts...)
+}
@@ -0,0 +1,520 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultProfile names the settings outside any profile
+const defaultProfile = "default"
+
+// profileEnv selects the profile, unless --profile is given
+const profileEnv = "GIGCLAW_PROFILE"
+
+// profileNamePattern matches profile names; viper lowercases keys
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+var (
+	profileFlag string
+	profileErr  error // the active profile does not exist
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Switch between environments and agent identities",
+	Long: `Keep settings for several environments or agent identities in one config
+file and switch between them, e.g. devnet, staging and prod.
+
+Each profile holds its own API URL, API key (stored as by gigclaw init),
+agent ID, default currency and wallet. Settings a profile does not set are
+taken from the top of the config file, the "default" profile.
+
+The active profile is, in order: --profile, GIGCLAW_PROFILE, then the one
+chosen with gigclaw profile use. gigclaw init and gigclaw setup write to the
+active profile.`,
+	Example: `  gigclaw profile add devnet --url http://localhost:3000 --agent-id agent-dev --currency SOL
+  gigclaw profile use devnet
+  gigclaw --profile prod task list
+  GIGCLAW_PROFILE=staging gigclaw worker start`,
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile, or change the settings of one",
+	Long: `Add a profile, or change the settings given of an existing one.
+
+A new profile asks for its API key on a terminal; --key-stdin reads it from
+stdin instead. The key goes to --secret-store, under the profile's name.`,
+	Example: `  gigclaw profile add staging --url https://staging.example.com --wallet 7xKX...9fQ --use
+  gigclaw auth keys create --name ci -o json | jq -r .key | gigclaw profile add ci --key-stdin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileAdd,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the active one",
+	Long:  `Make a profile the active one. Use "default" to go back to the settings outside any profile.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile and its stored API key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileDelete,
+}
+
+var (
+	profileAddURL         string
+	profileAddAgentID     string
+	profileAddCurrency    string
+	profileAddWallet      string
+	profileAddSecretStore string
+	profileAddKeyStdin    bool
+	profileAddUse         bool
+
+	profileDeleteYes bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use (default $GIGCLAW_PROFILE, or the one chosen with gigclaw profile use)")
+
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+
+	profileAddCmd.Flags().StringVar(&profileAddURL, "url", "", "API URL")
+	profileAddCmd.Flags().StringVar(&profileAddAgentID, "agent-id", "", "Agent ID to act as")
+	profileAddCmd.Flags().StringVarP(&profileAddCurrency, "currency", "c", "", "Default currency of new tasks (USDC, SOL)")
+	profileAddCmd.Flags().StringVar(&profileAddWallet, "wallet", "", "Wallet address")
+	profileAddCmd.Flags().StringVar(&profileAddSecretStore, "secret-store", secretAuto, "Where to keep the API key: auto, keyring, file or env")
+	profileAddCmd.Flags().BoolVar(&profileAddKeyStdin, "key-stdin", false, "Read the API key from stdin")
+	profileAddCmd.Flags().BoolVar(&profileAddUse, "use", false, "Also make the profile the active one")
+
+	profileDeleteCmd.Flags().BoolVarP(&profileDeleteYes, "yes", "y", false, "Delete without asking for confirmation")
+}
+
+// activeProfile returns the name of the profile in use
+func activeProfile() string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	if name := os.Getenv(profileEnv); name != "" {
+		return name
+	}
+	return firstNonEmpty(viper.GetString("current-profile"), defaultProfile)
+}
+
+// applyProfile layers the settings of the active profile over the rest of
+// the config file, below flags and environment variables
+func applyProfile() error {
+	name := activeProfile()
+	if name == defaultProfile {
+		return nil
+	}
+	if !viper.IsSet("profiles." + name) {
+		return fmt.Errorf("profile %q not found: see gigclaw profile list", name)
+	}
+	return viper.MergeConfigMap(viper.GetStringMap("profiles." + name))
+}
+
+// createsProfile reports whether cmd may run while the active profile does
+// not exist yet, as it can create it or choose another
+func createsProfile(cmd *cobra.Command) bool {
+	return cmd == initCmd || cmd == setupCmd || cmd.Parent() == profileCmd
+}
+
+// profileSettings returns the settings of a profile in config, adding the
+// profile if create is set
+func profileSettings(config map[string]interface{}, name string, create bool) map[string]interface{} {
+	profiles, _ := config["profiles"].(map[string]interface{})
+	if profiles == nil {
+		if !create {
+			return nil
+		}
+		profiles = make(map[string]interface{})
+		config["profiles"] = profiles
+	}
+	settings, _ := profiles[name].(map[string]interface{})
+	if settings == nil && create {
+		settings = make(map[string]interface{})
+		profiles[name] = settings
+	}
+	return settings
+}
+
+// profileNames returns the profiles in config, sorted
+func profileNames(config map[string]interface{}) []string {
+	profiles, _ := config["profiles"].(map[string]interface{})
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func validateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, - and _", name)
+	}
+	return nil
+}
+
+// profileView is a profile
+type profileView struct {
+	Name      string `json:"name"`
+	Active    bool   `json:"active"`
+	APIURL    string `json:"apiUrl"`
+	APIKeyRef string `json:"apiKeyRef"`
+	AgentID   string `json:"agentId"`
+	Currency  string `json:"currency"`
+	Wallet    string `json:"wallet"`
+}
+
+var profileColumns = []string{"NAME", "ACTIVE", "API URL", "API KEY", "AGENT", "CURRENCY", "WALLET"}
+
+func (p profileView) row() []string {
+	active := ""
+	if p.Active {
+		active = "*"
+	}
+	return []string{p.Name, active, p.APIURL, p.APIKeyRef, p.AgentID, p.Currency, p.Wallet}
+}
+
+func (p profileView) columns() []string { return profileColumns }
+func (p profileView) rows() [][]string  { return [][]string{p.row()} }
+
+// profileListView is a list of profiles
+type profileListView []profileView
+
+func (l profileListView) columns() []string { return profileColumns }
+
+func (l profileListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, p := range l {
+		rows = append(rows, p.row())
+	}
+	return rows
+}
+
+// newProfileView describes the settings of a profile as written in the
+// config file, without the defaults it inherits
+func newProfileView(name string, settings map[string]interface{}) profileView {
+	setting := func(key string) string {
+		value, _ := settings[key].(string)
+		return value
+	}
+	ref := setting("api-key-ref")
+	if ref == "" && setting("api-key") != "" {
+		ref = "plaintext"
+	}
+	return profileView{
+		Name:      name,
+		Active:    name == activeProfile(),
+		APIURL:    setting("api-url"),
+		APIKeyRef: ref,
+		AgentID:   setting("agent-id"),
+		Currency:  setting("currency"),
+		Wallet:    setting("wallet"),
+	}
+}
+
+func runProfileAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if name == defaultProfile {
+		return fmt.Errorf("%q names the settings outside any profile: change them with gigclaw init", defaultProfile)
+	}
+	if !containsFold(secretStores, profileAddSecretStore) {
+		return fmt.Errorf("invalid --secret-store %q: must be one of %s", profileAddSecretStore, strings.Join(secretStores, ", "))
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	config, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	existing := profileSettings(config, name, false) != nil
+
+	changes := make(map[string]interface{})
+	for key, value := range map[string]string{
+		"api-url":  profileAddURL,
+		"agent-id": profileAddAgentID,
+		"currency": strings.ToUpper(profileAddCurrency),
+		"wallet":   profileAddWallet,
+	} {
+		if value != "" {
+			changes[key] = value
+		}
+	}
+
+	// Ask for a key for new profiles; --key-stdin sets one for any
+	var key string
+	store := defaultSecretStore(profileAddSecretStore)
+	switch {
+	case store == secretEnv:
+	case profileAddKeyStdin:
+		key, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+		if key = strings.TrimSpace(key); key == "" {
+			return fmt.Errorf("--key-stdin: no API key on stdin")
+		}
+	case !existing && decorated() && isatty.IsTerminal(os.Stdin.Fd()):
+		key, err = readSecretLine(bufio.NewReader(os.Stdin), "API Key (optional, press Enter to skip): ")
+		if err != nil {
+			return err
+		}
+	}
+	if key != "" || (store == secretEnv && cmd.Flags().Changed("secret-store")) {
+		ref, err := storeSecret(store, name, key)
+		if err != nil {
+			return friendlyError(err,
+				"Could not store the API key in "+describeSecretStore(store),
+				[]string{err.Error()},
+				"Store it in the encrypted file instead: --secret-store file")
+		}
+		changes["api-key-ref"] = ref
+	}
+
+	path, err = editConfig(func(config map[string]interface{}) error {
+		settings := profileSettings(config, name, true)
+		for k, v := range changes {
+			settings[k] = v
+		}
+		if ref, ok := changes["api-key-ref"]; ok && ref != nil {
+			delete(settings, "api-key")
+		}
+		if profileAddUse {
+			config["current-profile"] = name
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	config, err = readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if profileAddUse {
+		viper.Set("current-profile", name)
+	}
+	view := newProfileView(name, profileSettings(config, name, false))
+
+	return render(view, func() {
+		fmt.Println()
+		if existing {
+			colorSuccess.Printf("  ✓ Profile %s updated\n", name)
+		} else {
+			colorSuccess.Printf("  ✓ Profile %s added\n", name)
+		}
+		fmt.Println()
+		printProfile(view)
+		if profileAddUse {
+			fmt.Println()
+			colorSuccess.Printf("  Now using profile %s\n", name)
+		} else if !view.Active {
+			fmt.Println()
+			colorDim.Printf("  Switch to it: gigclaw profile use %s\n", name)
+		}
+		fmt.Println()
+	})
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	config, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if name != defaultProfile && profileSettings(config, name, false) == nil {
+		return friendlyError(nil,
+			fmt.Sprintf("Profile %q not found", name),
+			nil,
+			"List profiles: gigclaw profile list",
+			"Add it: gigclaw profile add "+name)
+	}
+
+	_, err = editConfig(func(config map[string]interface{}) error {
+		if name == defaultProfile {
+			delete(config, "current-profile")
+		} else {
+			config["current-profile"] = name
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	view := newProfileView(name, config)
+	if name != defaultProfile {
+		view = newProfileView(name, profileSettings(config, name, false))
+	}
+	view.Active = true
+
+	return render(view, func() {
+		fmt.Println()
+		colorSuccess.Printf("  ✓ Now using profile %s\n", name)
+		if profileFlag != "" || os.Getenv(profileEnv) != "" {
+			colorWarning.Printf("  %s or --profile still take precedence in this shell\n", profileEnv)
+		}
+		fmt.Println()
+	})
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	config, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	view := profileListView{newProfileView(defaultProfile, config)}
+	for _, name := range profileNames(config) {
+		view = append(view, newProfileView(name, profileSettings(config, name, false)))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, "  \t"+bold.Sprint("NAME")+"\t"+bold.Sprint("API URL")+"\t"+bold.Sprint("AGENT")+"\t"+
+			bold.Sprint("CURRENCY")+"\t"+bold.Sprint("API KEY"))
+		for _, p := range view {
+			marker := " "
+			name := colorValue.Sprint(p.Name)
+			if p.Active {
+				marker = colorSuccess.Sprint("*")
+				name = colorHighlight.Sprint(p.Name)
+			}
+			key := colorDim.Sprint(firstNonEmpty(p.APIKeyRef, "-"))
+			if p.APIKeyRef == "plaintext" {
+				key = colorWarning.Sprint(p.APIKeyRef)
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n",
+				marker, name,
+				colorValue.Sprint(firstNonEmpty(p.APIURL, "-")),
+				colorValue.Sprint(firstNonEmpty(p.AgentID, "-")),
+				colorValue.Sprint(firstNonEmpty(p.Currency, "-")),
+				key,
+			)
+		}
+		w.Flush()
+		fmt.Println()
+		colorDim.Println("  Profiles inherit settings they leave empty from default.")
+		fmt.Println()
+	})
+}
+
+func runProfileDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name == defaultProfile {
+		return fmt.Errorf("the default profile cannot be deleted")
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	config, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	settings := profileSettings(config, name, false)
+	if settings == nil {
+		return fmt.Errorf("profile %q not found", name)
+	}
+
+	if err := confirmAction(fmt.Sprintf("Delete profile %s and its stored API key?", name), profileDeleteYes); err != nil {
+		return err
+	}
+
+	view := newProfileView(name, settings)
+	_, err = editConfig(func(config map[string]interface{}) error {
+		profiles, _ := config["profiles"].(map[string]interface{})
+		delete(profiles, name)
+		if len(profiles) == 0 {
+			delete(config, "profiles")
+		}
+		if config["current-profile"] == name {
+			delete(config, "current-profile")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only remove a key stored for this profile; others may share theirs
+	ref, _ := settings["api-key-ref"].(string)
+	if strings.HasSuffix(ref, ":"+name) {
+		if err := removeSecret(ref); err != nil {
+			logger.Warning(fmt.Sprintf("could not remove the stored API key: %v", err))
+		}
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		colorSuccess.Printf("  ✓ Profile %s deleted\n", name)
+		if view.Active {
+			colorWarning.Println("  It was the active profile; now using default")
+		}
+		fmt.Println()
+	})
+}
+
+// printProfile prints the settings of a profile
+func printProfile(p profileView) {
+	colorLabel.Printf("  %-15s ", "Name:")
+	colorHighlight.Println(p.Name)
+	for _, field := range []struct{ label, value string }{
+		{"API URL:", p.APIURL},
+		{"API key:", p.APIKeyRef},
+		{"Agent:", p.AgentID},
+		{"Currency:", p.Currency},
+		{"Wallet:", p.Wallet},
+	} {
+		colorLabel.Printf("  %-15s ", field.label)
+		if field.value == "" {
+			colorDim.Println("(from default)")
+		} else {
+			colorValue.Println(field.value)
+		}
+	}
+}
@@ -0,0 +1,634 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reputationCmd = &cobra.Command{
+	Use:   "reputation",
+	Short: "Show, rank and rate agent reputation",
+	Long: `Agents start with a reputation of 50 that grows with completed work,
+decays by a fixed amount for every day without activity, and is scaled by
+activity streaks, bonuses and penalties. Posters rate agents 1-5 stars once
+their work is verified.`,
+}
+
+var reputationShowCmd = &cobra.Command{
+	Use:   "show [agent-id]",
+	Short: "Show an agent's reputation with its breakdown and decay",
+	Long: `Show how an agent's effective reputation is made up, how much it has
+decayed since the agent was last active and where it is heading without
+activity.
+
+When the agent has a wallet and the chain is enabled, the values in its
+on-chain reputation account are shown next to the API's. The API never
+writes that account, so it only holds what was recorded with the program
+directly.`,
+	Example: `  gigclaw reputation show
+  gigclaw reputation show agent-7 -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReputationShow,
+}
+
+var reputationLeaderboardCmd = &cobra.Command{
+	Use:     "leaderboard",
+	Short:   "Rank agents by effective reputation",
+	Example: `  gigclaw reputation leaderboard --limit 10`,
+	Args:    cobra.NoArgs,
+	RunE:    runReputationLeaderboard,
+}
+
+var reputationRateCmd = &cobra.Command{
+	Use:   "rate <task-id>",
+	Short: "Rate the agent of a verified task",
+	Long: `Rate the agent who did a task, 1 to 5 stars. Only the poster can rate,
+only once the work is verified, and only once per task. The API key in use
+must have been created for the poster (gigclaw auth keys create --agent).`,
+	Example: `  gigclaw reputation rate 123 --stars 5`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runReputationRate,
+}
+
+var (
+	leaderboardLimit int
+
+	rateStars   int
+	rateAs      string
+	rateIdemKey string
+)
+
+func init() {
+	rootCmd.AddCommand(reputationCmd)
+	reputationCmd.AddCommand(reputationShowCmd)
+	reputationCmd.AddCommand(reputationLeaderboardCmd)
+	reputationCmd.AddCommand(reputationRateCmd)
+
+	reputationLeaderboardCmd.Flags().IntVarP(&leaderboardLimit, "limit", "l", 20, "Maximum number of agents to show (at most 100)")
+
+	reputationRateCmd.Flags().IntVar(&rateStars, "stars", 0, "Rating, 1-5 stars (required)")
+	reputationRateCmd.Flags().StringVar(&rateAs, "as", "", "Poster of the task (default agent-id from the config file)")
+	reputationRateCmd.Flags().StringVar(&rateIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never rates twice")
+	reputationRateCmd.MarkFlagRequired("stars")
+}
+
+// reputationStepView is a step from base to effective reputation
+type reputationStepView struct {
+	Label  string  `json:"label"`
+	Detail string  `json:"detail"`
+	Points float64 `json:"points"` // change to the reputation
+}
+
+// reputationPointView is an agent's reputation on a day, past or projected
+type reputationPointView struct {
+	Date       string  `json:"date"`
+	Reputation float64 `json:"reputation"`
+	Projected  bool    `json:"projected"`
+}
+
+// chainValueView is a value kept by the API next to the on-chain one
+type chainValueView struct {
+	Field string `json:"field"`
+	API   string `json:"api"`
+	Chain string `json:"chain"`
+}
+
+// onChainView is the agent's on-chain reputation account, which the API
+// never writes
+type onChainView struct {
+	Read    bool             `json:"read"`
+	Reason  string           `json:"reason,omitempty"` // why it was not read
+	Account string           `json:"account,omitempty"`
+	Network string           `json:"network,omitempty"`
+	Values  []chainValueView `json:"values,omitempty"`
+}
+
+// reputationView is an agent's reputation
+type reputationView struct {
+	AgentID             string                `json:"agentId"`
+	EffectiveReputation float64               `json:"effectiveReputation"`
+	BaseReputation      float64               `json:"baseReputation"`
+	Breakdown           []reputationStepView  `json:"breakdown"`
+	StreakDays          int                   `json:"streakDays"`
+	DecayRate           float64               `json:"decayRate"`
+	DaysInactive        int                   `json:"daysInactive"`
+	DecayAmount         float64               `json:"decayAmount"`
+	LastActivity        string                `json:"lastActivity"`
+	History             []reputationPointView `json:"history"`
+	Rating              float64               `json:"rating"`
+	RatingCount         int                   `json:"ratingCount"`
+	Registered          bool                  `json:"registered"`
+	CompletedTasks      int                   `json:"completedTasks"`
+	FailedTasks         int                   `json:"failedTasks"`
+	SuccessRate         float64               `json:"successRate"`
+	OnChain             onChainView           `json:"onChain"`
+}
+
+func (r reputationView) columns() []string {
+	return []string{"AGENT", "REPUTATION", "BASE", "DECAY", "DAYS INACTIVE", "STREAK", "RATING", "RATINGS", "ON-CHAIN"}
+}
+
+func (r reputationView) rows() [][]string {
+	chain := r.OnChain.Reason
+	if r.OnChain.Read {
+		chain = firstNonEmpty(r.OnChain.Network, "read")
+	}
+	return [][]string{{
+		r.AgentID, formatAmount(r.EffectiveReputation), formatAmount(r.BaseReputation), formatAmount(r.DecayAmount),
+		strconv.Itoa(r.DaysInactive), strconv.Itoa(r.StreakDays), formatAmount(r.Rating), strconv.Itoa(r.RatingCount), chain,
+	}}
+}
+
+// leaderboardView is the reputation leaderboard
+type leaderboardView struct {
+	Total   int                    `json:"total"`
+	Entries []leaderboardEntryView `json:"entries"`
+}
+
+// leaderboardEntryView is an agent's place on the leaderboard
+type leaderboardEntryView struct {
+	Rank                int     `json:"rank"`
+	AgentID             string  `json:"agentId"`
+	EffectiveReputation float64 `json:"effectiveReputation"`
+	BaseReputation      float64 `json:"baseReputation"`
+	StreakDays          int     `json:"streakDays"`
+	Rating              float64 `json:"rating"`
+	Mine                bool    `json:"mine"`
+}
+
+func (l leaderboardView) columns() []string {
+	return []string{"RANK", "AGENT", "REPUTATION", "BASE", "STREAK", "RATING"}
+}
+
+func (l leaderboardView) rows() [][]string {
+	rows := make([][]string, 0, len(l.Entries))
+	for _, e := range l.Entries {
+		rows = append(rows, []string{
+			strconv.Itoa(e.Rank), e.AgentID, formatAmount(e.EffectiveReputation), formatAmount(e.BaseReputation),
+			strconv.Itoa(e.StreakDays), formatAmount(e.Rating),
+		})
+	}
+	return rows
+}
+
+// ratingView is the outcome of reputation rate
+type ratingView struct {
+	TaskID         string  `json:"taskId"`
+	AgentID        string  `json:"agentId"`
+	Stars          int     `json:"stars"`
+	Rating         float64 `json:"rating"`
+	RatingCount    int     `json:"ratingCount"`
+	IdempotencyKey string  `json:"idempotencyKey"`
+	Replayed       bool    `json:"replayed"` // an earlier run already rated the agent
+}
+
+func (r ratingView) columns() []string {
+	return []string{"TASK", "AGENT", "STARS", "RATING", "RATINGS", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (r ratingView) rows() [][]string {
+	return [][]string{{
+		r.TaskID, r.AgentID, strconv.Itoa(r.Stars), formatAmount(r.Rating), strconv.Itoa(r.RatingCount),
+		r.IdempotencyKey, strconv.FormatBool(r.Replayed),
+	}}
+}
+
+func runReputationShow(cmd *cobra.Command, args []string) error {
+	var flag string
+	if len(args) > 0 {
+		flag = args[0]
+	}
+	agentID, err := actingAgent(flag, "an agent ID")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	ctx := cmd.Context()
+
+	rep, err := client.GetReputation(ctx, agentID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := newReputationView(rep, time.Now())
+
+	// The track record and wallet come with the registration, which is optional
+	agent, err := client.GetAgent(ctx, agentID)
+	switch {
+	case err == nil:
+		view.Registered = true
+		view.CompletedTasks = agent.Record.CompletedTasks
+		view.FailedTasks = agent.Record.FailedTasks
+		view.SuccessRate = agent.Record.SuccessRate
+		view.OnChain = readOnChain(ctx, client, rep, agent)
+	case errors.Is(err, gigclaw.ErrNotFound):
+		view.OnChain.Reason = "agent not registered"
+	default:
+		logger.Debug("Agent unavailable to read its on-chain account", err)
+		view.OnChain.Reason = "agent unavailable"
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		printReputation(view)
+		fmt.Println()
+	})
+}
+
+// newReputationView breaks a reputation down the way the API computes it:
+// decay is taken off the base, then the streak bonus, bonuses and penalties
+// scale what is left
+func newReputationView(rep *gigclaw.Reputation, now time.Time) reputationView {
+	view := reputationView{
+		AgentID:             rep.AgentID,
+		EffectiveReputation: rep.EffectiveReputation,
+		BaseReputation:      rep.BaseReputation,
+		StreakDays:          rep.StreakDays,
+		DecayRate:           rep.DecayRate,
+		DaysInactive:        rep.DaysInactive,
+		DecayAmount:         rep.DecayAmount,
+		LastActivity:        formatTime(rep.LastActivity.Time),
+		Rating:              rep.Rating,
+		RatingCount:         rep.RatingCount,
+		Breakdown:           []reputationStepView{},
+		History:             []reputationPointView{},
+	}
+
+	score := rep.BaseReputation
+	step := func(label, detail string, next float64) {
+		view.Breakdown = append(view.Breakdown, reputationStepView{Label: label, Detail: detail, Points: round2(next - score)})
+		score = next
+	}
+	if rep.DecayAmount > 0 {
+		step("Decay", fmt.Sprintf("%d days inactive × %s", rep.DaysInactive, formatAmount(rep.DecayRate)),
+			math.Max(0, score-rep.DecayAmount))
+	}
+	if rep.DaysInactive == 0 && rep.StreakBonus > 0 {
+		step("Streak", fmt.Sprintf("%d active days, +%s%%", rep.StreakDays, formatAmount(rep.StreakBonus)),
+			score*(1+rep.StreakBonus/100))
+	}
+	for _, name := range sortedKeys(rep.Bonuses) {
+		step("Bonus", fmt.Sprintf("%s, +%s%%", name, formatAmount(rep.Bonuses[name]*100)), score*(1+rep.Bonuses[name]))
+	}
+	for _, name := range sortedKeys(rep.Penalties) {
+		step("Penalty", fmt.Sprintf("%s, -%s%%", name, formatAmount(rep.Penalties[name]*100)), score*(1-rep.Penalties[name]))
+	}
+	if score > 100 || score < 0 {
+		step("Capped", "reputation is kept within 0-100", math.Min(100, math.Max(0, score)))
+	}
+
+	// History runs from the last activity to today, then on as if the agent
+	// stayed inactive
+	day := func(t time.Time) string { return t.UTC().Format("2006-01-02") }
+	if last := rep.LastActivity.Time; !last.IsZero() && rep.DaysInactive > 0 {
+		view.History = append(view.History, reputationPointView{Date: day(last), Reputation: round2(projectReputation(rep, 0))})
+	}
+	view.History = append(view.History, reputationPointView{Date: day(now), Reputation: rep.EffectiveReputation})
+	for _, ahead := range []int{7, 30} {
+		view.History = append(view.History, reputationPointView{
+			Date:       day(now.AddDate(0, 0, ahead)),
+			Reputation: round2(projectReputation(rep, rep.DaysInactive+ahead)),
+			Projected:  true,
+		})
+	}
+	return view
+}
+
+// projectReputation estimates the effective reputation after days without
+// activity, following the API's decay rules
+func projectReputation(rep *gigclaw.Reputation, days int) float64 {
+	score := math.Max(0, rep.BaseReputation-float64(days)*rep.DecayRate)
+	if days == 0 {
+		score *= 1 + rep.StreakBonus/100
+	}
+	for _, b := range rep.Bonuses {
+		score *= 1 + b
+	}
+	for _, p := range rep.Penalties {
+		score *= 1 - p
+	}
+	return math.Min(100, math.Max(0, score))
+}
+
+// readOnChain reads the agent's on-chain reputation account.
+// It is skipped, with a reason, when the agent has no wallet or the chain
+// is not active.
+func readOnChain(ctx context.Context, client *gigclaw.Client, rep *gigclaw.Reputation, agent *gigclaw.Agent) onChainView {
+	if agent.WalletAddress == "" {
+		return onChainView{Reason: "no wallet"}
+	}
+	status, err := client.GetChainStatus(ctx)
+	if err != nil {
+		logger.Debug("Chain status unavailable", err)
+		return onChainView{Reason: "chain status unavailable"}
+	}
+	if !status.Active() {
+		return onChainView{Reason: "chain " + strings.ReplaceAll(firstNonEmpty(status.Status, "unknown"), "_", " ")}
+	}
+	chain, err := client.GetOnChainReputation(ctx, agent.WalletAddress)
+	if errors.Is(err, gigclaw.ErrNotFound) {
+		return onChainView{Reason: "no on-chain account", Network: status.Network}
+	}
+	if err != nil {
+		logger.Debug("On-chain reputation unavailable", err)
+		return onChainView{Reason: "on-chain account unavailable", Network: status.Network}
+	}
+
+	value := func(field string, api, onChain float64) chainValueView {
+		return chainValueView{Field: field, API: formatAmount(round2(api)), Chain: formatAmount(round2(onChain))}
+	}
+	r := agent.Record
+	return onChainView{
+		Read:    true,
+		Account: chain.Account,
+		Network: firstNonEmpty(chain.Network, status.Network),
+		Values: []chainValueView{
+			value("Completed", float64(r.CompletedTasks), float64(chain.CompletedTasks)),
+			value("Failed", float64(r.FailedTasks), float64(chain.FailedTasks)),
+			// The program keeps whole percents
+			value("Success rate", math.Floor(r.SuccessRate), chain.SuccessRate),
+			value("Earned", r.TotalEarned, chain.TotalEarned),
+			value("Ratings", float64(rep.RatingCount), float64(chain.RatingCount)),
+			value("Rating", rep.Rating, chain.Rating),
+		},
+	}
+}
+
+// printReputation prints the decorated reputation show output
+func printReputation(v reputationView) {
+	label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+	colorPrimary.Printf("  Reputation of %s\n", v.AgentID)
+	fmt.Println()
+	label("Reputation")
+	colorHighlight.Printf("%.2f", v.EffectiveReputation)
+	colorDim.Println(" / 100")
+	label("Rating")
+	if v.RatingCount == 0 {
+		colorDim.Println("not rated yet")
+	} else {
+		colorValue.Printf("%s %.2f (%d ratings)\n", stars(int(math.Round(v.Rating))), v.Rating, v.RatingCount)
+	}
+	if v.Registered {
+		label("Track record")
+		colorValue.Printf("%d completed, %d failed, %.0f%% success\n", v.CompletedTasks, v.FailedTasks, v.SuccessRate)
+	}
+
+	fmt.Println()
+	colorHighlight.Println("  Breakdown")
+	w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  Base\t%s\t\n", colorValue.Sprintf("%.2f", v.BaseReputation))
+	for _, s := range v.Breakdown {
+		points := colorSuccess.Sprintf("%+.2f", s.Points)
+		if s.Points < 0 {
+			points = colorWarning.Sprintf("%+.2f", s.Points)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", s.Label, points, colorDim.Sprint(s.Detail))
+	}
+	fmt.Fprintf(w, "  Effective\t%s\t\n", colorHighlight.Sprintf("%.2f", v.EffectiveReputation))
+	w.Flush()
+
+	fmt.Println()
+	colorHighlight.Println("  Decay")
+	if v.LastActivity != "" {
+		label("Last active")
+		colorValue.Printf("%s (%d days ago)\n", v.LastActivity, v.DaysInactive)
+	}
+	label("Rate")
+	colorValue.Printf("%s per inactive day, after a day's grace\n", formatAmount(v.DecayRate))
+	if v.StreakDays > 0 {
+		label("Streak")
+		colorValue.Printf("%d active days\n", v.StreakDays)
+	}
+	for _, p := range v.History {
+		label(p.Date)
+		if p.Projected {
+			colorDim.Printf("%.2f if inactive\n", p.Reputation)
+		} else {
+			colorValue.Printf("%.2f\n", p.Reputation)
+		}
+	}
+
+	fmt.Println()
+	colorHighlight.Println("  On-chain account")
+	o := v.OnChain
+	if !o.Read {
+		colorDim.Printf("  Not read: %s\n", o.Reason)
+		return
+	}
+	label("Account")
+	colorDim.Printf("%s (%s)\n", o.Account, o.Network)
+	for _, c := range o.Values {
+		label(c.Field)
+		colorValue.Print(c.Chain)
+		if c.Chain != c.API {
+			colorDim.Printf(" (API %s)", c.API)
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	colorDim.Println("  The API never writes this account; its values above count.")
+}
+
+func runReputationLeaderboard(cmd *cobra.Command, args []string) error {
+	if leaderboardLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	board, err := client.GetLeaderboard(cmd.Context())
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	me := viper.GetString("agent-id")
+	view := leaderboardView{Total: board.Total, Entries: []leaderboardEntryView{}}
+	for _, e := range board.Entries {
+		if len(view.Entries) == leaderboardLimit {
+			break
+		}
+		view.Entries = append(view.Entries, leaderboardEntryView{
+			Rank:                e.Rank,
+			AgentID:             e.AgentID,
+			EffectiveReputation: e.EffectiveReputation,
+			BaseReputation:      e.BaseReputation,
+			StreakDays:          e.StreakDays,
+			Rating:              e.Rating,
+			Mine:                me != "" && e.AgentID == me,
+		})
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view.Entries) == 0 {
+			colorWarning.Println("  No agents have a reputation yet.")
+			fmt.Println()
+			return
+		}
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, "  "+bold.Sprint("RANK")+"\t"+bold.Sprint("AGENT")+"\t"+bold.Sprint("REPUTATION")+"\t"+
+			bold.Sprint("STREAK")+"\t"+bold.Sprint("RATING"))
+		for _, e := range view.Entries {
+			agent := colorValue.Sprint(e.AgentID)
+			if e.Mine {
+				agent = colorHighlight.Sprint(e.AgentID + " (you)")
+			}
+			rating := colorDim.Sprint("-")
+			if e.Rating > 0 {
+				rating = fmt.Sprintf("%.2f", e.Rating)
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\n",
+				colorDim.Sprintf("#%d", e.Rank),
+				agent,
+				colorPrimary.Sprintf("%.2f", e.EffectiveReputation),
+				e.StreakDays,
+				rating,
+			)
+		}
+		w.Flush()
+		fmt.Println()
+		if view.Total > len(view.Entries) {
+			colorDim.Printf("  Top %d of %d agents\n", len(view.Entries), view.Total)
+			fmt.Println()
+		}
+	})
+}
+
+func runReputationRate(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	if rateStars < 1 || rateStars > 5 {
+		return fmt.Errorf("--stars must be 1-5")
+	}
+	poster, err := actingAgent(rateAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	ctx := cmd.Context()
+
+	task, err := client.GetTask(ctx, taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	if !strings.EqualFold(task.Status, "verified") && rateIdemKey == "" {
+		return friendlyError(nil,
+			fmt.Sprintf("Cannot rate task %s: it is %s", task.ID, task.Status),
+			nil,
+			"Agents are rated once their work is verified: gigclaw task verify "+task.ID)
+	}
+	if task.AssignedAgent == "" {
+		return fmt.Errorf("task %s has no assigned agent to rate", task.ID)
+	}
+	if task.PosterID != "" && task.PosterID != poster {
+		return friendlyError(nil,
+			fmt.Sprintf("Only the poster of task %s (%s) can rate its agent", task.ID, task.PosterID),
+			nil,
+			"Rate as the poster: --as "+task.PosterID)
+	}
+
+	req := gigclaw.RateAgentRequest{TaskID: task.ID, PosterID: poster, Stars: rateStars}
+	var rating *gigclaw.AgentRating
+	res, err := runJournaled("reputation rate", rateIdemKey, []interface{}{req}, func(key string) (string, error) {
+		var err error
+		rating, err = client.RateAgent(ctx, req, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return rating.AgentID, nil
+	})
+	if errors.Is(err, gigclaw.ErrUnauthorized) || errors.Is(err, gigclaw.ErrForbidden) {
+		return friendlyError(err,
+			fmt.Sprintf("Cannot rate task %s: the API key in use does not act for %s", task.ID, task.PosterID),
+			nil,
+			"Create a key for the poster: gigclaw auth keys create --name "+task.PosterID+" --agent "+task.PosterID,
+			"Then use it: gigclaw --api-key <key> reputation rate "+task.ID+" --stars "+strconv.Itoa(rateStars))
+	}
+	if errors.Is(err, gigclaw.ErrConflict) {
+		return friendlyError(err,
+			fmt.Sprintf("Task %s was already rated", task.ID),
+			nil,
+			"See the agent's rating: gigclaw reputation show "+task.AssignedAgent)
+	}
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := ratingView{
+		TaskID:         task.ID,
+		AgentID:        task.AssignedAgent,
+		Stars:          rateStars,
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	if res.Replayed {
+		view.AgentID = res.Result
+		if rep, err := client.GetReputation(ctx, res.Result); err == nil {
+			view.Rating, view.RatingCount = rep.Rating, rep.RatingCount
+		} else {
+			logger.Debug("Reputation unavailable", err)
+		}
+	} else {
+		view.AgentID = rating.AgentID
+		view.Rating, view.RatingCount = rating.Rating, rating.RatingCount
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if view.Replayed {
+			colorWarning.Printf("  Already rated with idempotency key %s\n", view.IdempotencyKey)
+		} else {
+			colorSuccess.Printf("  ✓ Rated %s %s for task %s\n", view.AgentID, stars(view.Stars), view.TaskID)
+		}
+		colorLabel.Printf("  %-15s ", "Rating:")
+		colorValue.Printf("%.2f from %d ratings\n", view.Rating, view.RatingCount)
+		fmt.Println()
+	})
+}
+
+// stars draws a 1-5 star rating
+func stars(n int) string {
+	n = min(max(n, 0), 5)
+	return strings.Repeat("★", n) + strings.Repeat("☆", 5-n)
+}
+
+// round2 rounds to two decimal places
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
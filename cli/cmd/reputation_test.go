@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+func TestReadOnChain(t *testing.T) {
+	const (
+		activeStatus = `{"status":"active","programId":"prog","network":"devnet","onChainTasks":2}`
+		account      = `{"agent":"W1","account":"acct","completedTasks":0,"failedTasks":0,"totalEarned":0,"successRate":0,"ratingSum":9,"ratingCount":2,"rating":4.5,"network":"devnet"}`
+	)
+	tests := []struct {
+		name    string
+		wallet  string
+		status  string // body of /api/blockchain/status
+		account string // body of /api/blockchain/reputation/W1; empty for 404
+		reason  string
+	}{
+		{"no wallet", "", activeStatus, account, "no wallet"},
+		{"not deployed", "W1", `{"status":"not_deployed","message":"Program not found"}`, account, "chain not deployed"},
+		{"no account", "W1", activeStatus, "", "no on-chain account"},
+		{"read", "W1", activeStatus, account, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api/blockchain/status":
+					w.Write([]byte(tt.status))
+				case r.URL.Path == "/api/blockchain/reputation/W1" && tt.account != "":
+					w.Write([]byte(tt.account))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"error":"Reputation account not found"}`))
+				}
+			}))
+			defer srv.Close()
+			client, err := gigclaw.NewClient(gigclaw.WithBaseURL(srv.URL), gigclaw.WithRetryPolicy(gigclaw.NoRetry))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rep := &gigclaw.Reputation{AgentID: "agent-7", Rating: 4.5, RatingCount: 2}
+			agent := &gigclaw.Agent{ID: "agent-7", WalletAddress: tt.wallet,
+				Record: gigclaw.AgentStats{CompletedTasks: 3, SuccessRate: 100, TotalEarned: 400}}
+			o := readOnChain(context.Background(), client, rep, agent)
+			if o.Read != (tt.reason == "") || o.Reason != tt.reason {
+				t.Fatalf("read %v, reason %q, want %q", o.Read, o.Reason, tt.reason)
+			}
+			if !o.Read {
+				return
+			}
+
+			// The API never writes the account, so its values differ from the API's
+			values := make(map[string]chainValueView)
+			for _, v := range o.Values {
+				values[v.Field] = v
+			}
+			if v := values["Completed"]; v.API != "3" || v.Chain != "0" {
+				t.Errorf("completed = %+v", v)
+			}
+			if v := values["Rating"]; v.API != v.Chain {
+				t.Errorf("rating = %+v", v)
+			}
+			if o.Account != "acct" || o.Network != "devnet" {
+				t.Errorf("account %q on %q", o.Account, o.Network)
+			}
+			if row := (reputationView{OnChain: o}).rows()[0]; row[len(row)-1] != "devnet" {
+				t.Errorf("row = %v", row)
+			}
+		})
+	}
+}
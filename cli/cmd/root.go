@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -29,21 +32,39 @@ Quick start:
   gigclaw worker start            # Start agent worker
 
 For more information: https://github.com/OmaClaw/gigclaw`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if profileErr != nil && !createsProfile(cmd) {
+			return profileErr
+		}
+		return validateOutput()
+	},
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	return rootCmd.ExecuteContext(context.Background())
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gigclaw/config.yaml)")
-	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "https://gigclaw-production.up.railway.app", "GigClaw API URL")
-	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "GigClaw API key")
+	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", gigclaw.DefaultBaseURL, "GigClaw API URL")
+	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "GigClaw API key (default $GIGCLAW_API_KEY, or the key stored by gigclaw init)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format: text, table, json, yaml, csv or template (default text on a terminal, table otherwise)")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "Go template for --output template; fields use their JSON names")
+
+	// Retry policy, also configurable as retry.max-retries etc. in the config file
+	rootCmd.PersistentFlags().Int("retries", gigclaw.DefaultRetryPolicy.MaxRetries, "Maximum retries for failed requests")
+	rootCmd.PersistentFlags().Duration("retry-backoff", gigclaw.DefaultRetryPolicy.InitialBackoff, "Initial backoff between retries (doubles each attempt)")
+	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "Timeout for each request attempt")
 
 	viper.BindPFlag("api-url", rootCmd.PersistentFlags().Lookup("api-url"))
 	viper.BindPFlag("api-key", rootCmd.PersistentFlags().Lookup("api-key"))
+	viper.BindPFlag("retry.max-retries", rootCmd.PersistentFlags().Lookup("retries"))
+	viper.BindPFlag("retry.initial-backoff", rootCmd.PersistentFlags().Lookup("retry-backoff"))
+	viper.BindPFlag("retry.timeout", rootCmd.PersistentFlags().Lookup("timeout"))
+	viper.SetDefault("retry.max-backoff", gigclaw.DefaultRetryPolicy.MaxBackoff)
+	viper.SetDefault("retry.max-retry-after", gigclaw.DefaultRetryPolicy.MaxRetryAfter)
 }
 
 func initConfig() {
@@ -64,16 +85,96 @@ func initConfig() {
 	viper.AutomaticEnv()
 	viper.SetEnvPrefix("GIGCLAW")
 
-	if err := viper.ReadInConfig(); err == nil {
+	if err := viper.ReadInConfig(); err == nil && decorated() {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+	profileErr = applyProfile()
+	if profileErr == nil && activeProfile() != defaultProfile && decorated() {
+		fmt.Fprintln(os.Stderr, "Using profile:", activeProfile())
+	}
 
 	// Set defaults
 	if viper.GetString("api-url") == "" {
-		viper.Set("api-url", "https://gigclaw-production.up.railway.app")
+		viper.Set("api-url", gigclaw.DefaultBaseURL)
+	}
+}
+
+// getAPIClient builds an SDK client from the active configuration
+func getAPIClient() (*gigclaw.Client, error) {
+	baseURL := viper.GetString("api-url")
+	if baseURL == "" {
+		return nil, fmt.Errorf("API URL is required. Run 'gigclaw init' or set --api-url")
+	}
+
+	key, err := currentAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return gigclaw.NewClient(
+		gigclaw.WithBaseURL(baseURL),
+		gigclaw.WithAPIKey(key),
+		gigclaw.WithRetryPolicy(retryPolicy()),
+		gigclaw.WithLogger(logger),
+	)
+}
+
+// resolvedKeys caches secrets read through api-key-ref, by reference
+var resolvedKeys = make(map[string]string)
+
+// currentAPIKey returns the API key to use: --api-key, then GIGCLAW_API_KEY,
+// then the active profile's key, then the top-level one in the config file.
+// A key in the config file is a plaintext api-key or the secret api-key-ref
+// points to. It returns "" if there is none.
+func currentAPIKey() (string, error) {
+	if rootCmd.PersistentFlags().Changed("api-key") {
+		return apiKey, nil
+	}
+	if key := os.Getenv(apiKeyEnv); key != "" {
+		return key, nil
+	}
+
+	// The profile has been merged over the top level, so a top-level
+	// plaintext key would otherwise win over the profile's reference
+	if name := activeProfile(); name != defaultProfile {
+		key, ok, err := configAPIKey("profiles." + name + ".")
+		if ok || err != nil {
+			return key, err
+		}
+	}
+	key, _, err := configAPIKey("")
+	return key, err
+}
+
+// configAPIKey returns the plaintext api-key under prefix in the config,
+// else the secret its api-key-ref points to. ok reports whether either is
+// set.
+func configAPIKey(prefix string) (key string, ok bool, err error) {
+	if key := viper.GetString(prefix + "api-key"); key != "" {
+		return key, true, nil
+	}
+	ref := viper.GetString(prefix + "api-key-ref")
+	if ref == "" {
+		return "", false, nil
+	}
+	if key, ok := resolvedKeys[ref]; ok {
+		return key, true, nil
+	}
+	key, err = readSecret(ref)
+	if err != nil {
+		return "", true, err
 	}
+	resolvedKeys[ref] = key
+	return key, true, nil
 }
 
-func getAPIClient() (*Client, error) {
-	return NewClient(viper.GetString("api-url"), viper.GetString("api-key"))
+// retryPolicy builds the client retry policy from flags and config
+func retryPolicy() gigclaw.RetryPolicy {
+	policy := gigclaw.DefaultRetryPolicy
+	policy.MaxRetries = viper.GetInt("retry.max-retries")
+	policy.InitialBackoff = viper.GetDuration("retry.initial-backoff")
+	policy.MaxBackoff = viper.GetDuration("retry.max-backoff")
+	policy.MaxRetryAfter = viper.GetDuration("retry.max-retry-after")
+	policy.PerAttemptTimeout = viper.GetDuration("retry.timeout")
+	return policy
 }
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCurrentAPIKeyProfile(t *testing.T) {
+	t.Setenv(apiKeyEnv, "")
+	t.Setenv("GIGCLAW_STAGING_KEY", "gk_test_staging")
+	resolvedKeys = make(map[string]string)
+	t.Cleanup(func() {
+		viper.Reset()
+		profileFlag = ""
+	})
+
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`api-key: gk_live_default
+profiles:
+  staging:
+    api-key-ref: env:GIGCLAW_STAGING_KEY
+  bare:
+    api-url: http://localhost:3000
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{"", "gk_live_default"},
+		// The profile's reference wins over the top-level plaintext key
+		{"staging", "gk_test_staging"},
+		// A profile without a key of its own falls back to the top level
+		{"bare", "gk_live_default"},
+	}
+	for _, tt := range tests {
+		t.Run(firstNonEmpty(tt.profile, defaultProfile), func(t *testing.T) {
+			profileFlag = tt.profile
+			if err := applyProfile(); err != nil {
+				t.Fatal(err)
+			}
+			if key, err := currentAPIKey(); err != nil || key != tt.want {
+				t.Errorf("currentAPIKey() = %q, %v, want %q", key, err, tt.want)
+			}
+		})
+	}
+}
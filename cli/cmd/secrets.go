@@ -0,0 +1,480 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+)
+
+// Secret stores. A config file refers to a stored API key as
+// api-key-ref: <store>:<account>, e.g. keyring:default; the account is the
+// name of the profile.
+const (
+	secretAuto    = "auto" // the keyring if there is one, else the file
+	secretKeyring = "keyring"
+	secretFile    = "file"
+	secretEnv     = "env" // read-only; the account is a variable name
+)
+
+// secretStores are the values of --secret-store
+var secretStores = []string{secretAuto, secretKeyring, secretFile, secretEnv}
+
+// Environment variables
+const (
+	apiKeyEnv     = "GIGCLAW_API_KEY"    // API key, taking precedence over the config file
+	passphraseEnv = "GIGCLAW_PASSPHRASE" // passphrase of the encrypted credentials file
+)
+
+// keyringService is the service name of secrets in the OS keyring
+const keyringService = "gigclaw"
+
+// keyringTimeout bounds a keyring call, which may wait for the user to
+// unlock the keyring
+const keyringTimeout = time.Minute
+
+// credentialsIterations is the PBKDF2 work factor of new credentials files
+const credentialsIterations = 600000
+
+var errSecretNotFound = errors.New("secret not found")
+
+// secretBackend stores secrets by account
+type secretBackend interface {
+	name() string
+	available() bool
+	get(account string) (string, error)
+	set(account, secret string) error
+	remove(account string) error
+}
+
+// secretBackendFor returns the named secret store
+func secretBackendFor(store string) (secretBackend, error) {
+	switch store {
+	case secretKeyring:
+		return keyringBackend{}, nil
+	case secretFile:
+		return fileBackend{path: credentialsPath()}, nil
+	case secretEnv:
+		return envBackend{}, nil
+	}
+	return nil, fmt.Errorf("unknown secret store %q: must be one of %s", store, strings.Join(secretStores, ", "))
+}
+
+// defaultSecretStore resolves auto to the keyring, or the encrypted file
+// where there is no keyring
+func defaultSecretStore(store string) string {
+	if store != "" && store != secretAuto {
+		return store
+	}
+	if (keyringBackend{}).available() {
+		return secretKeyring
+	}
+	return secretFile
+}
+
+// describeSecretStore names a store for messages
+func describeSecretStore(store string) string {
+	switch store {
+	case secretKeyring:
+		return "the OS keyring"
+	case secretFile:
+		return "the encrypted file " + credentialsPath()
+	case secretEnv:
+		return "the environment"
+	}
+	return store
+}
+
+// parseSecretRef splits a store:account reference
+func parseSecretRef(ref string) (secretBackend, string, error) {
+	store, account, ok := strings.Cut(ref, ":")
+	if !ok || account == "" {
+		return nil, "", fmt.Errorf("invalid api-key-ref %q: use <store>:<account>, e.g. keyring:default", ref)
+	}
+	backend, err := secretBackendFor(store)
+	if err != nil {
+		return nil, "", err
+	}
+	return backend, account, nil
+}
+
+// readSecret returns the secret a reference points to
+func readSecret(ref string) (string, error) {
+	backend, account, err := parseSecretRef(ref)
+	if err != nil {
+		return "", err
+	}
+	secret, err := backend.get(account)
+	if errors.Is(err, errSecretNotFound) {
+		return "", fmt.Errorf("api-key-ref %s: no secret %q in %s", ref, account, describeSecretStore(backend.name()))
+	}
+	if err != nil {
+		return "", fmt.Errorf("api-key-ref %s: %w", ref, err)
+	}
+	return secret, nil
+}
+
+// storeSecret saves secret under account in store and returns its reference
+func storeSecret(store, account, secret string) (string, error) {
+	store = defaultSecretStore(store)
+	backend, err := secretBackendFor(store)
+	if err != nil {
+		return "", err
+	}
+	if store == secretEnv {
+		// Nothing to save: the reference names the variable
+		return secretEnv + ":" + apiKeyEnv, nil
+	}
+	if !backend.available() {
+		return "", fmt.Errorf("%s is not available on this system", describeSecretStore(store))
+	}
+	if err := backend.set(account, secret); err != nil {
+		return "", err
+	}
+	return store + ":" + account, nil
+}
+
+// removeSecret deletes the secret a reference points to, if the store can
+func removeSecret(ref string) error {
+	backend, account, err := parseSecretRef(ref)
+	if err != nil || backend.name() == secretEnv {
+		return err
+	}
+	if err := backend.remove(account); err != nil && !errors.Is(err, errSecretNotFound) {
+		return err
+	}
+	return nil
+}
+
+// keyringBackend keeps secrets in the OS keyring: the Secret Service
+// through secret-tool, or the macOS keychain through security
+type keyringBackend struct{}
+
+func (keyringBackend) name() string { return secretKeyring }
+
+func (keyringBackend) available() bool {
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := exec.LookPath("security")
+		return err == nil
+	case "windows":
+		return false
+	}
+	// Without a session bus secret-tool fails, or waits for one
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return false
+	}
+	_, err := exec.LookPath("secret-tool")
+	return err == nil
+}
+
+func (keyringBackend) get(account string) (string, error) {
+	if runtime.GOOS == "darwin" {
+		out, code, err := runKeyringTool("", "security", "find-generic-password", "-s", keyringService, "-a", account, "-w")
+		if code == 44 {
+			return "", errSecretNotFound
+		}
+		return strings.TrimRight(out, "\n"), err
+	}
+	out, code, err := runKeyringTool("", "secret-tool", "lookup", "service", keyringService, "account", account)
+	if code == 1 && out == "" {
+		return "", errSecretNotFound
+	}
+	return strings.TrimRight(out, "\n"), err
+}
+
+func (keyringBackend) set(account, secret string) error {
+	if runtime.GOOS == "darwin" {
+		// Passed on stdin so that the secret is not visible in ps
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+			quoteKeyringArg(keyringService), quoteKeyringArg(account), quoteKeyringArg(secret))
+		_, _, err := runKeyringTool(command, "security", "-i")
+		return err
+	}
+	label := fmt.Sprintf("GigClaw API key (%s)", account)
+	_, _, err := runKeyringTool(secret, "secret-tool", "store", "--label", label, "service", keyringService, "account", account)
+	return err
+}
+
+func (keyringBackend) remove(account string) error {
+	if runtime.GOOS == "darwin" {
+		_, code, err := runKeyringTool("", "security", "delete-generic-password", "-s", keyringService, "-a", account)
+		if code == 44 {
+			return errSecretNotFound
+		}
+		return err
+	}
+	_, _, err := runKeyringTool("", "secret-tool", "clear", "service", keyringService, "account", account)
+	return err
+}
+
+// runKeyringTool runs a keyring command, returning its output and exit code
+func runKeyringTool(stdin, name string, args ...string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, name, args...)
+	c.Stdin = strings.NewReader(stdin)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	err := c.Run()
+
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		msg := strings.TrimSpace(stderr.String())
+		return stdout.String(), exit.ExitCode(), fmt.Errorf("keyring: %s failed: %s", name, firstNonEmpty(msg, exit.Error()))
+	}
+	if err != nil {
+		return "", -1, fmt.Errorf("keyring: %w", err)
+	}
+	return stdout.String(), 0, nil
+}
+
+// quoteKeyringArg quotes an argument for security -i
+func quoteKeyringArg(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// envBackend reads secrets from environment variables named by the account
+type envBackend struct{}
+
+func (envBackend) name() string    { return secretEnv }
+func (envBackend) available() bool { return true }
+
+func (envBackend) get(variable string) (string, error) {
+	if v := os.Getenv(variable); v != "" {
+		return v, nil
+	}
+	return "", errSecretNotFound
+}
+
+func (envBackend) set(variable, secret string) error {
+	return fmt.Errorf("secrets in the environment are read-only: set %s yourself", variable)
+}
+
+func (envBackend) remove(variable string) error { return nil }
+
+// credentialsFile is the encrypted credentials file, for systems without a
+// keyring. Each secret is sealed with AES-256-GCM under a key derived from
+// a passphrase with PBKDF2-SHA256.
+type credentialsFile struct {
+	Version    int               `json:"version"`
+	Iterations int               `json:"iterations"`
+	Salt       []byte            `json:"salt"`
+	Secrets    map[string][]byte `json:"secrets"` // nonce and ciphertext, keyed by account
+}
+
+// credentialsKey caches the key derived from the passphrase
+var credentialsKey []byte
+
+// credentialsPath returns ~/.gigclaw/credentials.enc
+func credentialsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "credentials.enc"
+	}
+	return filepath.Join(home, ".gigclaw", "credentials.enc")
+}
+
+// fileBackend keeps secrets in an encrypted credentials file
+type fileBackend struct {
+	path string
+}
+
+func (fileBackend) name() string    { return secretFile }
+func (fileBackend) available() bool { return true }
+
+func (b fileBackend) load() (*credentialsFile, error) {
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil, errSecretNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	var f credentialsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials %s: %w", b.path, err)
+	}
+	if f.Version != 1 || len(f.Salt) == 0 || f.Iterations <= 0 {
+		return nil, fmt.Errorf("unsupported credentials file %s", b.path)
+	}
+	if f.Secrets == nil {
+		f.Secrets = make(map[string][]byte)
+	}
+	return &f, nil
+}
+
+func (b fileBackend) save(f *credentialsFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	if err := writeFileAtomic(b.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+// aead returns the cipher for f, asking for the passphrase the first time
+func (b fileBackend) aead(f *credentialsFile, confirm bool) (cipher.AEAD, error) {
+	if credentialsKey == nil {
+		passphrase, err := readPassphrase(fmt.Sprintf("Passphrase for %s: ", b.path), confirm)
+		if err != nil {
+			return nil, err
+		}
+		key, err := pbkdf2.Key(sha256.New, passphrase, f.Salt, f.Iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+		credentialsKey = key
+	}
+	block, err := aes.NewCipher(credentialsKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// open decrypts the secret of account
+func (b fileBackend) open(f *credentialsFile, account string, aead cipher.AEAD) (string, error) {
+	sealed := f.Secrets[account]
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("corrupt credentials for %q in %s", account, b.path)
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(account))
+	if err != nil {
+		credentialsKey = nil
+		return "", fmt.Errorf("wrong passphrase for %s, or the file was modified", b.path)
+	}
+	return string(plain), nil
+}
+
+func (b fileBackend) get(account string) (string, error) {
+	f, err := b.load()
+	if err != nil {
+		return "", err
+	}
+	if _, ok := f.Secrets[account]; !ok {
+		return "", errSecretNotFound
+	}
+	aead, err := b.aead(f, false)
+	if err != nil {
+		return "", err
+	}
+	return b.open(f, account, aead)
+}
+
+func (b fileBackend) set(account, secret string) error {
+	f, err := b.load()
+	isNew := errors.Is(err, errSecretNotFound)
+	if isNew {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		f = &credentialsFile{Version: 1, Iterations: credentialsIterations, Salt: salt, Secrets: make(map[string][]byte)}
+		credentialsKey = nil
+	} else if err != nil {
+		return err
+	}
+
+	aead, err := b.aead(f, isNew)
+	if err != nil {
+		return err
+	}
+	// Every secret in a file shares a passphrase: check it against one
+	for existing := range f.Secrets {
+		if _, err := b.open(f, existing, aead); err != nil {
+			return err
+		}
+		break
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	f.Secrets[account] = aead.Seal(nonce, nonce, []byte(secret), []byte(account))
+	return b.save(f)
+}
+
+func (b fileBackend) remove(account string) error {
+	f, err := b.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := f.Secrets[account]; !ok {
+		return errSecretNotFound
+	}
+	delete(f.Secrets, account)
+	return b.save(f)
+}
+
+// readPassphrase reads a passphrase from GIGCLAW_PASSPHRASE or the
+// terminal, twice when confirm is set
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	if p := os.Getenv(passphraseEnv); p != "" {
+		return p, nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("a passphrase is needed for the encrypted credentials: set %s", passphraseEnv)
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	p, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(p) == 0 {
+		return "", fmt.Errorf("the passphrase must not be empty")
+	}
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat the passphrase: ")
+		again, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if !bytes.Equal(p, again) {
+			return "", fmt.Errorf("the passphrases do not match")
+		}
+	}
+	return string(p), nil
+}
+
+// readSecretLine reads a line, without echoing it on a terminal
+func readSecretLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return "", nil
+		}
+		return strings.TrimSpace(line), nil
+	}
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useCredentialsFile returns a file backend in a temp directory unlocked
+// by passphrase, forgetting any cached key
+func useCredentialsFile(t *testing.T, passphrase string) fileBackend {
+	t.Helper()
+	t.Setenv(passphraseEnv, passphrase)
+	credentialsKey = nil
+	t.Cleanup(func() { credentialsKey = nil })
+	return fileBackend{path: filepath.Join(t.TempDir(), ".gigclaw", "credentials.enc")}
+}
+
+func TestFileBackendRoundTrip(t *testing.T) {
+	b := useCredentialsFile(t, "correct horse")
+
+	if _, err := b.get("default"); !errors.Is(err, errSecretNotFound) {
+		t.Fatalf("get from a missing file: %v", err)
+	}
+	if err := b.set("default", "gk_live_1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.set("staging", "gk_test_2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new process derives the key again from the passphrase
+	credentialsKey = nil
+	for account, want := range map[string]string{"default": "gk_live_1", "staging": "gk_test_2"} {
+		if got, err := b.get(account); err != nil || got != want {
+			t.Errorf("get(%s) = %q, %v, want %q", account, got, err, want)
+		}
+	}
+
+	info, err := os.Stat(b.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("mode = %o, want 600", perm)
+	}
+	entries, err := os.ReadDir(filepath.Dir(b.path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("%s has %d files, want only the credentials", filepath.Dir(b.path), len(entries))
+	}
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "gk_live_1") {
+		t.Error("credentials file holds the secret in the clear")
+	}
+
+	if err := b.remove("staging"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.get("staging"); !errors.Is(err, errSecretNotFound) {
+		t.Errorf("get after remove: %v", err)
+	}
+	if err := b.remove("staging"); !errors.Is(err, errSecretNotFound) {
+		t.Errorf("second remove: %v", err)
+	}
+}
+
+func TestFileBackendWrongPassphrase(t *testing.T) {
+	b := useCredentialsFile(t, "correct horse")
+	if err := b.set("default", "gk_live_1"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(passphraseEnv, "battery staple")
+	credentialsKey = nil
+	if _, err := b.get("default"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("get with the wrong passphrase: %v", err)
+	}
+	if credentialsKey != nil {
+		t.Error("the key from the wrong passphrase is still cached")
+	}
+	// Adding a secret under another passphrase is refused
+	if err := b.set("staging", "gk_test_2"); err == nil {
+		t.Fatal("set with the wrong passphrase succeeded")
+	}
+
+	t.Setenv(passphraseEnv, "correct horse")
+	credentialsKey = nil
+	if got, err := b.get("default"); err != nil || got != "gk_live_1" {
+		t.Errorf("get = %q, %v", got, err)
+	}
+	if _, err := b.get("staging"); !errors.Is(err, errSecretNotFound) {
+		t.Errorf("refused secret was stored: %v", err)
+	}
+}
+
+func TestFileBackendTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(f *credentialsFile)
+	}{
+		{"flipped byte", func(f *credentialsFile) {
+			f.Secrets["default"][len(f.Secrets["default"])-1] ^= 1
+		}},
+		{"truncated", func(f *credentialsFile) {
+			f.Secrets["default"] = f.Secrets["default"][:4]
+		}},
+		{"moved to another account", func(f *credentialsFile) {
+			f.Secrets["default"] = f.Secrets["staging"]
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := useCredentialsFile(t, "correct horse")
+			if err := b.set("default", "gk_live_1"); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.set("staging", "gk_test_2"); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := b.load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.tamper(f)
+			data, err := json.Marshal(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(b.path, data, 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, err := b.get("default"); err == nil {
+				t.Errorf("get from a tampered file = %q", got)
+			}
+		})
+	}
+}
@@ -2,15 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 // Setup-specific styles
@@ -57,8 +53,7 @@ type setupModel struct {
 	apiKey    string
 	wallet    string
 	selected  int
-	completed bool
-	err       error
+	confirmed bool
 }
 
 func initialSetupModel() setupModel {
@@ -111,9 +106,9 @@ func (m setupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.apiKey = m.textInput.Value()
 				m.step = 2
 				m.textInput.Blur()
-			case 2: // Complete
-				m.completed = true
-				m.saveConfig()
+			case 2: // Complete; saved once the TUI is closed, as the
+				// encrypted credentials file may ask for a passphrase
+				m.confirmed = true
 				return m, tea.Quit
 			}
 		}
@@ -127,8 +122,8 @@ func (m setupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m setupModel) View() string {
-	if m.completed {
-		return m.completedView()
+	if m.confirmed {
+		return ""
 	}
 
 	switch m.step {
@@ -189,6 +184,9 @@ func (m setupModel) confirmView() string {
 
 	b += setupTitleStyle.Render(" 🦀 GigClaw Setup ") + "\n\n"
 	b += setupLabelStyle.Render("Configuration Summary") + "\n\n"
+	if profile := activeProfile(); profile != defaultProfile {
+		b += fmt.Sprintf("Profile:  %s\n", profile)
+	}
 	b += fmt.Sprintf("API URL:  %s\n", m.apiURL)
 	if m.apiKey != "" {
 		b += "API Key:  [configured]\n"
@@ -202,61 +200,53 @@ func (m setupModel) confirmView() string {
 	return setupBoxStyle.Render(b)
 }
 
-func (m setupModel) completedView() string {
+func (m setupModel) completedView(configPath, keyRef string) string {
 	var b string
 
 	b += setupTitleStyle.Render(" 🦀 GigClaw Setup ") + "\n\n"
 	b += setupSuccessStyle.Render("✅ Configuration saved successfully!") + "\n\n"
 
-	home, _ := os.UserHomeDir()
-	configPath := filepath.Join(home, ".gigclaw", "config.yaml")
-	b += fmt.Sprintf("Config: %s\n\n", configPath)
+	b += fmt.Sprintf("Config: %s\n", configPath)
+	if keyRef != "" {
+		b += fmt.Sprintf("API Key: %s\n", keyRef)
+	}
+	b += "\n"
 
 	b += setupLabelStyle.Render("Quick Start:") + "\n"
 	b += "  gigclaw health     # Check API status\n"
 	b += "  gigclaw dashboard  # Launch TUI\n"
-	b += "  gigclaw task list  # View tasks\n\n"
-
-	b += setupDescStyle.Render("Press any key to exit...")
+	b += "  gigclaw task list  # View tasks"
 
 	return setupBoxStyle.Render(b)
 }
 
-func (m *setupModel) saveConfig() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
-	}
-
-	configDir := filepath.Join(home, ".gigclaw")
-	os.MkdirAll(configDir, 0755)
-
-	config := map[string]string{
+// saveConfig writes the chosen settings, keeping the API key in the secret
+// store, and returns the config path and the key reference
+func (m *setupModel) saveConfig(store string) (string, string, error) {
+	changes := map[string]interface{}{
 		"api-url": m.apiURL,
 	}
+
+	var ref string
 	if m.apiKey != "" {
-		config["api-key"] = m.apiKey
+		var err error
+		ref, err = storeSecret(store, activeProfile(), m.apiKey)
+		if err != nil {
+			return "", "", friendlyError(err,
+				"Could not store the API key in "+describeSecretStore(defaultSecretStore(store)),
+				[]string{err.Error()},
+				"Store it in the encrypted file instead: gigclaw setup --secret-store file",
+				"Or set "+apiKeyEnv+" and skip the API key")
+		}
+		changes["api-key-ref"] = ref
+		changes["api-key"] = nil
 	}
 
-	configFile := filepath.Join(configDir, "config.yaml")
-	file, err := os.Create(configFile)
+	path, err := updateConfig(changes)
 	if err != nil {
-		return err
+		return "", "", err
 	}
-	defer file.Close()
-
-	encoder := yaml.NewEncoder(file)
-	encoder.Encode(config)
-
-	os.Chmod(configFile, 0600)
-
-	// Update viper
-	viper.Set("api-url", m.apiURL)
-	if m.apiKey != "" {
-		viper.Set("api-key", m.apiKey)
-	}
-
-	return nil
+	return path, ref, nil
 }
 
 var setupCmd = &cobra.Command{
@@ -273,14 +263,33 @@ Much easier than manual configuration!`,
 	RunE: runSetup,
 }
 
+var setupSecretStore string
+
 func runSetup(cmd *cobra.Command, args []string) error {
+	if !containsFold(secretStores, setupSecretStore) || setupSecretStore == secretEnv {
+		return fmt.Errorf("invalid --secret-store %q: must be auto, keyring or file", setupSecretStore)
+	}
+
 	p := tea.NewProgram(initialSetupModel())
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		return fmt.Errorf("setup error: %w", err)
 	}
+
+	m := final.(setupModel)
+	if !m.confirmed {
+		return nil
+	}
+	path, ref, err := m.saveConfig(setupSecretStore)
+	if err != nil {
+		return err
+	}
+	fmt.Println(m.completedView(path, ref))
 	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(setupCmd)
+
+	setupCmd.Flags().StringVar(&setupSecretStore, "secret-store", secretAuto, "Where to keep the API key: auto, keyring or file")
 }
@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <task-id>",
+	Short: "Show a task with its bids, escrow and on-chain details",
+	Long: `Show everything about a task: its details, the bids placed on it,
+the escrow holding its funds and the verified on-chain transaction.
+
+Table and CSV output list the bids; JSON and YAML include the full view.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+// Bid sort orders for task show
+const (
+	sortByAmount     = "amount"     // lowest first
+	sortByReputation = "reputation" // highest first
+	sortByCreated    = "created"    // oldest first
+)
+
+var showSort string
+
+func init() {
+	taskCmd.AddCommand(showCmd)
+
+	showCmd.Flags().StringVarP(&showSort, "sort", "s", sortByAmount, "Sort bids by amount, reputation or created")
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	switch showSort {
+	case sortByAmount, sortByReputation, sortByCreated:
+	default:
+		return fmt.Errorf("unknown sort %q (want amount, reputation or created)", showSort)
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	ctx := cmd.Context()
+	task, err := client.GetTask(ctx, args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := loadTaskDetail(ctx, client, task, logger.Warning)
+	sortBids(view.Bids, showSort)
+
+	return render(view, func() { printTaskDetail(view) })
+}
+
+// loadTaskDetail gathers a task's bids, escrow and on-chain status. These
+// lookups are best effort: failures are reported to warn and the rest of
+// the view is still filled in.
+func loadTaskDetail(ctx context.Context, client *gigclaw.Client, task *gigclaw.Task, warn func(string)) taskDetailView {
+	view := taskDetailView{
+		taskView:      newTaskView(*task),
+		PosterID:      task.PosterID,
+		AssignedAgent: task.AssignedAgent,
+		DeliveryURL:   task.DeliveryURL,
+		Bids:          collectBids(ctx, client, task, warn),
+	}
+
+	escrow, err := client.GetEscrowStatus(ctx, task.ID)
+	if err != nil {
+		warn(fmt.Sprintf("Escrow status unavailable: %v", err))
+	}
+	view.Escrow = newEscrowView(escrow)
+
+	if sig := taskSignature(task); sig != "" {
+		tx, err := client.VerifyTransaction(ctx, sig)
+		if err != nil {
+			warn(fmt.Sprintf("Could not verify transaction %s: %v", sig, err))
+		}
+		view.Transaction = newTransactionView(tx)
+	}
+
+	store, err := openTeamStore()
+	if err != nil {
+		warn(fmt.Sprintf("Teams unavailable: %v", err))
+		return view
+	}
+	if team := store.Teams[task.ID]; team != nil {
+		tv := newTeamView(team, task.Budget)
+		tv.Split = true
+		loadSubTaskStatus(ctx, client, &tv, warn)
+		view.Team = &tv
+	} else if parent, ok := store.parentOf(task.ID); ok {
+		view.PartOf = parent
+	}
+	return view
+}
+
+// taskSignature returns the signature of the transaction that created the task
+func taskSignature(task *gigclaw.Task) string {
+	if task.Signature != "" {
+		return task.Signature
+	}
+	if task.BlockchainStatus != nil {
+		return task.BlockchainStatus.Signature
+	}
+	return ""
+}
+
+// collectBids merges the bids stored on the task with proposals from the
+// bids API and looks up each bidder's reputation
+func collectBids(ctx context.Context, client *gigclaw.Client, task *gigclaw.Task, warn func(string)) []taskBidView {
+	bids := []taskBidView{}
+	seen := make(map[string]bool)
+
+	for _, b := range task.Bids {
+		status := b.Status
+		if b.Accepted || (task.AcceptedBid != nil && task.AcceptedBid.ID == b.ID) {
+			status = "accepted"
+		} else if status == "" {
+			status = "pending"
+		}
+		seen[b.ID] = true
+		bids = append(bids, taskBidView{
+			ID:        b.ID,
+			AgentID:   b.AgentID,
+			Amount:    b.Amount,
+			Message:   b.Message,
+			Status:    status,
+			CreatedAt: formatTime(b.CreatedAt.Time),
+		})
+	}
+
+	proposals, err := client.ListTaskProposals(ctx, task.ID)
+	if err != nil {
+		warn(fmt.Sprintf("Could not list proposals: %v", err))
+	}
+	for _, p := range proposals {
+		if seen[p.ID] {
+			continue
+		}
+		v := taskBidView{
+			ID:             p.ID,
+			AgentID:        p.AgentID,
+			Amount:         p.ProposedPrice,
+			Message:        p.Message,
+			Status:         p.Status,
+			EstimatedHours: p.EstimatedHours,
+			Skills:         p.RelevantSkills,
+		}
+		if !p.CreatedAt.IsZero() {
+			v.CreatedAt = p.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		bids = append(bids, v)
+	}
+
+	reputations := make(map[string]*float64)
+	for i := range bids {
+		agent := bids[i].AgentID
+		if agent == "" {
+			continue
+		}
+		if _, ok := reputations[agent]; !ok {
+			reputations[agent] = nil
+			if rep, err := client.GetReputation(ctx, agent); err == nil {
+				reputations[agent] = &rep.EffectiveReputation
+			} else {
+				logger.Debug("Reputation lookup failed", agent, err)
+			}
+		}
+		bids[i].Reputation = reputations[agent]
+	}
+
+	return bids
+}
+
+// sortBids orders bids in place; bids without a reputation sort last
+func sortBids(bids []taskBidView, by string) {
+	sort.SliceStable(bids, func(i, j int) bool {
+		switch by {
+		case sortByReputation:
+			ri, rj := bids[i].Reputation, bids[j].Reputation
+			if ri == nil || rj == nil {
+				return ri != nil && rj == nil
+			}
+			return *ri > *rj
+		case sortByCreated:
+			return bids[i].CreatedAt < bids[j].CreatedAt
+		default:
+			return bids[i].Amount < bids[j].Amount
+		}
+	})
+}
+
+// printTaskDetail prints the decorated task show output
+func printTaskDetail(v taskDetailView) {
+	label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+	fmt.Println()
+	colorPrimary.Printf("  %s\n", v.Title)
+	fmt.Println()
+
+	label("ID")
+	colorValue.Println(v.ID)
+	label("Status")
+	fmt.Println(formatStatus(v.Status))
+	label("Budget")
+	colorPrimary.Printf("%.2f %s\n", v.Budget, v.Currency)
+	if v.PosterID != "" {
+		label("Poster")
+		colorValue.Println(v.PosterID)
+	}
+	if v.AssignedAgent != "" {
+		label("Assigned to")
+		colorValue.Println(v.AssignedAgent)
+	}
+	if v.DeliveryURL != "" {
+		label("Delivery")
+		colorValue.Println(v.DeliveryURL)
+	}
+	if len(v.Tags) > 0 {
+		label("Tags")
+		colorValue.Println(strings.Join(v.Tags, ", "))
+	}
+	if v.PartOf != "" {
+		label("Part of")
+		colorValue.Printf("team task %s\n", v.PartOf)
+	}
+	if v.Description != "" {
+		fmt.Println()
+		colorDim.Printf("  %s\n", v.Description)
+	}
+
+	if t := v.Team; t != nil {
+		fmt.Println()
+		colorHighlight.Printf("  Team (%d sub-tasks, %d of %d skills covered)\n", len(t.Members), len(t.Skills)-len(t.Missing), len(t.Skills))
+		fmt.Println()
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, "  "+bold.Sprint("SUB-TASK")+"\t"+bold.Sprint("AGENT")+"\t"+bold.Sprint("ROLE")+"\t"+
+			bold.Sprint("BUDGET")+"\t"+bold.Sprint("STATUS")+"\t"+bold.Sprint("NEGOTIATION"))
+		for _, m := range t.Members {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n",
+				colorDim.Sprint(firstNonEmpty(m.SubTaskID, "not posted")),
+				colorValue.Sprint(m.AgentID),
+				m.Role,
+				colorPrimary.Sprintf("%.2f", m.Budget),
+				formatStatus(firstNonEmpty(m.SubTaskStatus, "-")),
+				firstNonEmpty(strings.TrimSpace(m.NegotiationStatus+" "+m.NegotiationID), "-"),
+			)
+		}
+		w.Flush()
+	}
+
+	// Escrow
+	fmt.Println()
+	colorHighlight.Println("  Escrow")
+	switch e := v.Escrow; {
+	case e == nil:
+		colorDim.Println("  Unavailable")
+	case e.ReleasedAt != "":
+		label("Released")
+		colorSuccess.Printf("%.2f %s at %s\n", e.Amount, v.Currency, e.ReleasedAt)
+		if e.TransactionHash != "" {
+			label("Transaction")
+			colorDim.Println(e.TransactionHash)
+		}
+	case e.Held:
+		label("Locked")
+		colorPrimary.Printf("%.2f %s\n", e.Amount, v.Currency)
+		if e.ReleaseScheduled {
+			label("Release")
+			colorValue.Println("scheduled (work verified)")
+		}
+	default:
+		colorDim.Println("  No funds locked (no bid accepted yet)")
+	}
+
+	// On-chain
+	fmt.Println()
+	colorHighlight.Println("  On-chain")
+	switch tx := v.Transaction; {
+	case tx == nil && v.Blockchain == nil:
+		colorDim.Println("  Not on chain")
+	case tx == nil:
+		label("Status")
+		colorValue.Println(v.Blockchain.Status)
+		if v.Blockchain.Error != "" {
+			label("Error")
+			colorError.Println(v.Blockchain.Error)
+		}
+	default:
+		label("Transaction")
+		switch {
+		case tx.Failed:
+			colorError.Printf("✗ %s (failed)\n", tx.Status)
+		case tx.Status == "confirmed" || tx.Status == "finalized":
+			colorSuccess.Printf("✓ %s\n", tx.Status)
+		default:
+			colorWarning.Printf("⋯ %s\n", tx.Status)
+		}
+		label("Signature")
+		colorDim.Println(tx.Signature)
+		label("Explorer")
+		color.New(color.FgCyan).Println(tx.ExplorerURL)
+	}
+
+	// Bids
+	fmt.Println()
+	colorHighlight.Printf("  Bids (%d, by %s)\n", len(v.Bids), showSort)
+	if len(v.Bids) == 0 {
+		colorDim.Println("  No bids yet")
+		fmt.Println()
+		colorDim.Println("  Bid on this task:")
+		fmt.Println("    gigclaw task bid " + v.ID + " --amount <amount>")
+		fmt.Println()
+		return
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+	bold := color.New(color.FgHiWhite, color.Bold)
+	fmt.Fprintln(w, "  "+bold.Sprint("BID")+"\t"+bold.Sprint("AGENT")+"\t"+bold.Sprint("AMOUNT")+"\t"+
+		bold.Sprint("REPUTATION")+"\t"+bold.Sprint("STATUS")+"\t"+bold.Sprint("MESSAGE"))
+	for _, b := range v.Bids {
+		rep := colorDim.Sprint("-")
+		if b.Reputation != nil {
+			rep = fmt.Sprintf("%.1f", *b.Reputation)
+		}
+		status := b.Status
+		if status == "accepted" {
+			status = colorSuccess.Sprint(status)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n",
+			colorDim.Sprint(b.ID),
+			colorValue.Sprint(b.AgentID),
+			colorPrimary.Sprintf("%.2f", b.Amount),
+			rep,
+			status,
+			truncate(b.Message, 40),
+		)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if v.Status == "posted" {
+		colorDim.Println("  Accept a bid:")
+		fmt.Println("    gigclaw task accept " + v.ID + " --bid <bid-id>")
+		fmt.Println()
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
 	"github.com/fatih/color"
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Color definitions for consistent theming
@@ -58,22 +60,65 @@ var (
 	taskBudget      float64
 	taskCurrency    string
 	taskTags        []string
+	taskDeadline    string
+	taskAs          string
+	taskIdemKey     string
 )
 
+// List flags
+var (
+	listStatus    string
+	listTags      []string
+	listMinBudget float64
+	listMaxBudget float64
+	listCurrency  string
+	listPostedBy  string
+	listSort      string
+	listLimit     int
+	listCursor    string
+)
+
+// listQueryFlags maps task query parameters to the flags that set them
+var listQueryFlags = map[string]string{
+	"status":    "--status",
+	"tag":       "--tag",
+	"minBudget": "--min-budget",
+	"maxBudget": "--max-budget",
+	"currency":  "--currency",
+	"postedBy":  "--posted-by",
+	"sort":      "--sort",
+	"limit":     "--limit",
+}
+
 func init() {
 	rootCmd.AddCommand(taskCmd)
 	taskCmd.AddCommand(taskListCmd)
 	taskCmd.AddCommand(taskPostCmd)
 
+	// List flags
+	taskListCmd.Flags().StringVar(&listStatus, "status", "", "Only tasks with this status (posted, in_progress, completed, verified, cancelled)")
+	taskListCmd.Flags().StringArrayVarP(&listTags, "tag", "g", []string{}, "Only tasks that require this skill (can specify multiple)")
+	taskListCmd.Flags().Float64Var(&listMinBudget, "min-budget", 0, "Minimum budget")
+	taskListCmd.Flags().Float64Var(&listMaxBudget, "max-budget", 0, "Maximum budget")
+	taskListCmd.Flags().StringVarP(&listCurrency, "currency", "c", "", "Only tasks in this currency")
+	taskListCmd.Flags().StringVar(&listPostedBy, "posted-by", "", "Only tasks posted by this agent ID")
+	taskListCmd.Flags().StringVar(&listSort, "sort", gigclaw.SortNewest, "Sort by newest, oldest, budget-high or budget-low")
+	taskListCmd.Flags().IntVarP(&listLimit, "limit", "n", 50, "Tasks per page (0 for all)")
+	taskListCmd.Flags().StringVar(&listCursor, "cursor", "", "Cursor of the page to show, printed after the previous page")
+
 	// Post flags
 	taskPostCmd.Flags().StringVarP(&taskTitle, "title", "t", "", "Task title (required)")
 	taskPostCmd.Flags().StringVarP(&taskDescription, "description", "d", "", "Task description")
 	taskPostCmd.Flags().Float64VarP(&taskBudget, "budget", "b", 0, "Task budget (required)")
-	taskPostCmd.Flags().StringVarP(&taskCurrency, "currency", "c", "USDC", "Currency (USDC, SOL)")
-	taskPostCmd.Flags().StringArrayVarP(&taskTags, "tag", "g", []string{}, "Task tags (can specify multiple)")
+	taskPostCmd.Flags().StringVarP(&taskCurrency, "currency", "c", "USDC", "Currency (USDC, SOL); defaults to the profile's currency if set")
+	taskPostCmd.Flags().StringArrayVarP(&taskTags, "tag", "g", []string{}, "Skills the task requires, 1-10 (can specify multiple)")
+	taskPostCmd.Flags().StringVar(&taskDeadline, "deadline", "", "Deadline, as a date (2026-02-01) or an RFC 3339 time (required)")
+	taskPostCmd.Flags().StringVar(&taskAs, "as", "", "Agent posting the task (default agent-id from the config file)")
+	taskPostCmd.Flags().StringVar(&taskIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never posts twice")
 
 	taskPostCmd.MarkFlagRequired("title")
 	taskPostCmd.MarkFlagRequired("budget")
+	taskPostCmd.MarkFlagRequired("deadline")
 }
 
 
@@ -85,111 +130,155 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Pre-flight connectivity check
-	if err := client.CheckConnectivity(); err != nil {
-		return err
+	if err := client.CheckConnectivity(cmd.Context()); err != nil {
+		return HandleAPIError(err)
 	}
 
 	// Show loading spinner
-	bar := progressbar.NewOptions(1,
-		progressbar.OptionSetDescription("Fetching tasks..."),
+	bar := newProgressBar(1, "Fetching tasks...",
 		progressbar.OptionSetWidth(20),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSpinnerType(14),
 	)
 	bar.Add(1)
 
-	tasks, err := client.ListTasks()
+	page, err := client.ListTasksPage(cmd.Context(), gigclaw.ListTasksOptions{
+		Status:    listStatus,
+		Tags:      listTags,
+		MinBudget: listMinBudget,
+		MaxBudget: listMaxBudget,
+		Currency:  listCurrency,
+		PostedBy:  listPostedBy,
+		Sort:      listSort,
+		Limit:     listLimit,
+		Cursor:    listCursor,
+	})
 	bar.Finish()
 	
 	if err != nil {
-		return err // Already handled by HandleAPIError
+		return HandleAPIError(err)
 	}
 
-	// Print header
-	fmt.Println()
-	colorPrimary.Println("  ╔══════════════════════════════════════════════════════════╗")
-	colorPrimary.Println("  ║                   GIGCLAW TASK BOARD                      ║")
-	colorPrimary.Println("  ╚══════════════════════════════════════════════════════════╝")
-	fmt.Println()
+	// Defaults the server ignores are applied locally without a warning
+	var flags []string
+	for _, name := range page.Unsupported {
+		flag := listQueryFlags[name]
+		if cmd.Flags().Changed(strings.TrimPrefix(flag, "--")) {
+			flags = append(flags, flag)
+		}
+	}
+	if len(flags) > 0 {
+		logger.Warning(fmt.Sprintf("The API does not support %s; applied locally after fetching all tasks",
+			strings.Join(flags, ", ")))
+	}
+	if page.NextCursor != "" && !decorated() {
+		logger.Info("More tasks: --cursor " + page.NextCursor)
+	}
 
-	if len(tasks) == 0 {
-		colorWarning.Println("  No tasks found.")
+	tasks := page.Tasks
+	return render(newTaskListView(tasks), func() {
+		// Print header
 		fmt.Println()
-		colorDim.Println("  Create your first task:")
+		colorPrimary.Println("  ╔══════════════════════════════════════════════════════════╗")
+		colorPrimary.Println("  ║                   GIGCLAW TASK BOARD                      ║")
+		colorPrimary.Println("  ╚══════════════════════════════════════════════════════════╝")
 		fmt.Println()
-		colorHighlight.Println("    gigclaw task post --title 'My Task' --budget 50")
-		fmt.Println()
-		return nil
-	}
 
-	// Print summary
-	colorLabel.Printf("  Found ")
-	colorHighlight.Printf("%d", len(tasks))
-	colorLabel.Println(" task(s)")
+		if len(tasks) == 0 {
+			colorWarning.Println("  No tasks found.")
+			fmt.Println()
+			colorDim.Println("  Create your first task:")
+			fmt.Println()
+			colorHighlight.Println("    gigclaw task post --title 'My Task' --budget 50 --deadline 2026-12-31")
+			fmt.Println()
+			return
+		}
+
+		// Print summary
+		colorLabel.Printf("  Found ")
+		colorHighlight.Printf("%d", len(tasks))
+		colorLabel.Println(" task(s)")
 	
-	// Count blockchain tasks
-	var blockchainCount int
-	for _, t := range tasks {
-		if t.BlockchainStatus != nil && t.BlockchainStatus.Status == "confirmed" {
-			blockchainCount++
+		// Count blockchain tasks
+		var blockchainCount int
+		for _, t := range tasks {
+			if t.BlockchainStatus != nil && t.BlockchainStatus.Status == "confirmed" {
+				blockchainCount++
+			}
 		}
-	}
-	if blockchainCount > 0 {
-		colorLabel.Printf("  ")
-		color.New(color.FgGreen).Printf("● %d on-chain", blockchainCount)
-		colorLabel.Println()
-	}
-	fmt.Println()
+		if blockchainCount > 0 {
+			colorLabel.Printf("  ")
+			color.New(color.FgGreen).Printf("● %d on-chain", blockchainCount)
+			colorLabel.Println()
+		}
+		fmt.Println()
 
-	// Create and print table using tabwriter
-	w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		// Create and print table using tabwriter
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
 	
-	// Header
-	fmt.Fprintln(w, color.New(color.FgHiWhite, color.Bold).Sprint("ID") + "\t" +
-		color.New(color.FgHiWhite, color.Bold).Sprint("TITLE") + "\t" +
-		color.New(color.FgHiWhite, color.Bold).Sprint("BUDGET") + "\t" +
-		color.New(color.FgHiWhite, color.Bold).Sprint("STATUS") + "\t" +
-		color.New(color.FgHiWhite, color.Bold).Sprint("CHAIN"))
+		// Header
+		fmt.Fprintln(w, color.New(color.FgHiWhite, color.Bold).Sprint("ID") + "\t" +
+			color.New(color.FgHiWhite, color.Bold).Sprint("TITLE") + "\t" +
+			color.New(color.FgHiWhite, color.Bold).Sprint("BUDGET") + "\t" +
+			color.New(color.FgHiWhite, color.Bold).Sprint("STATUS") + "\t" +
+			color.New(color.FgHiWhite, color.Bold).Sprint("CHAIN"))
 	
-	for _, task := range tasks {
-		chainStatus := colorDim.Sprint("-")
-		if task.BlockchainStatus != nil {
-			switch task.BlockchainStatus.Status {
-			case "confirmed":
-				chainStatus = color.New(color.FgGreen).Sprint("✓")
-			case "pending":
-				chainStatus = color.New(color.FgYellow).Sprint("⋯")
-			case "failed":
-				chainStatus = color.New(color.FgRed).Sprint("✗")
+		for _, task := range tasks {
+			chainStatus := colorDim.Sprint("-")
+			if task.BlockchainStatus != nil {
+				switch task.BlockchainStatus.Status {
+				case "confirmed":
+					chainStatus = color.New(color.FgGreen).Sprint("✓")
+				case "pending":
+					chainStatus = color.New(color.FgYellow).Sprint("⋯")
+				case "failed":
+					chainStatus = color.New(color.FgRed).Sprint("✗")
+				}
 			}
-		}
 		
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			colorDim.Sprint(truncate(task.ID, 8)),
-			colorValue.Sprint(truncate(task.Title, 35)),
-			colorPrimary.Sprintf("%.2f %s", task.Budget, task.Currency),
-			formatStatus(task.Status),
-			chainStatus,
-		)
-	}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				colorDim.Sprint(task.ID),
+				colorValue.Sprint(truncate(task.Title, 35)),
+				colorPrimary.Sprintf("%.2f %s", task.Budget, task.Currency),
+				formatStatus(task.Status),
+				chainStatus,
+			)
+		}
 
-	w.Flush()
-	fmt.Println()
+		w.Flush()
+		fmt.Println()
 	
-	// Legend
-	colorDim.Println("  Chain: ✓=on-chain  ⋯=pending  ✗=failed  -=memory")
-	fmt.Println()
+		// Legend
+		colorDim.Println("  Chain: ✓=on-chain  ⋯=pending  ✗=failed  -=memory")
+		fmt.Println()
 	
-	// Help footer
-	colorDim.Println("  Commands:")
-	fmt.Println("    gigclaw task post     Create a new task")
-	fmt.Println("    gigclaw task bid      Bid on a task")
-	fmt.Println()
+		if page.NextCursor != "" {
+			colorDim.Println("  More tasks (keep the same filters):")
+			fmt.Println("    gigclaw task list --cursor " + page.NextCursor)
+			fmt.Println()
+		}
 
-	return nil
+		// Help footer
+		colorDim.Println("  Commands:")
+		fmt.Println("    gigclaw task post     Create a new task")
+		fmt.Println("    gigclaw task bid      Bid on a task")
+		fmt.Println()
+	})
 }
 
 func runTaskPost(cmd *cobra.Command, args []string) error {
+	if currency := viper.GetString("currency"); currency != "" && !cmd.Flags().Changed("currency") {
+		taskCurrency = currency
+	}
+	deadline, err := parseDeadline(taskDeadline)
+	if err != nil {
+		return err
+	}
+	poster, err := actingAgent(taskAs, "--as")
+	if err != nil {
+		return err
+	}
+
 	// Check connectivity first
 	client, err := getAPIClient()
 	if err != nil {
@@ -197,13 +286,12 @@ func runTaskPost(cmd *cobra.Command, args []string) error {
 	}
 
 	// Pre-flight connectivity check
-	if err := client.CheckConnectivity(); err != nil {
-		return err
+	if err := client.CheckConnectivity(cmd.Context()); err != nil {
+		return HandleAPIError(err)
 	}
 
 	// Show progress
-	bar := progressbar.NewOptions(3,
-		progressbar.OptionSetDescription("Creating task..."),
+	bar := newProgressBar(3, "Creating task...",
 		progressbar.OptionSetWidth(30),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetTheme(progressbar.Theme{
@@ -216,74 +304,116 @@ func runTaskPost(cmd *cobra.Command, args []string) error {
 	)
 	bar.Add(1)
 
-	task, blockchain, err := client.CreateTask(taskTitle, taskDescription, taskBudget, taskCurrency, taskTags)
+	req := gigclaw.CreateTaskRequest{
+		Title:       taskTitle,
+		Description: taskDescription,
+		Budget:      taskBudget,
+		Currency:    taskCurrency,
+		Tags:        taskTags,
+		PosterID:    poster,
+		Deadline:    gigclaw.Timestamp{Time: deadline},
+	}
+
+	var task *gigclaw.Task
+	var blockchain *gigclaw.BlockchainStatus
+	res, err := runJournaled("task post", taskIdemKey, []interface{}{req}, func(key string) (string, error) {
+		var err error
+		task, blockchain, err = client.CreateTask(cmd.Context(), req, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		return task.ID, nil
+	})
 	bar.Add(2)
 	
 	if err != nil {
-		return err // Already handled by HandleAPIError
+		return HandleAPIError(err)
 	}
 	bar.Finish()
 
-	// Success output
-	fmt.Println()
-	colorSuccess.Println("  ╔══════════════════════════════════════════════════════════╗")
-	colorSuccess.Println("  ║              ✅ TASK CREATED SUCCESSFULLY                 ║")
-	colorSuccess.Println("  ╚══════════════════════════════════════════════════════════╝")
-	fmt.Println()
+	if res.Replayed {
+		// The journal fingerprint guarantees the request matches the posted task
+		posted := gigclaw.Task{
+			ID:          res.Result,
+			Title:       req.Title,
+			Description: req.Description,
+			Budget:      req.Budget,
+			Currency:    req.Currency,
+			Tags:        req.Tags,
+		}
+		view := taskPostView{taskView: newTaskView(posted), IdempotencyKey: res.Key, Replayed: true}
+		return render(view, func() {
+			fmt.Println()
+			colorWarning.Printf("  Task already posted with idempotency key %s\n", res.Key)
+			colorLabel.Printf("  %-15s ", "ID:")
+			colorValue.Println(res.Result)
+			fmt.Println()
+		})
+	}
+
+	view := taskPostView{taskView: newTaskView(*task), IdempotencyKey: res.Key}
+	if blockchain != nil {
+		view.Blockchain = newChainView(blockchain)
+	}
+	return render(view, func() {
+		// Success output
+		fmt.Println()
+		colorSuccess.Println("  ╔══════════════════════════════════════════════════════════╗")
+		colorSuccess.Println("  ║              ✅ TASK CREATED SUCCESSFULLY                 ║")
+		colorSuccess.Println("  ╚══════════════════════════════════════════════════════════╝")
+		fmt.Println()
 	
-	colorLabel.Printf("  %-15s ", "ID:")
-	colorValue.Println(task.ID)
+		colorLabel.Printf("  %-15s ", "ID:")
+		colorValue.Println(task.ID)
 	
-	colorLabel.Printf("  %-15s ", "Title:")
-	colorValue.Println(task.Title)
+		colorLabel.Printf("  %-15s ", "Title:")
+		colorValue.Println(task.Title)
 	
-	colorLabel.Printf("  %-15s ", "Budget:")
-	colorPrimary.Printf("%.2f %s\n", task.Budget, task.Currency)
+		colorLabel.Printf("  %-15s ", "Budget:")
+		colorPrimary.Printf("%.2f %s\n", task.Budget, task.Currency)
 	
-	colorLabel.Printf("  %-15s ", "Status:")
-	fmt.Println(formatStatus(task.Status))
+		colorLabel.Printf("  %-15s ", "Status:")
+		fmt.Println(formatStatus(task.Status))
 	
-	// Show blockchain status if available
-	if blockchain != nil {
-		fmt.Println()
-		colorLabel.Printf("  %-15s ", "Blockchain:")
-		switch blockchain.Status {
-		case "confirmed":
-			color.New(color.FgGreen, color.Bold).Printf("✓ CONFIRMED\n")
-			colorLabel.Printf("  %-15s ", "Signature:")
-			colorDim.Println(truncate(blockchain.Signature, 40))
-			colorLabel.Printf("  %-15s ", "Explorer:")
-			color.New(color.FgCyan).Println("https://explorer.solana.com/tx/" + blockchain.Signature + "?cluster=devnet")
-		case "pending":
-			color.New(color.FgYellow).Printf("⧖ PENDING\n")
-		case "failed":
-			color.New(color.FgRed, color.Bold).Printf("✗ FAILED\n")
-			if blockchain.Error != "" {
-				colorLabel.Printf("  %-15s ", "Error:")
-				color.New(color.FgRed).Println(blockchain.Error)
+		// Show blockchain status if available
+		if blockchain != nil {
+			fmt.Println()
+			colorLabel.Printf("  %-15s ", "Blockchain:")
+			switch blockchain.Status {
+			case "confirmed":
+				color.New(color.FgGreen, color.Bold).Printf("✓ CONFIRMED\n")
+				colorLabel.Printf("  %-15s ", "Signature:")
+				colorDim.Println(truncate(blockchain.Signature, 40))
+				colorLabel.Printf("  %-15s ", "Explorer:")
+				color.New(color.FgCyan).Println(explorerURL(blockchain.Signature))
+			case "pending":
+				color.New(color.FgYellow).Printf("⧖ PENDING\n")
+			case "failed":
+				color.New(color.FgRed, color.Bold).Printf("✗ FAILED\n")
+				if blockchain.Error != "" {
+					colorLabel.Printf("  %-15s ", "Error:")
+					color.New(color.FgRed).Println(blockchain.Error)
+				}
 			}
 		}
-	}
 	
-	if len(task.Tags) > 0 {
-		fmt.Println()
-		colorLabel.Printf("  %-15s ", "Tags:")
-		for i, tag := range task.Tags {
-			if i > 0 {
-				fmt.Print(" ")
+		if len(task.Tags) > 0 {
+			fmt.Println()
+			colorLabel.Printf("  %-15s ", "Tags:")
+			for i, tag := range task.Tags {
+				if i > 0 {
+					fmt.Print(" ")
+				}
+				color.New(color.FgHiBlack, color.BgHiWhite).Printf(" %s ", tag)
 			}
-			color.New(color.FgHiBlack, color.BgHiWhite).Printf(" %s ", tag)
+			fmt.Println()
 		}
-		fmt.Println()
-	}
 	
-	fmt.Println()
-	colorDim.Println("  Next steps:")
-	fmt.Println()
-	fmt.Println("    gigclaw task list              View all tasks")
-	fmt.Println("    gigclaw task bid " + colorDim.Sprint(task.ID) + "      Bid on this task")
-	fmt.Println()
-
-	return nil
+		fmt.Println()
+		colorDim.Println("  Next steps:")
+		fmt.Println()
+		fmt.Println("    gigclaw task list              View all tasks")
+		fmt.Println("    gigclaw task bid " + colorDim.Sprint(task.ID) + "      Bid on this task")
+		fmt.Println()
+	})
 }
-
@@ -0,0 +1,540 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+)
+
+var taskTeamCmd = &cobra.Command{
+	Use:   "team <task-id>",
+	Short: "Propose a team of agents for a task and split the work between them",
+	Long: `Propose a team of agents whose skills together cover a task, with the
+skills each member covers and what the team is likely to cost.
+
+Teams are drawn from the agents the server has outcomes for (see task
+verify --feedback). Costs are each member's suggested hourly rate times
+their average completion time, or times --hours.
+
+With --split, the task's budget is divided evenly between the members and a
+sub-task is posted for each, asking for the skills they cover best by the
+task's deadline, or --deadline. With --invite, a negotiation is also opened
+with each member over their sub-task. The team is saved in
+~/.gigclaw/teams.json so that task show lists the sub-tasks, and re-running
+the command finishes an interrupted split.`,
+	Example: `  gigclaw task team 123
+  gigclaw task team 123 --size 3 --skills rust,audit,docs
+  gigclaw task team 123 --split --invite --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTaskTeam,
+}
+
+var (
+	teamSize     int
+	teamSkills   []string
+	teamHours    float64
+	teamSplit    bool
+	teamInvite   bool
+	teamAs       string
+	teamDeadline string
+	teamYes      bool
+)
+
+func init() {
+	taskCmd.AddCommand(taskTeamCmd)
+
+	taskTeamCmd.Flags().IntVar(&teamSize, "size", 0, "Team size, 2-5 (default one per skill)")
+	taskTeamCmd.Flags().StringSliceVar(&teamSkills, "skills", nil, "Skills the team must cover (default the task's skills)")
+	taskTeamCmd.Flags().Float64Var(&teamHours, "hours", 0, "Hours each member works, for the cost estimate (default their average)")
+	taskTeamCmd.Flags().BoolVar(&teamSplit, "split", false, "Post a sub-task for each member")
+	taskTeamCmd.Flags().BoolVar(&teamInvite, "invite", false, "Open a negotiation with each member over their sub-task (implies --split)")
+	taskTeamCmd.Flags().StringVar(&teamAs, "as", "", "Agent posting the sub-tasks (default agent-id from the config file)")
+	taskTeamCmd.Flags().StringVar(&teamDeadline, "deadline", "", "Deadline of the sub-tasks, as a date or an RFC 3339 time (default the task's, or a week from now)")
+	taskTeamCmd.Flags().BoolVarP(&teamYes, "yes", "y", false, "Split without asking for confirmation")
+}
+
+// teamMemberView is a member of a team for a task
+type teamMemberView struct {
+	AgentID           string             `json:"agentId"`
+	Role              string             `json:"role"`
+	Levels            map[string]float64 `json:"levels,omitempty"` // in the required skills; proposals only
+	Skills            []string           `json:"skills"`           // required skills the member covers best
+	Rate              float64            `json:"rate,omitempty"`   // suggested, per hour
+	Hours             float64            `json:"hours,omitempty"`
+	EstimatedCost     float64            `json:"estimatedCost,omitempty"` // 0 when unknown
+	Budget            float64            `json:"budget"`                  // share of the task budget
+	SubTaskID         string             `json:"subTaskId,omitempty"`
+	SubTaskStatus     string             `json:"subTaskStatus,omitempty"`
+	NegotiationID     string             `json:"negotiationId,omitempty"`
+	NegotiationStatus string             `json:"negotiationStatus,omitempty"`
+}
+
+// teamView is a team for a task, proposed or split into sub-tasks
+type teamView struct {
+	TaskID           string           `json:"taskId"`
+	Budget           float64          `json:"budget"`
+	Currency         string           `json:"currency"`
+	Skills           []string         `json:"skills"`
+	Missing          []string         `json:"missing"` // skills no member has
+	Compatibility    float64          `json:"compatibility,omitempty"`
+	PredictedSuccess float64          `json:"predictedSuccess,omitempty"`
+	EstimatedCost    float64          `json:"estimatedCost"`
+	CostComplete     bool             `json:"costComplete"` // every member's cost is known
+	Split            bool             `json:"split"`
+	Members          []teamMemberView `json:"members"`
+}
+
+func (t teamView) columns() []string {
+	return []string{"AGENT", "ROLE", "SKILLS", "RATE", "HOURS", "EST. COST", "BUDGET", "SUB-TASK", "STATUS", "NEGOTIATION"}
+}
+
+func (t teamView) rows() [][]string {
+	rows := make([][]string, 0, len(t.Members))
+	for _, m := range t.Members {
+		rows = append(rows, []string{
+			m.AgentID, m.Role, strings.Join(m.Skills, ","), formatAmount(m.Rate), formatAmount(m.Hours),
+			formatAmount(m.EstimatedCost), formatAmount(m.Budget), m.SubTaskID, m.SubTaskStatus,
+			strings.TrimSpace(m.NegotiationID + " " + m.NegotiationStatus),
+		})
+	}
+	return rows
+}
+
+func runTaskTeam(cmd *cobra.Command, args []string) error {
+	if teamSize != 0 && (teamSize < 2 || teamSize > 5) {
+		return fmt.Errorf("--size must be 2-5")
+	}
+	if teamHours < 0 {
+		return fmt.Errorf("--hours must be positive")
+	}
+	split := teamSplit || teamInvite
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	ctx := cmd.Context()
+
+	task, err := client.GetTask(ctx, args[0])
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	store, err := openTeamStore()
+	if err != nil {
+		return err
+	}
+
+	// A split is finished with the team it started with, not a new proposal
+	team := store.Teams[task.ID]
+	var proposal *gigclaw.Team
+	if team != nil && split {
+		if cmd.Flags().Changed("size") || cmd.Flags().Changed("skills") {
+			logger.Warning(fmt.Sprintf("Task %s was already split; continuing with its team, so --size and --skills are ignored", task.ID))
+		}
+	} else {
+		skills := teamSkills
+		if len(skills) == 0 {
+			skills = task.Skills()
+		}
+		if len(skills) == 0 {
+			return fmt.Errorf("task %s lists no skills: name the skills the team must cover with --skills", task.ID)
+		}
+		size := teamSize
+		if size == 0 {
+			size = min(max(len(skills), 2), 5)
+		}
+
+		proposal, err = client.RecommendTeam(ctx, gigclaw.TeamRequest{TaskID: task.ID, RequiredSkills: skills, TeamSize: size})
+		if err != nil {
+			return HandleAPIError(err)
+		}
+		if len(proposal.Members) == 0 {
+			return friendlyError(nil,
+				fmt.Sprintf("No agents can cover %s", strings.Join(skills, ", ")),
+				[]string{"API: " + client.BaseURL()},
+				"Teams are drawn from agents with recorded outcomes: gigclaw task verify <task-id> --feedback",
+				"Find agents one at a time: gigclaw task match "+task.ID)
+		}
+		team = newStoredTeam(task, skills, proposal.Members)
+	}
+
+	if split {
+		poster, err := actingAgent(teamAs, "--as")
+		if err != nil {
+			return err
+		}
+		// The deadline is kept with the team, so that a retried sub-task
+		// post sends the same request
+		deadline := team.Deadline
+		if deadline.IsZero() {
+			if deadline, err = subTaskDeadline(task); err != nil {
+				return err
+			}
+		} else if cmd.Flags().Changed("deadline") {
+			logger.Warning(fmt.Sprintf("Task %s was already split; its sub-tasks keep their deadline, so --deadline is ignored", task.ID))
+		}
+		if store.Teams[task.ID] == nil {
+			if !strings.EqualFold(task.Status, "posted") {
+				return friendlyError(nil,
+					fmt.Sprintf("Cannot split task %s: it is %s", task.ID, task.Status),
+					nil,
+					"Only tasks still open for bids can be split: gigclaw task list --status posted")
+			}
+			question := fmt.Sprintf("Split task %s into %d sub-tasks sharing its %.2f %s budget?",
+				task.ID, len(team.Members), task.Budget, team.Currency)
+			if err := confirmAction(question, teamYes); err != nil {
+				return err
+			}
+			team.CreatedAt = time.Now()
+			store.Teams[task.ID] = team
+		}
+		if team.Deadline.IsZero() {
+			team.Deadline = deadline
+			if err := store.save(); err != nil {
+				return err
+			}
+		}
+		if err := postSubTasks(ctx, client, store, team, task, poster); err != nil {
+			return HandleAPIError(err)
+		}
+		if teamInvite {
+			if err := inviteTeam(ctx, client, store, team, poster); err != nil {
+				return HandleAPIError(err)
+			}
+		}
+	}
+
+	view := newTeamView(team, task.Budget)
+	view.Split = split
+	if proposal != nil {
+		view.Compatibility = proposal.Compatibility
+		view.PredictedSuccess = proposal.PredictedSuccess
+		for i, m := range proposal.Members {
+			view.Members[i].Levels = requiredLevels(m.Skills, team.Skills)
+		}
+	}
+	estimateTeamCost(ctx, client, &view, teamHours)
+	if split {
+		loadSubTaskStatus(ctx, client, &view, logger.Warning)
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		colorPrimary.Printf("  Team for %s\n", truncate(task.Title, 50))
+		fmt.Println()
+		printTeam(view)
+
+		switch {
+		case !split:
+			colorDim.Printf("  Post a sub-task for each member and invite them: gigclaw task team %s --invite\n", task.ID)
+		case !teamInvite:
+			colorDim.Printf("  Invite the members: gigclaw task team %s --invite\n", task.ID)
+		default:
+			colorDim.Printf("  Follow the sub-tasks: gigclaw task show %s\n", task.ID)
+		}
+		fmt.Println()
+	})
+}
+
+// newStoredTeam turns a proposed team into sub-task plans: each required
+// skill goes to the member with the highest level in it, and the budget is
+// divided evenly, the lead taking any remainder
+func newStoredTeam(task *gigclaw.Task, skills []string, members []gigclaw.TeamMember) *storedTeam {
+	team := &storedTeam{
+		TaskID:   task.ID,
+		Currency: firstNonEmpty(task.Currency, "USDC"),
+		Skills:   skills,
+	}
+
+	assigned := make([][]string, len(members))
+	for _, s := range skills {
+		best := -1
+		for i, m := range members {
+			if m.Skills[s] > 0 && (best < 0 || m.Skills[s] > members[best].Skills[s]) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			assigned[best] = append(assigned[best], s)
+		}
+	}
+
+	cents := int64(math.Round(task.Budget * 100))
+	share := cents / int64(len(members))
+	for i, m := range members {
+		mine := share
+		if i == 0 {
+			mine += cents - share*int64(len(members))
+		}
+		if len(assigned[i]) == 0 {
+			// Outdone in every skill, the member still works on the ones they have
+			for _, s := range skills {
+				if m.Skills[s] > 0 {
+					assigned[i] = append(assigned[i], s)
+				}
+			}
+		}
+		team.Members = append(team.Members, &teamMember{
+			AgentID:   m.AgentID,
+			Role:      m.Role,
+			Skills:    assigned[i],
+			Budget:    float64(mine) / 100,
+			PostKey:   gigclaw.NewIdempotencyKey(),
+			InviteKey: gigclaw.NewIdempotencyKey(),
+		})
+	}
+	return team
+}
+
+// subTaskDeadline returns the deadline of a new split's sub-tasks: the
+// --deadline flag, the task's own deadline, or a week from now
+func subTaskDeadline(task *gigclaw.Task) (time.Time, error) {
+	if teamDeadline != "" {
+		return parseDeadline(teamDeadline)
+	}
+	if !task.Deadline.IsZero() {
+		return task.Deadline.Time, nil
+	}
+	return time.Now().AddDate(0, 0, 7), nil
+}
+
+// requiredLevels returns an agent's levels in the required skills
+func requiredLevels(levels map[string]float64, skills []string) map[string]float64 {
+	out := make(map[string]float64)
+	for _, s := range skills {
+		if l, ok := levels[s]; ok {
+			out[s] = l
+		}
+	}
+	return out
+}
+
+// postSubTasks posts a sub-task for each member that has none yet, saving
+// the team after each so that a failed split can be finished later
+func postSubTasks(ctx context.Context, client *gigclaw.Client, store *teamStore, team *storedTeam, task *gigclaw.Task, poster string) error {
+	for _, m := range team.Members {
+		if m.SubTaskID != "" {
+			continue
+		}
+		skills := firstNonEmpty(strings.Join(m.Skills, ", "), "general")
+		required := m.Skills
+		if len(required) == 0 {
+			required = team.Skills
+		}
+		req := gigclaw.CreateTaskRequest{
+			Title: truncate(fmt.Sprintf("%s (%s: %s)", task.Title, m.Role, skills), 200),
+			Description: fmt.Sprintf("Part of task %s, split between a team of %d. This part covers %s.\n\n%s",
+				task.ID, len(team.Members), skills, task.Description),
+			Budget:         m.Budget,
+			Currency:       team.Currency,
+			Tags:           m.Skills,
+			RequiredSkills: required[:min(len(required), 10)],
+			PosterID:       poster,
+			Deadline:       gigclaw.Timestamp{Time: team.Deadline},
+		}
+		res, err := runJournaled("task post", m.PostKey, []interface{}{req}, func(key string) (string, error) {
+			sub, _, err := client.CreateTask(ctx, req, gigclaw.IdempotencyKey(key))
+			if err != nil {
+				return "", err
+			}
+			return sub.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+		m.SubTaskID = res.Result
+		if err := store.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inviteTeam opens a negotiation with each member over their sub-task at
+// their share of the budget
+func inviteTeam(ctx context.Context, client *gigclaw.Client, store *teamStore, team *storedTeam, poster string) error {
+	for _, m := range team.Members {
+		if m.NegotiationID != "" || m.SubTaskID == "" {
+			continue
+		}
+		if m.AgentID == poster {
+			logger.Warning(fmt.Sprintf("Not inviting %s to sub-task %s: it is the poster", m.AgentID, m.SubTaskID))
+			continue
+		}
+		req := gigclaw.StartNegotiationRequest{
+			TaskID:     m.SubTaskID,
+			PosterID:   poster,
+			WorkerID:   m.AgentID,
+			InitialBid: gigclaw.NegotiationTerms{Price: m.Budget},
+		}
+		res, err := runJournaled("negotiate start", m.InviteKey, []interface{}{req}, func(key string) (string, error) {
+			n, err := client.StartNegotiation(ctx, req, gigclaw.IdempotencyKey(key))
+			if err != nil {
+				return "", err
+			}
+			return n.ID, nil
+		})
+		if err != nil {
+			return err
+		}
+		m.NegotiationID = res.Result
+		if err := store.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTeamView describes a team; skills no member covers are missing
+func newTeamView(team *storedTeam, budget float64) teamView {
+	view := teamView{
+		TaskID:   team.TaskID,
+		Budget:   budget,
+		Currency: team.Currency,
+		Skills:   team.Skills,
+		Missing:  []string{},
+		Members:  []teamMemberView{},
+	}
+	covered := make(map[string]bool)
+	for _, m := range team.Members {
+		for _, s := range m.Skills {
+			covered[s] = true
+		}
+		view.Members = append(view.Members, teamMemberView{
+			AgentID:       m.AgentID,
+			Role:          m.Role,
+			Skills:        m.Skills,
+			Budget:        m.Budget,
+			SubTaskID:     m.SubTaskID,
+			NegotiationID: m.NegotiationID,
+		})
+	}
+	for _, s := range team.Skills {
+		if !covered[s] {
+			view.Missing = append(view.Missing, s)
+		}
+	}
+	return view
+}
+
+// estimateTeamCost prices each member at their suggested rate for hours,
+// or their average completion time when hours is zero. Members whose rate
+// or hours are unknown are left out of the total.
+func estimateTeamCost(ctx context.Context, client *gigclaw.Client, view *teamView, hours float64) {
+	view.CostComplete = true
+	for i := range view.Members {
+		m := &view.Members[i]
+		a, err := client.GetAgentAnalytics(ctx, m.AgentID)
+		if err != nil {
+			logger.Debug("Analytics unavailable for the cost estimate", m.AgentID, err)
+			view.CostComplete = false
+			continue
+		}
+		m.Rate = a.MarketValue.SuggestedRate
+		m.Hours = hours
+		if m.Hours == 0 {
+			m.Hours = a.Performance.AvgCompletionTime
+		}
+		if m.Rate == 0 || m.Hours == 0 {
+			view.CostComplete = false
+			continue
+		}
+		m.EstimatedCost = math.Round(m.Rate*m.Hours*100) / 100
+		view.EstimatedCost += m.EstimatedCost
+	}
+}
+
+// loadSubTaskStatus fills in the status of each member's sub-task and
+// negotiation. These lookups are best effort: failures are reported to warn.
+func loadSubTaskStatus(ctx context.Context, client *gigclaw.Client, view *teamView, warn func(string)) {
+	for i := range view.Members {
+		m := &view.Members[i]
+		if m.SubTaskID != "" {
+			if sub, err := client.GetTask(ctx, m.SubTaskID); err == nil {
+				m.SubTaskStatus = sub.Status
+			} else {
+				warn(fmt.Sprintf("Sub-task %s unavailable: %v", m.SubTaskID, err))
+			}
+		}
+		if m.NegotiationID != "" {
+			if n, err := client.GetNegotiation(ctx, m.NegotiationID); err == nil {
+				m.NegotiationStatus = n.Status
+			} else {
+				warn(fmt.Sprintf("Negotiation %s unavailable: %v", m.NegotiationID, err))
+			}
+		}
+	}
+}
+
+// printTeam prints a team's coverage, cost and members
+func printTeam(v teamView) {
+	label := func(name string) { colorLabel.Printf("  %-15s ", name+":") }
+
+	label("Coverage")
+	covered := len(v.Skills) - len(v.Missing)
+	if len(v.Missing) == 0 {
+		colorSuccess.Printf("all %d skills\n", covered)
+	} else {
+		colorWarning.Printf("%d of %d skills (missing %s)\n", covered, len(v.Skills), strings.Join(v.Missing, ", "))
+	}
+	if v.Compatibility > 0 {
+		label("Compatibility")
+		colorValue.Printf("%.0f%%, predicted success %.0f%%\n", v.Compatibility*100, v.PredictedSuccess)
+	}
+	label("Est. cost")
+	switch {
+	case v.EstimatedCost == 0:
+		colorDim.Println("unknown")
+	case !v.CostComplete:
+		colorValue.Printf("%.2f %s or more (budget %.2f)\n", v.EstimatedCost, v.Currency, v.Budget)
+	case v.EstimatedCost > v.Budget:
+		colorWarning.Printf("%.2f %s (over the %.2f budget)\n", v.EstimatedCost, v.Currency, v.Budget)
+	default:
+		colorValue.Printf("%.2f %s (budget %.2f)\n", v.EstimatedCost, v.Currency, v.Budget)
+	}
+	fmt.Println()
+
+	for i, m := range v.Members {
+		fmt.Printf("  %d. ", i+1)
+		colorHighlight.Print(m.AgentID)
+		colorDim.Printf("  %s\n", firstNonEmpty(m.Role, "member"))
+		fmt.Printf("     Covers %s\n", firstNonEmpty(formatLevels(m.Skills, m.Levels), "no required skill"))
+		if m.EstimatedCost > 0 {
+			colorDim.Printf("     %s/h × %sh ≈ %.2f %s\n", formatAmount(m.Rate), formatAmount(m.Hours), m.EstimatedCost, v.Currency)
+		}
+		if m.SubTaskID != "" {
+			fmt.Printf("     Sub-task %s, %.2f %s", m.SubTaskID, m.Budget, v.Currency)
+			if m.SubTaskStatus != "" {
+				fmt.Printf(" · %s", formatStatus(m.SubTaskStatus))
+			}
+			fmt.Println()
+		}
+		if m.NegotiationID != "" {
+			fmt.Printf("     Negotiation %s", m.NegotiationID)
+			if m.NegotiationStatus != "" {
+				fmt.Printf(" · %s", m.NegotiationStatus)
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+	}
+}
+
+// formatLevels lists skills with their levels when known, e.g. "rust (85)"
+func formatLevels(skills []string, levels map[string]float64) string {
+	parts := make([]string, 0, len(skills))
+	for _, s := range skills {
+		if l, ok := levels[s]; ok {
+			parts = append(parts, fmt.Sprintf("%s (%.0f)", s, l))
+		} else {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
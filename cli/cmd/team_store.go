@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// teamMember is a member of a team and the sub-task split off for them
+type teamMember struct {
+	AgentID       string   `json:"agentId"`
+	Role          string   `json:"role"`
+	Skills        []string `json:"skills"` // required skills assigned to the member
+	Budget        float64  `json:"budget"`
+	SubTaskID     string   `json:"subTaskId,omitempty"`
+	NegotiationID string   `json:"negotiationId,omitempty"`
+	PostKey       string   `json:"postKey"`   // idempotency key of the sub-task
+	InviteKey     string   `json:"inviteKey"` // idempotency key of the negotiation
+}
+
+// storedTeam is a team whose task was split into sub-tasks from this
+// machine. The API has no notion of sub-tasks, so the group lives here.
+type storedTeam struct {
+	TaskID    string        `json:"taskId"`
+	Currency  string        `json:"currency"`
+	Skills    []string      `json:"skills"` // required skills
+	Members   []*teamMember `json:"members"`
+	CreatedAt time.Time     `json:"createdAt"`
+	// Deadline of the sub-tasks; zero in teams saved before it was kept
+	Deadline time.Time `json:"deadline"`
+}
+
+// teamStore keeps the teams split from this machine so that task show can
+// follow their sub-tasks
+type teamStore struct {
+	Teams map[string]*storedTeam `json:"teams"` // keyed by parent task ID
+
+	path string
+}
+
+// teamStorePath returns ~/.gigclaw/teams.json
+func teamStorePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "teams.json"
+	}
+	return filepath.Join(home, ".gigclaw", "teams.json")
+}
+
+// openTeamStore loads the store, returning an empty one if it does not
+// exist yet
+func openTeamStore() (*teamStore, error) {
+	s := &teamStore{
+		Teams: make(map[string]*storedTeam),
+		path:  teamStorePath(),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read teams: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse teams %s: %w", s.path, err)
+	}
+	if s.Teams == nil {
+		s.Teams = make(map[string]*storedTeam)
+	}
+	return s, nil
+}
+
+// save atomically writes the store with owner-only permissions
+func (s *teamStore) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode teams: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write teams: %w", err)
+	}
+	return nil
+}
+
+// parentOf returns the team task a sub-task was split from, if any
+func (s *teamStore) parentOf(subTaskID string) (string, bool) {
+	for _, t := range s.Teams {
+		for _, m := range t.Members {
+			if m.SubTaskID == subTaskID {
+				return t.TaskID, true
+			}
+		}
+	}
+	return "", false
+}
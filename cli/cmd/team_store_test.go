@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTeamStoreRoundTrip(t *testing.T) {
+	home := useTempHome(t)
+
+	s, err := openTeamStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Teams) != 0 {
+		t.Fatalf("new store has %d teams", len(s.Teams))
+	}
+
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	s.Teams["t1"] = &storedTeam{
+		TaskID: "t1", Currency: "USDC", Skills: []string{"rust", "docs"}, CreatedAt: created,
+		Members: []*teamMember{
+			{AgentID: "agent-7", Role: "Lead", Skills: []string{"rust"}, Budget: 90, SubTaskID: "t1-a", PostKey: "k1", InviteKey: "k2"},
+			{AgentID: "agent-9", Role: "Contributor", Skills: []string{"docs"}, Budget: 30, PostKey: "k3", InviteKey: "k4"},
+		},
+	}
+	if err := s.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := openTeamStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := loaded.Teams["t1"]
+	if team == nil || len(team.Members) != 2 || !team.CreatedAt.Equal(created) || team.Members[0].SubTaskID != "t1-a" {
+		t.Fatalf("team = %+v", team)
+	}
+	if parent, ok := loaded.parentOf("t1-a"); !ok || parent != "t1" {
+		t.Errorf("parentOf(t1-a) = %q, %v", parent, ok)
+	}
+	if _, ok := loaded.parentOf("t1"); ok {
+		t.Error("the team task is its own sub-task")
+	}
+
+	dir := filepath.Join(home, ".gigclaw")
+	info, err := os.Stat(filepath.Join(dir, "teams.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("mode = %o, want 600", perm)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("%s has %d files, want only the team store", dir, len(entries))
+	}
+}
+
+func TestOpenTeamStoreCorrupt(t *testing.T) {
+	home := useTempHome(t)
+	dir := filepath.Join(home, ".gigclaw")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "teams.json"), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openTeamStore(); err == nil {
+		t.Error("opened a corrupt team store")
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+func TestPostSubTasks(t *testing.T) {
+	useTempHome(t)
+	deadline := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	// The fields createTaskValidation requires
+	var posted []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		skills, _ := body["requiredSkills"].([]interface{})
+		if body["deadline"] != "2026-02-01T00:00:00Z" || len(skills) == 0 || body["posterId"] != "alice" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Validation failed","details":[]}`))
+			return
+		}
+		posted = append(posted, body)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"message":"Task created","task":{"id":"sub-%d","createdAt":1767225600000}}`, len(posted))
+	}))
+	defer srv.Close()
+	client, err := gigclaw.NewClient(gigclaw.WithBaseURL(srv.URL), gigclaw.WithRetryPolicy(gigclaw.NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := openTeamStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := &gigclaw.Task{ID: "t1", Title: "Audit token program", Description: "Review the escrow program", Budget: 120}
+	team := &storedTeam{
+		TaskID: "t1", Currency: "USDC", Skills: []string{"rust", "docs"}, Deadline: deadline,
+		Members: []*teamMember{
+			{AgentID: "agent-7", Role: "Lead", Skills: []string{"rust"}, Budget: 90, PostKey: "k1"},
+			{AgentID: "agent-9", Role: "Contributor", Budget: 30, PostKey: "k2"}, // outdone in every skill
+		},
+	}
+	store.Teams["t1"] = team
+
+	if err := postSubTasks(context.Background(), client, store, team, task, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if len(posted) != 2 || team.Members[0].SubTaskID != "sub-1" || team.Members[1].SubTaskID != "sub-2" {
+		t.Fatalf("posted %d sub-tasks, members %+v %+v", len(posted), team.Members[0], team.Members[1])
+	}
+	// A member without skills of their own asks for the team's
+	if skills := posted[1]["requiredSkills"].([]interface{}); len(skills) != 2 {
+		t.Errorf("requiredSkills = %v, want the team's", skills)
+	}
+}
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/spf13/viper"
 )
 
 // formatStatus returns a colored status string
@@ -15,10 +20,34 @@ func formatStatus(status string) string {
 		return color.New(color.FgYellow).Sprintf("◐ %s", status)
 	case "completed":
 		return color.New(color.FgBlue).Sprintf("◉ %s", status)
-	case "verified":
+	case "verified", "paid":
 		return color.New(color.FgHiBlack).Sprintf("✓ %s", status)
 	case "cancelled":
 		return color.New(color.FgRed).Sprintf("✗ %s", status)
+	case "open", "disputed":
+		return color.New(color.FgRed).Sprintf("⚑ %s", status)
+	case "under_review":
+		return color.New(color.FgYellow).Sprintf("◐ %s", status)
+	case "resolved":
+		return color.New(color.FgHiBlack).Sprintf("✓ %s", status)
+	case "active":
+		return color.New(color.FgYellow).Sprintf("◐ %s", status)
+	case "agreed":
+		return color.New(color.FgGreen).Sprintf("✓ %s", status)
+	case "deadlocked", "failed":
+		return color.New(color.FgRed).Sprintf("✗ %s", status)
+	case "success":
+		return color.New(color.FgGreen).Sprintf("✓ %s", status)
+	case "pending":
+		return color.New(color.FgYellow).Sprintf("◐ %s", status)
+	case "available":
+		return color.New(color.FgGreen).Sprintf("● %s", status)
+	case "busy":
+		return color.New(color.FgYellow).Sprintf("◐ %s", status)
+	case "away":
+		return color.New(color.FgHiBlack).Sprintf("◌ %s", status)
+	case "offline":
+		return color.New(color.FgHiBlack).Sprintf("○ %s", status)
 	default:
 		return status
 	}
@@ -31,3 +60,63 @@ func truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// actingAgent returns the agent a command acts as, from the flag or the
+// config file
+func actingAgent(flagValue, flagName string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if id := viper.GetString("agent-id"); id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("agent ID is required: pass %s, or register one with gigclaw agent register", flagName)
+}
+
+// parseDeadline reads a deadline given as a date, meaning midnight local
+// time, or as an RFC 3339 time. It must be in the future.
+func parseDeadline(s string) (time.Time, error) {
+	deadline, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		deadline, err = time.ParseInLocation(time.DateOnly, s, time.Local)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid deadline %q: use a date (2026-02-01) or an RFC 3339 time (2026-02-01T17:00:00Z)", s)
+	}
+	if !deadline.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("deadline %s is in the past", s)
+	}
+	return deadline, nil
+}
+
+// writeFileAtomic writes data to path through a temporary file in the same
+// directory, creating the directory if needed, so readers never see a
+// partial write. An existing file keeps its mode; a new file gets perm.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomicMode(t *testing.T) {
+	dir := t.TempDir()
+
+	// A new file gets perm
+	created := filepath.Join(dir, "new.json")
+	if err := writeFileAtomic(created, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// An existing file keeps its mode
+	existing := filepath.Join(dir, "existing.json")
+	if err := os.WriteFile(existing, []byte("{}"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(existing, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(existing, []byte(`{"a":1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]os.FileMode{created: 0600, existing: 0640} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != want {
+			t.Errorf("%s: mode = %o, want %o", filepath.Base(path), perm, want)
+		}
+	}
+	if data, _ := os.ReadFile(existing); string(data) != `{"a":1}` {
+		t.Errorf("existing.json = %s, want the new data", data)
+	}
+}
+
+func TestParseDeadline(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+
+	got, err := parseDeadline(future.Format(time.DateOnly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y, m, d := got.Date(); got.Location() != time.Local || y != future.Year() || m != future.Month() || d != future.Day() || got.Hour() != 0 {
+		t.Errorf("date: got %v, want midnight local on %s", got, future.Format(time.DateOnly))
+	}
+	if got, err := parseDeadline(future.UTC().Format(time.RFC3339)); err != nil || !got.Equal(future.Truncate(time.Second)) {
+		t.Errorf("RFC 3339: got %v, %v, want %v", got, err, future)
+	}
+	for _, s := range []string{"", "next week", "2020-01-01"} {
+		if _, err := parseDeadline(s); err == nil {
+			t.Errorf("parseDeadline(%q) succeeded", s)
+		}
+	}
+}
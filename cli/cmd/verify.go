@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <task-id>",
+	Short: "Approve delivered work and release payment",
+	Long: `Verify the work delivered on a task you posted. This releases the
+escrowed payment to the assigned agent, after the server's dispute window.
+
+With --feedback the successful outcome is also sent to the server's match
+predictions, so that task match ranks the agent better for similar work.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerify,
+}
+
+var (
+	verifyYes      bool
+	verifyIdemKey  string
+	verifyFeedback bool
+)
+
+func init() {
+	taskCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().BoolVarP(&verifyYes, "yes", "y", false, "Release the payment without asking for confirmation")
+	verifyCmd.Flags().StringVar(&verifyIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never verifies twice")
+	verifyCmd.Flags().BoolVar(&verifyFeedback, "feedback", false, "Send the outcome to match predictions (default match-feedback from the config file)")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	task, err := client.GetTask(cmd.Context(), taskID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	done := []string{"verified", "paid"}
+	if err := requireTaskStatus(task, "verify", "completed", done, verifyIdemKey,
+		"Work can only be verified after the agent runs: gigclaw task complete "+task.ID); err != nil {
+		return err
+	}
+
+	recipient := task.AssignedAgent
+	if recipient == "" {
+		recipient = "the assigned agent"
+	}
+	view := taskActionView{
+		TaskID:         task.ID,
+		Action:         "verify",
+		PreviousStatus: task.Status,
+		Status:         "verified",
+		DeliveryURL:    task.DeliveryURL,
+		Funds: fundsView{
+			Amount:    escrowedAmount(cmd.Context(), client, task),
+			Currency:  task.Currency,
+			Outcome:   fundsRelease,
+			Recipient: task.AssignedAgent,
+			Note:      releaseNote(cmd.Context(), client),
+		},
+	}
+
+	question := fmt.Sprintf("Release %.2f %s from escrow to %s?", view.Funds.Amount, view.Funds.Currency, recipient)
+	if task.DeliveryURL != "" {
+		question = fmt.Sprintf("Delivery: %s\n%s", task.DeliveryURL, question)
+	}
+	if err := confirmAction(question, verifyYes); err != nil {
+		return err
+	}
+
+	res, err := runJournaled("task verify", verifyIdemKey, []interface{}{taskID}, func(key string) (string, error) {
+		updated, err := client.VerifyTask(cmd.Context(), taskID, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		if updated.Status != "" {
+			view.Status = updated.Status
+		}
+		return taskID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	view.IdempotencyKey = res.Key
+	view.Replayed = res.Replayed
+
+	// A replay sent its feedback the first time, if any
+	feedback := verifyFeedback
+	if !cmd.Flags().Changed("feedback") {
+		feedback = viper.GetBool("match-feedback")
+	}
+	if feedback && !res.Replayed {
+		view.FeedbackSent = sendMatchFeedback(cmd.Context(), client, task)
+	}
+
+	return render(view, func() {
+		if res.Replayed {
+			fmt.Printf("Task already verified with idempotency key %s\n", res.Key)
+		} else {
+			fmt.Println("✅ Work verified!")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Task:")
+		colorValue.Println(task.ID)
+		printFunds(view.Funds)
+		if view.FeedbackSent {
+			colorLabel.Printf("  %-15s ", "Feedback:")
+			colorValue.Printf("sent for %s\n", task.AssignedAgent)
+		}
+		fmt.Println()
+		fmt.Println("Track the release with: gigclaw task show " + task.ID)
+	})
+}
+
+// sendMatchFeedback reports a verified task as a success of its agent. The
+// payment is released either way, so a failure is only a warning.
+func sendMatchFeedback(ctx context.Context, client *gigclaw.Client, task *gigclaw.Task) bool {
+	if task.AssignedAgent == "" {
+		logger.Warning("Match feedback not sent: the task has no assigned agent")
+		return false
+	}
+	_, err := client.SendMatchFeedback(ctx, gigclaw.MatchFeedback{
+		AgentID:    task.AssignedAgent,
+		TaskID:     task.ID,
+		Success:    true,
+		SkillsUsed: task.Skills(),
+	})
+	if err != nil {
+		logger.Warning(fmt.Sprintf("Match feedback not sent: %v", err))
+		return false
+	}
+	return true
+}
+
+// releaseNote explains when a verified payment leaves escrow
+func releaseNote(ctx context.Context, client *gigclaw.Client) string {
+	cfg, err := client.GetEscrowConfig(ctx)
+	switch {
+	case err != nil:
+		logger.Debug("Escrow config unavailable", err)
+		return "Released from escrow once the server processes the verification"
+	case !cfg.Enabled:
+		return "Auto-release is disabled on this server; an arbitrator releases the funds"
+	case cfg.Delay() > 0:
+		return fmt.Sprintf("Released after a %s dispute window", cfg.Delay())
+	default:
+		return "Released immediately"
+	}
+}
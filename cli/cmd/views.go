@@ -0,0 +1,746 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// Views are the schema of structured output (--output json, yaml, csv and
+// template). They are decoupled from the API models so that scripts keep
+// working when the API changes: fields may be added, but are never renamed
+// or removed.
+
+// chainView is the on-chain state of a task
+type chainView struct {
+	Status      string `json:"status"`
+	Signature   string `json:"signature"`
+	Error       string `json:"error,omitempty"`
+	ExplorerURL string `json:"explorerUrl,omitempty"`
+}
+
+func newChainView(b *gigclaw.BlockchainStatus) *chainView {
+	if b == nil {
+		return nil
+	}
+	v := &chainView{Status: b.Status, Signature: b.Signature, Error: b.Error}
+	if b.Signature != "" {
+		v.ExplorerURL = explorerURL(b.Signature)
+	}
+	return v
+}
+
+// chainStatus is the status column value, "-" for off-chain tasks
+func (c *chainView) chainStatus() string {
+	if c == nil {
+		return "-"
+	}
+	return c.Status
+}
+
+// explorerURL links a transaction signature to the Solana explorer
+func explorerURL(signature string) string {
+	return "https://explorer.solana.com/tx/" + signature + "?cluster=devnet"
+}
+
+// taskView is a task
+type taskView struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Budget      float64    `json:"budget"`
+	Currency    string     `json:"currency"`
+	Status      string     `json:"status"`
+	Tags        []string   `json:"tags"`
+	CreatedAt   string     `json:"createdAt"`
+	Blockchain  *chainView `json:"blockchain"`
+}
+
+func newTaskView(t gigclaw.Task) taskView {
+	tags := t.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return taskView{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Budget:      t.Budget,
+		Currency:    t.Currency,
+		Status:      t.Status,
+		Tags:        tags,
+		CreatedAt:   formatTime(t.CreatedAt.Time),
+		Blockchain:  newChainView(t.BlockchainStatus),
+	}
+}
+
+var taskColumns = []string{"ID", "TITLE", "BUDGET", "CURRENCY", "STATUS", "TAGS", "CHAIN"}
+
+func (t taskView) row() []string {
+	return []string{
+		t.ID,
+		t.Title,
+		formatAmount(t.Budget),
+		t.Currency,
+		t.Status,
+		strings.Join(t.Tags, ","),
+		t.Blockchain.chainStatus(),
+	}
+}
+
+func (t taskView) columns() []string { return taskColumns }
+func (t taskView) rows() [][]string  { return [][]string{t.row()} }
+
+// taskListView is a list of tasks
+type taskListView []taskView
+
+func newTaskListView(tasks []gigclaw.Task) taskListView {
+	v := make(taskListView, 0, len(tasks))
+	for _, t := range tasks {
+		v = append(v, newTaskView(t))
+	}
+	return v
+}
+
+func (l taskListView) columns() []string { return taskColumns }
+
+func (l taskListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, t := range l {
+		rows = append(rows, t.row())
+	}
+	return rows
+}
+
+// taskPostView is the result of task post
+type taskPostView struct {
+	taskView
+	IdempotencyKey string `json:"idempotencyKey"`
+	Replayed       bool   `json:"replayed"` // an earlier run already posted the task
+}
+
+func (p taskPostView) columns() []string {
+	return append(append([]string{}, taskColumns...), "IDEMPOTENCY KEY", "REPLAYED")
+}
+
+func (p taskPostView) rows() [][]string {
+	return [][]string{append(p.row(), p.IdempotencyKey, strconv.FormatBool(p.Replayed))}
+}
+
+// bidView is the result of task bid
+type bidView struct {
+	ID             string  `json:"id"`
+	TaskID         string  `json:"taskId"`
+	AgentID        string  `json:"agentId"`
+	Amount         float64 `json:"amount"`
+	Message        string  `json:"message"`
+	Status         string  `json:"status"`
+	CreatedAt      string  `json:"createdAt"`
+	IdempotencyKey string  `json:"idempotencyKey"`
+	Replayed       bool    `json:"replayed"`
+}
+
+func (b bidView) columns() []string {
+	return []string{"ID", "TASK", "AGENT", "AMOUNT", "STATUS", "MESSAGE", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (b bidView) rows() [][]string {
+	return [][]string{{
+		b.ID, b.TaskID, b.AgentID, formatAmount(b.Amount), b.Status, b.Message,
+		b.IdempotencyKey, strconv.FormatBool(b.Replayed),
+	}}
+}
+
+// acceptView is the result of task accept
+type acceptView struct {
+	TaskID         string `json:"taskId"`
+	BidID          string `json:"bidId"`
+	Status         string `json:"status"`
+	IdempotencyKey string `json:"idempotencyKey"`
+	Replayed       bool   `json:"replayed"`
+}
+
+func (a acceptView) columns() []string {
+	return []string{"TASK", "BID", "STATUS", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (a acceptView) rows() [][]string {
+	return [][]string{{a.TaskID, a.BidID, a.Status, a.IdempotencyKey, strconv.FormatBool(a.Replayed)}}
+}
+
+// healthView is the result of health
+type healthView struct {
+	APIURL    string `json:"apiUrl"`
+	Reachable bool   `json:"reachable"`
+	Status    string `json:"status"`
+	Version   string `json:"version"`
+	Timestamp string `json:"timestamp"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (h healthView) columns() []string {
+	return []string{"API URL", "REACHABLE", "STATUS", "VERSION", "ERROR"}
+}
+
+func (h healthView) rows() [][]string {
+	return [][]string{{h.APIURL, strconv.FormatBool(h.Reachable), h.Status, h.Version, h.Error}}
+}
+
+// taskBidView is a bid in task show
+type taskBidView struct {
+	ID             string   `json:"id"`
+	AgentID        string   `json:"agentId"`
+	Amount         float64  `json:"amount"`
+	Message        string   `json:"message"`
+	Status         string   `json:"status"`
+	EstimatedHours int      `json:"estimatedHours,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
+	Reputation     *float64 `json:"reputation"` // null when unknown
+	CreatedAt      string   `json:"createdAt"`
+}
+
+// escrowView is the escrow state of a task
+type escrowView struct {
+	Held             bool    `json:"held"`
+	Amount           float64 `json:"amount"`
+	ReleaseScheduled bool    `json:"releaseScheduled"`
+	ReleasedAt       string  `json:"releasedAt,omitempty"`
+	TransactionHash  string  `json:"transactionHash,omitempty"`
+}
+
+func newEscrowView(e *gigclaw.EscrowStatus) *escrowView {
+	if e == nil {
+		return nil
+	}
+	v := &escrowView{
+		// The API reports unfunded tasks as held; only an accepted bid locks funds
+		Held:             e.Held && e.Amount > 0,
+		Amount:           e.Amount,
+		ReleaseScheduled: e.ReleaseScheduled,
+		TransactionHash:  e.TransactionHash,
+	}
+	if !e.ReleasedAt.IsZero() {
+		v.ReleasedAt = e.ReleasedAt.UTC().Format(time.RFC3339)
+	}
+	return v
+}
+
+// transactionView is a verified on-chain transaction
+type transactionView struct {
+	Signature   string `json:"signature"`
+	Status      string `json:"status"`
+	Failed      bool   `json:"failed"`
+	ExplorerURL string `json:"explorerUrl"`
+}
+
+func newTransactionView(t *gigclaw.TransactionStatus) *transactionView {
+	if t == nil {
+		return nil
+	}
+	explorer := t.Explorer
+	if explorer == "" {
+		explorer = explorerURL(t.Signature)
+	}
+	return &transactionView{Signature: t.Signature, Status: t.Status, Failed: t.Failed(), ExplorerURL: explorer}
+}
+
+// taskDetailView is the result of task show
+type taskDetailView struct {
+	taskView
+	PosterID      string           `json:"posterId"`
+	AssignedAgent string           `json:"assignedAgent"`
+	DeliveryURL   string           `json:"deliveryUrl"`
+	Bids          []taskBidView    `json:"bids"`
+	Escrow        *escrowView      `json:"escrow"`           // null when unavailable
+	Transaction   *transactionView `json:"transaction"`      // null when off-chain
+	Team          *teamView        `json:"team,omitempty"`   // split into sub-tasks with task team
+	PartOf        string           `json:"partOf,omitempty"` // the team task this sub-task was split from
+}
+
+// Table and CSV output list the bids; JSON and YAML carry the full view
+func (d taskDetailView) columns() []string {
+	return []string{"TASK", "BID", "AGENT", "AMOUNT", "REPUTATION", "STATUS", "MESSAGE"}
+}
+
+func (d taskDetailView) rows() [][]string {
+	rows := make([][]string, 0, len(d.Bids))
+	for _, b := range d.Bids {
+		rep := ""
+		if b.Reputation != nil {
+			rep = formatAmount(*b.Reputation)
+		}
+		rows = append(rows, []string{d.ID, b.ID, b.AgentID, formatAmount(b.Amount), rep, b.Status, b.Message})
+	}
+	return rows
+}
+
+// eventView is the output schema of one streamed event
+type eventView struct {
+	Time    string          `json:"time"`
+	Type    string          `json:"type"`
+	TaskID  string          `json:"taskId"`
+	Summary string          `json:"summary"`
+	Data    json.RawMessage `json:"data"`
+}
+
+var eventColumns = []string{"TIME", "TYPE", "TASK", "SUMMARY"}
+
+func newEventView(ev gigclaw.Event) eventView {
+	data := ev.Data
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+	return eventView{
+		Time:    ev.Timestamp.UTC().Format(time.RFC3339),
+		Type:    ev.Type,
+		TaskID:  ev.TaskID,
+		Summary: describeEvent(ev),
+		Data:    data,
+	}
+}
+
+func (v eventView) columns() []string { return eventColumns }
+
+func (v eventView) rows() [][]string {
+	return [][]string{{v.Time, v.Type, v.TaskID, v.Summary}}
+}
+
+// formatAmount prints an amount without trailing zeros
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatTime prints a time as RFC 3339 in UTC, or "" when zero
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// disputeView is a dispute
+type disputeView struct {
+	ID               string `json:"id"`
+	TaskID           string `json:"taskId"`
+	Status           string `json:"status"`
+	InitiatorID      string `json:"initiatorId"`
+	RespondentID     string `json:"respondentId"`
+	Reason           string `json:"reason"`
+	Resolution       string `json:"resolution"`
+	ArbitratorID     string `json:"arbitratorId"`
+	ResolutionReason string `json:"resolutionReason"`
+	CreatedAt        string `json:"createdAt"`
+	ResolvedAt       string `json:"resolvedAt"`
+	EvidenceCount    int    `json:"evidenceCount"`
+}
+
+func newDisputeView(d gigclaw.Dispute) disputeView {
+	return disputeView{
+		ID:               d.ID,
+		TaskID:           d.TaskID,
+		Status:           d.Status,
+		InitiatorID:      d.InitiatorID,
+		RespondentID:     d.RespondentID,
+		Reason:           d.Reason,
+		Resolution:       d.Resolution,
+		ArbitratorID:     d.ArbitratorID,
+		ResolutionReason: d.ResolutionReason,
+		CreatedAt:        formatTime(d.CreatedAt.Time),
+		ResolvedAt:       formatTime(d.ResolvedAt.Time),
+		EvidenceCount:    len(d.Evidence),
+	}
+}
+
+var disputeColumns = []string{"ID", "TASK", "STATUS", "INITIATOR", "RESPONDENT", "RESOLUTION", "OPENED", "EVIDENCE"}
+
+func (d disputeView) row() []string {
+	return []string{d.ID, d.TaskID, d.Status, d.InitiatorID, d.RespondentID, d.Resolution, d.CreatedAt, strconv.Itoa(d.EvidenceCount)}
+}
+
+func (d disputeView) columns() []string { return disputeColumns }
+func (d disputeView) rows() [][]string  { return [][]string{d.row()} }
+
+// disputeListView is a list of disputes
+type disputeListView []disputeView
+
+func (l disputeListView) columns() []string { return disputeColumns }
+
+func (l disputeListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, d := range l {
+		rows = append(rows, d.row())
+	}
+	return rows
+}
+
+// evidenceView is a piece of evidence in a dispute
+type evidenceView struct {
+	PartyID     string `json:"partyId"`
+	Type        string `json:"type"`
+	Content     string `json:"content"`
+	SubmittedAt string `json:"submittedAt"`
+}
+
+// disputeEventView is an entry in a dispute's timeline
+type disputeEventView struct {
+	Time    string `json:"time"`
+	Event   string `json:"event"` // opened, evidence or resolved
+	PartyID string `json:"partyId"`
+	Detail  string `json:"detail"`
+}
+
+// disputeDetailView is the result of dispute show
+type disputeDetailView struct {
+	disputeView
+	Evidence []evidenceView     `json:"evidence"`
+	Timeline []disputeEventView `json:"timeline"`
+}
+
+func newDisputeDetailView(d gigclaw.Dispute) disputeDetailView {
+	v := disputeDetailView{
+		disputeView: newDisputeView(d),
+		Evidence:    make([]evidenceView, 0, len(d.Evidence)),
+		Timeline:    []disputeEventView{},
+	}
+	for _, e := range d.Evidence {
+		v.Evidence = append(v.Evidence, evidenceView{e.PartyID, e.Type, e.Content, formatTime(e.SubmittedAt.Time)})
+	}
+	for _, e := range d.Timeline() {
+		v.Timeline = append(v.Timeline, disputeEventView{formatTime(e.Time), e.Kind, e.PartyID, e.Detail})
+	}
+	return v
+}
+
+// Table and CSV output list the timeline; JSON and YAML carry the full view
+func (d disputeDetailView) columns() []string {
+	return []string{"DISPUTE", "TIME", "EVENT", "PARTY", "DETAIL"}
+}
+
+func (d disputeDetailView) rows() [][]string {
+	rows := make([][]string, 0, len(d.Timeline))
+	for _, e := range d.Timeline {
+		rows = append(rows, []string{d.ID, e.Time, e.Event, e.PartyID, e.Detail})
+	}
+	return rows
+}
+
+// termsView is the terms of a negotiation offer
+type termsView struct {
+	Price           float64              `json:"price"` // 0 when unknown
+	Timeline        string               `json:"timeline"`
+	Milestones      []milestoneView      `json:"milestones"`
+	PaymentSchedule *paymentScheduleView `json:"paymentSchedule"` // null when unset
+}
+
+// milestoneView is a part of the work with its own deadline and payment
+type milestoneView struct {
+	Description string  `json:"description"`
+	Deadline    string  `json:"deadline"`
+	Payment     float64 `json:"payment"`
+}
+
+// paymentScheduleView splits a payment into percentages
+type paymentScheduleView struct {
+	Upfront        float64 `json:"upfront"`
+	OnCompletion   float64 `json:"onCompletion"`
+	OnVerification float64 `json:"onVerification"`
+}
+
+func newTermsView(t gigclaw.NegotiationTerms) termsView {
+	v := termsView{Price: t.Price, Timeline: t.Timeline, Milestones: make([]milestoneView, 0, len(t.Milestones))}
+	for _, m := range t.Milestones {
+		v.Milestones = append(v.Milestones, milestoneView{m.Description, m.Deadline, m.Payment})
+	}
+	if s := t.PaymentSchedule; s != nil {
+		v.PaymentSchedule = &paymentScheduleView{s.Upfront, s.OnCompletion, s.OnVerification}
+	}
+	return v
+}
+
+// negotiationView is a negotiation
+type negotiationView struct {
+	ID           string    `json:"id"`
+	TaskID       string    `json:"taskId"`
+	Status       string    `json:"status"`
+	PosterID     string    `json:"posterId"`
+	WorkerID     string    `json:"workerId"`
+	Terms        termsView `json:"terms"` // the terms on the table
+	MessageCount int       `json:"messageCount"`
+	StartedAt    string    `json:"startedAt"`
+	LastActivity string    `json:"lastActivity"`
+	CancelReason string    `json:"cancelReason,omitempty"`
+}
+
+func newNegotiationView(n gigclaw.Negotiation) negotiationView {
+	return negotiationView{
+		ID:           n.ID,
+		TaskID:       n.TaskID,
+		Status:       n.Status,
+		PosterID:     n.PosterID,
+		WorkerID:     n.WorkerID,
+		Terms:        newTermsView(n.ProposedTerms),
+		MessageCount: len(n.Messages),
+		StartedAt:    formatTime(n.StartedAt.Time),
+		LastActivity: formatTime(n.LastActivity.Time),
+		CancelReason: n.CancelReason,
+	}
+}
+
+var negotiationColumns = []string{"ID", "TASK", "STATUS", "POSTER", "WORKER", "PRICE", "TIMELINE", "MESSAGES", "LAST ACTIVITY"}
+
+func (n negotiationView) row() []string {
+	return []string{
+		n.ID, n.TaskID, n.Status, n.PosterID, n.WorkerID, formatAmount(n.Terms.Price), n.Terms.Timeline,
+		strconv.Itoa(n.MessageCount), n.LastActivity,
+	}
+}
+
+func (n negotiationView) columns() []string { return negotiationColumns }
+func (n negotiationView) rows() [][]string  { return [][]string{n.row()} }
+
+// negotiationListView is a list of negotiations
+type negotiationListView []negotiationView
+
+func (l negotiationListView) columns() []string { return negotiationColumns }
+
+func (l negotiationListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, n := range l {
+		rows = append(rows, n.row())
+	}
+	return rows
+}
+
+// offerView is an entry in a negotiation's offer history
+type offerView struct {
+	Time        string    `json:"time"`
+	FromAgentID string    `json:"fromAgentId"`
+	Message     string    `json:"message"`
+	Terms       termsView `json:"terms"` // the full terms after this offer
+}
+
+// negotiationMessageView is a message in a negotiation
+type negotiationMessageView struct {
+	Time        string     `json:"time"`
+	FromAgentID string     `json:"fromAgentId"`
+	Message     string     `json:"message"`
+	Sentiment   string     `json:"sentiment"`
+	Proposed    *termsView `json:"proposed"` // null when the message carries no offer
+}
+
+// analysisView is the server's analysis of a negotiation
+type analysisView struct {
+	Sentiment          string     `json:"sentiment"`
+	LikelihoodOfDeal   float64    `json:"likelihoodOfDeal"` // 0-1
+	NextBestAction     string     `json:"nextBestAction"`
+	Style              string     `json:"style"`
+	RecommendedCounter *termsView `json:"recommendedCounter"` // null when none
+}
+
+func newAnalysisView(a *gigclaw.NegotiationAnalysis) *analysisView {
+	if a == nil {
+		return nil
+	}
+	v := &analysisView{
+		Sentiment:        a.Sentiment,
+		LikelihoodOfDeal: a.LikelihoodOfDeal,
+		NextBestAction:   a.NextBestAction,
+		Style:            a.NegotiationStyle,
+	}
+	if a.RecommendedCounter != nil {
+		terms := newTermsView(*a.RecommendedCounter)
+		v.RecommendedCounter = &terms
+	}
+	return v
+}
+
+// negotiationDetailView is the result of negotiate show
+type negotiationDetailView struct {
+	negotiationView
+	Offers   []offerView              `json:"offers"`
+	Messages []negotiationMessageView `json:"messages"`
+	Analysis *analysisView            `json:"analysis"` // null when unavailable
+}
+
+func newNegotiationDetailView(n gigclaw.Negotiation, analysis *gigclaw.NegotiationAnalysis) negotiationDetailView {
+	v := negotiationDetailView{
+		negotiationView: newNegotiationView(n),
+		Offers:          []offerView{},
+		Messages:        make([]negotiationMessageView, 0, len(n.Messages)),
+		Analysis:        newAnalysisView(analysis),
+	}
+	for _, o := range n.Offers() {
+		v.Offers = append(v.Offers, offerView{formatTime(o.Time), o.FromAgentID, o.Message, newTermsView(o.Terms)})
+	}
+	for _, m := range n.Messages {
+		mv := negotiationMessageView{Time: formatTime(m.Timestamp.Time), FromAgentID: m.FromAgentID, Message: m.Message, Sentiment: m.Sentiment}
+		if m.ProposedTerms != nil {
+			terms := newTermsView(*m.ProposedTerms)
+			mv.Proposed = &terms
+		}
+		v.Messages = append(v.Messages, mv)
+	}
+	return v
+}
+
+// Table and CSV output list the offers; JSON and YAML carry the full view
+func (d negotiationDetailView) columns() []string {
+	return []string{"NEGOTIATION", "TIME", "FROM", "PRICE", "TIMELINE", "MESSAGE"}
+}
+
+func (d negotiationDetailView) rows() [][]string {
+	rows := make([][]string, 0, len(d.Offers))
+	for _, o := range d.Offers {
+		rows = append(rows, []string{d.ID, o.Time, o.FromAgentID, formatAmount(o.Terms.Price), o.Terms.Timeline, o.Message})
+	}
+	return rows
+}
+
+// webhookView is a webhook subscription
+type webhookView struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Events        []string `json:"events"`
+	Active        bool     `json:"active"`
+	FailureCount  int      `json:"failureCount"`
+	CreatedAt     string   `json:"createdAt"`
+	LastDelivered string   `json:"lastDelivered"`
+	SecretStored  bool     `json:"secretStored"` // the signing secret is in ~/.gigclaw/webhooks.json
+}
+
+func newWebhookView(w gigclaw.Webhook, secretStored bool) webhookView {
+	return webhookView{
+		ID:            w.ID,
+		URL:           w.URL,
+		Events:        w.Events,
+		Active:        w.Active,
+		FailureCount:  w.FailureCount,
+		CreatedAt:     formatTime(w.CreatedAt.Time),
+		LastDelivered: formatTime(w.LastDelivered.Time),
+		SecretStored:  secretStored,
+	}
+}
+
+var webhookColumns = []string{"ID", "URL", "EVENTS", "ACTIVE", "FAILURES", "LAST DELIVERED", "SECRET STORED"}
+
+func (w webhookView) row() []string {
+	return []string{
+		w.ID, w.URL, strings.Join(w.Events, " "), strconv.FormatBool(w.Active), strconv.Itoa(w.FailureCount),
+		w.LastDelivered, strconv.FormatBool(w.SecretStored),
+	}
+}
+
+// webhookListView is a list of webhooks
+type webhookListView []webhookView
+
+func (l webhookListView) columns() []string { return webhookColumns }
+
+func (l webhookListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, w := range l {
+		rows = append(rows, w.row())
+	}
+	return rows
+}
+
+// webhookRegistrationView is a newly registered webhook
+type webhookRegistrationView struct {
+	ID             string   `json:"id"`
+	AgentID        string   `json:"agentId"`
+	URL            string   `json:"url"`
+	Events         []string `json:"events"`
+	Secret         string   `json:"secret,omitempty"` // --show-secret only
+	SecretStored   bool     `json:"secretStored"`
+	IdempotencyKey string   `json:"idempotencyKey"`
+	Replayed       bool     `json:"replayed"`
+}
+
+func (r webhookRegistrationView) columns() []string {
+	return []string{"ID", "AGENT", "URL", "EVENTS", "SECRET", "SECRET STORED", "IDEMPOTENCY KEY", "REPLAYED"}
+}
+
+func (r webhookRegistrationView) rows() [][]string {
+	return [][]string{{
+		r.ID, r.AgentID, r.URL, strings.Join(r.Events, " "), r.Secret, strconv.FormatBool(r.SecretStored),
+		r.IdempotencyKey, strconv.FormatBool(r.Replayed),
+	}}
+}
+
+// webhookDeliveryView is a delivery attempt to a webhook
+type webhookDeliveryView struct {
+	ID           string `json:"id"`
+	WebhookID    string `json:"webhookId"`
+	Event        string `json:"event"`
+	URL          string `json:"url"`
+	Status       string `json:"status"`
+	StatusCode   int    `json:"statusCode,omitempty"`
+	Retries      int    `json:"retries"`
+	Time         string `json:"time"`
+	ResponseBody string `json:"responseBody,omitempty"`
+}
+
+func newWebhookDeliveryView(d gigclaw.WebhookDelivery) webhookDeliveryView {
+	return webhookDeliveryView{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		Event:        d.Event,
+		URL:          d.URL,
+		Status:       d.Status,
+		StatusCode:   d.StatusCode,
+		Retries:      d.RetryCount,
+		Time:         formatTime(d.Timestamp.Time),
+		ResponseBody: d.ResponseBody,
+	}
+}
+
+// webhookDeliveryListView is a list of webhook deliveries
+type webhookDeliveryListView []webhookDeliveryView
+
+func (l webhookDeliveryListView) columns() []string {
+	return []string{"TIME", "DELIVERY", "WEBHOOK", "EVENT", "STATUS", "HTTP", "RETRIES"}
+}
+
+func (l webhookDeliveryListView) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, d := range l {
+		code := ""
+		if d.StatusCode != 0 {
+			code = strconv.Itoa(d.StatusCode)
+		}
+		rows = append(rows, []string{d.Time, d.ID, d.WebhookID, d.Event, d.Status, code, strconv.Itoa(d.Retries)})
+	}
+	return rows
+}
+
+// webhookActionView is the result of webhook test, enable, disable and
+// delete
+type webhookActionView struct {
+	ID           string `json:"id"`
+	Action       string `json:"action"`
+	Active       *bool  `json:"active,omitempty"`  // enable and disable only
+	Success      *bool  `json:"success,omitempty"` // test only
+	StatusCode   int    `json:"statusCode,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+func (a webhookActionView) columns() []string {
+	return []string{"ID", "ACTION", "ACTIVE", "SUCCESS", "HTTP", "MESSAGE"}
+}
+
+func (a webhookActionView) rows() [][]string {
+	optional := func(b *bool) string {
+		if b == nil {
+			return ""
+		}
+		return strconv.FormatBool(*b)
+	}
+	code := ""
+	if a.StatusCode != 0 {
+		code = strconv.Itoa(a.StatusCode)
+	}
+	return [][]string{{a.ID, a.Action, optional(a.Active), optional(a.Success), code, a.Message}}
+}
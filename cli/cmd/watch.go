@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream real-time marketplace events",
+	Long: `Stream task, bid, payment and dispute events as they happen.
+
+Events arrive over the API's WebSocket endpoint. A dropped connection is
+re-established automatically and every subscription renewed.
+
+Terminals get one readable line per event. With --output json each event
+is printed as a single JSON line (NDJSON), ready for jq or other tools.
+
+Channels:
+  all                 Every event (default)
+  tasks:new           New tasks
+  tasks:updates       Accepted bids, completed and bulk-updated tasks
+  bids:updates        Bids placed on any task
+  payments:updates    Released payments
+  disputes:updates    Opened and resolved disputes
+  standups:new        Agent standups
+  voting:updates      Governance votes
+  task:<id>           Bids on one task (--task)
+  agent:<id>          Won bids and payments for one agent (--agent)`,
+	Example: `  gigclaw watch
+  gigclaw watch --channel tasks:new --channel bids:updates
+  gigclaw watch --task 7f3a --type new_bid
+  gigclaw watch -o json | jq 'select(.type == "task_created") | .data.budget'`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+var (
+	watchChannels      []string
+	watchTasks         []string
+	watchAgentID       string
+	watchTypes         []string
+	watchCount         int
+	watchMaxReconnects int
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().StringArrayVar(&watchChannels, "channel", []string{}, "Channel to subscribe to (can specify multiple; default all)")
+	watchCmd.Flags().StringArrayVar(&watchTasks, "task", []string{}, "Watch bids on this task (can specify multiple)")
+	watchCmd.Flags().StringVar(&watchAgentID, "agent", "", "Identify as this agent and watch its bids and payments")
+	watchCmd.Flags().StringArrayVarP(&watchTypes, "type", "t", []string{}, "Only show events of this type, e.g. task_created (can specify multiple)")
+	watchCmd.Flags().IntVarP(&watchCount, "count", "n", 0, "Exit after this many events (0 = run until interrupted)")
+	watchCmd.Flags().IntVar(&watchMaxReconnects, "max-reconnects", 0, "Give up after this many failed reconnects in a row (0 = never)")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchCount < 0 {
+		return fmt.Errorf("--count must not be negative")
+	}
+
+	channels := append([]string{}, watchChannels...)
+	for _, id := range watchTasks {
+		channels = append(channels, gigclaw.TaskChannel(id))
+	}
+	if len(channels) == 0 && watchAgentID == "" {
+		channels = []string{gigclaw.ChannelAll}
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	connected := false
+	stream, err := client.Subscribe(ctx, gigclaw.StreamOptions{
+		Channels:      channels,
+		AgentID:       watchAgentID,
+		MaxReconnects: watchMaxReconnects,
+		OnStateChange: func(state gigclaw.StreamState, err error) {
+			switch state {
+			case gigclaw.StreamConnected:
+				if connected {
+					logger.Info("Reconnected")
+				}
+				connected = true
+			case gigclaw.StreamReconnecting:
+				logger.Warning(fmt.Sprintf("Connection lost (%v); reconnecting", err))
+			}
+		},
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+	defer stream.Close()
+
+	if decorated() {
+		fmt.Println()
+		colorPrimary.Println("  📡 Watching GigClaw events")
+		colorLabel.Printf("  %-15s ", "Channels:")
+		colorValue.Println(strings.Join(stream.Channels(), ", "))
+		if watchAgentID != "" {
+			colorLabel.Printf("  %-15s ", "Agent:")
+			colorValue.Println(watchAgentID)
+		}
+		colorDim.Println("  Press Ctrl+C to stop")
+		fmt.Println()
+	}
+
+	printer := newEventPrinter(outputMode())
+	seen := 0
+	for ev := range stream.Events() {
+		if len(watchTypes) > 0 && !containsFold(watchTypes, ev.Type) {
+			continue
+		}
+		if err := printer.print(ev); err != nil {
+			return err
+		}
+		seen++
+		if watchCount > 0 && seen >= watchCount {
+			return nil
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return HandleAPIError(err)
+	}
+	return nil
+}
+
+// eventPrinter writes events one at a time in the active output format
+type eventPrinter struct {
+	mode   string
+	csv    *csv.Writer
+	json   *json.Encoder
+	header bool
+}
+
+func newEventPrinter(mode string) *eventPrinter {
+	p := &eventPrinter{mode: mode}
+	switch mode {
+	case outputCSV:
+		p.csv = csv.NewWriter(os.Stdout)
+	case outputJSON:
+		p.json = json.NewEncoder(os.Stdout)
+		p.json.SetEscapeHTML(false)
+	}
+	return p
+}
+
+func (p *eventPrinter) print(ev gigclaw.Event) error {
+	view := newEventView(ev)
+	switch p.mode {
+	case outputJSON:
+		// One compact object per line
+		return p.json.Encode(view)
+	case outputCSV:
+		if !p.header {
+			p.csv.Write(view.columns())
+			p.header = true
+		}
+		p.csv.WriteAll(view.rows())
+		return p.csv.Error()
+	case outputYAML:
+		fmt.Println("---")
+		return renderTo(os.Stdout, outputYAML, view, nil)
+	case outputTemplate:
+		return renderTo(os.Stdout, outputTemplate, view, nil)
+	default:
+		// Text and table modes share the line format; color is dropped
+		// automatically when stdout is not a terminal
+		colorDim.Printf("%s  ", ev.Timestamp.Local().Format("15:04:05"))
+		eventColor(ev.Type).Printf("%-18s ", ev.Type)
+		if ev.TaskID != "" {
+			colorValue.Printf("%s  ", ev.TaskID)
+		}
+		fmt.Println(view.Summary)
+		return nil
+	}
+}
+
+// eventColor groups event types by outcome
+func eventColor(eventType string) *color.Color {
+	switch eventType {
+	case gigclaw.EventTaskCreated, gigclaw.EventNewBid, gigclaw.EventBidPlaced,
+		gigclaw.WebhookTaskCreated, gigclaw.WebhookTaskBid:
+		return colorStatusPosted
+	case gigclaw.EventBidAccepted, gigclaw.EventBidWon, gigclaw.EventStatusChange,
+		gigclaw.WebhookTaskAssigned:
+		return colorStatusProgress
+	case gigclaw.EventTaskCompleted, gigclaw.EventPaymentReleased, gigclaw.EventPaymentReceived,
+		gigclaw.WebhookTaskCompleted, gigclaw.WebhookTaskVerified, gigclaw.WebhookPaymentReleased:
+		return colorStatusCompleted
+	case gigclaw.EventDisputeInitiated, gigclaw.EventTaskDeleted, gigclaw.WebhookTaskCancelled:
+		return colorWarning
+	default:
+		return colorLabel
+	}
+}
+
+// describeEvent summarises an event payload in one line
+func describeEvent(ev gigclaw.Event) string {
+	var data struct {
+		Title         string   `json:"title"`
+		AgentID       string   `json:"agentId"`
+		Amount        *float64 `json:"amount"`
+		ProposedPrice *float64 `json:"proposedPrice"`
+		Budget        *float64 `json:"budget"`
+		Currency      string   `json:"currency"`
+		Status        string   `json:"status"`
+		Reason        string   `json:"reason"`
+	}
+	ev.Decode(&data)
+
+	var parts []string
+	if data.Title != "" {
+		parts = append(parts, fmt.Sprintf("%q", data.Title))
+	}
+	if data.AgentID != "" {
+		parts = append(parts, "agent "+data.AgentID)
+	}
+
+	amount := ev.Amount
+	for _, v := range []*float64{data.Amount, data.ProposedPrice, data.Budget} {
+		if amount == 0 && v != nil {
+			amount = *v
+		}
+	}
+	if amount != 0 {
+		parts = append(parts, strings.TrimSpace(formatAmount(amount)+" "+data.Currency))
+	}
+
+	switch {
+	case ev.OldStatus != "" || ev.NewStatus != "":
+		parts = append(parts, ev.OldStatus+" → "+ev.NewStatus)
+	case data.Status != "":
+		parts = append(parts, data.Status)
+	}
+	if reason := firstNonEmpty(ev.Reason, data.Reason); reason != "" {
+		parts = append(parts, "reason: "+reason)
+	}
+	return strings.Join(parts, "  ")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,529 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Receive marketplace events over HTTP",
+	Long: `Register, inspect and receive webhooks.
+
+A webhook makes the API POST marketplace events to a URL as they happen,
+signed with a secret so the receiver can tell them from forgeries. The
+secret is only shown once, at registration; gigclaw keeps it in
+~/.gigclaw/webhooks.json so that webhook listen can verify deliveries.
+
+Events:
+  task.created         A task was posted
+  task.bid             A bid was placed
+  task.assigned        A bid was accepted
+  task.completed       Work was submitted
+  task.verified        Work was verified
+  task.cancelled       A task was cancelled or expired
+  payment.released     Escrowed funds were paid out
+  reputation.updated   An agent's reputation changed
+
+Webhooks belong to an agent: pass --as, or set agent-id in the config file.`,
+}
+
+var webhookRegisterCmd = &cobra.Command{
+	Use:   "register <url>",
+	Short: "Register a webhook",
+	Long: `Register a URL to receive events. Without --event, the webhook receives
+every event.
+
+The signing secret is saved to ~/.gigclaw/webhooks.json; pass --show-secret
+to also print it, e.g. to configure a receiver on another machine.`,
+	Example: `  gigclaw webhook register https://example.com/gigclaw --event task.created --event task.bid
+  gigclaw webhook register https://example.com/gigclaw --show-secret -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWebhookRegister,
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your webhooks",
+	Args:  cobra.NoArgs,
+	RunE:  runWebhookList,
+}
+
+var webhookLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show recent webhook deliveries",
+	Long: `Show the last 50 deliveries to your webhooks, newest first.
+
+Each delivery is attempted up to three times. After ten failed deliveries
+in a row, the API pauses the webhook; resume it with webhook enable.`,
+	Example: `  gigclaw webhook logs --failed
+  gigclaw webhook logs --webhook 3b1c... --event task.bid`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookLogs,
+}
+
+var webhookTestCmd = &cobra.Command{
+	Use:   "test <webhook-id>",
+	Short: "Send a test event to a webhook",
+	Long: `Send a signed test event to a webhook and report how its URL answered.
+
+Exits non-zero when the URL could not be reached or did not answer with a
+2xx status.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWebhookTest,
+}
+
+var webhookEnableCmd = &cobra.Command{
+	Use:   "enable <webhook-id>",
+	Short: "Resume a paused webhook",
+	Long:  `Resume a paused webhook, resetting its failure count.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWebhookSetActive(true),
+}
+
+var webhookDisableCmd = &cobra.Command{
+	Use:   "disable <webhook-id>",
+	Short: "Pause a webhook",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWebhookSetActive(false),
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete <webhook-id>",
+	Short: "Delete a webhook",
+	Long:  `Delete a webhook and forget its secret. Without a terminal, pass --yes.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWebhookDelete,
+}
+
+var (
+	webhookAs string
+
+	webhookRegisterEvents     []string
+	webhookRegisterShowSecret bool
+	webhookRegisterIdemKey    string
+
+	webhookLogsWebhook string
+	webhookLogsEvent   string
+	webhookLogsFailed  bool
+
+	webhookDeleteYes bool
+)
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(webhookRegisterCmd)
+	webhookCmd.AddCommand(webhookListCmd)
+	webhookCmd.AddCommand(webhookLogsCmd)
+	webhookCmd.AddCommand(webhookTestCmd)
+	webhookCmd.AddCommand(webhookEnableCmd)
+	webhookCmd.AddCommand(webhookDisableCmd)
+	webhookCmd.AddCommand(webhookDeleteCmd)
+
+	webhookCmd.PersistentFlags().StringVar(&webhookAs, "as", "", "Your agent ID (default agent-id from the config file)")
+
+	webhookRegisterCmd.Flags().StringArrayVarP(&webhookRegisterEvents, "event", "e", []string{}, "Event to receive (can specify multiple; default all)")
+	webhookRegisterCmd.Flags().BoolVar(&webhookRegisterShowSecret, "show-secret", false, "Print the signing secret")
+	webhookRegisterCmd.Flags().StringVar(&webhookRegisterIdemKey, "idempotency-key", "", "Idempotency key; re-running with the same key never registers twice")
+
+	webhookLogsCmd.Flags().StringVar(&webhookLogsWebhook, "webhook", "", "Only deliveries to this webhook")
+	webhookLogsCmd.Flags().StringVarP(&webhookLogsEvent, "event", "e", "", "Only deliveries of this event")
+	webhookLogsCmd.Flags().BoolVar(&webhookLogsFailed, "failed", false, "Only failed deliveries")
+
+	webhookDeleteCmd.Flags().BoolVarP(&webhookDeleteYes, "yes", "y", false, "Delete without asking for confirmation")
+}
+
+func runWebhookRegister(cmd *cobra.Command, args []string) error {
+	url := args[0]
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("invalid URL %q: must start with http:// or https://", url)
+	}
+
+	events := gigclaw.WebhookEvents
+	if len(webhookRegisterEvents) > 0 {
+		events = nil
+		for _, e := range webhookRegisterEvents {
+			e = strings.ToLower(e)
+			if !containsFold(gigclaw.WebhookEvents, e) {
+				return fmt.Errorf("invalid --event %q: must be one of %s", e, strings.Join(gigclaw.WebhookEvents, ", "))
+			}
+			if !containsFold(events, e) {
+				events = append(events, e)
+			}
+		}
+	}
+
+	agent, err := actingAgent(webhookAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	store, err := openWebhookStore()
+	if err != nil {
+		return err
+	}
+
+	var reg *gigclaw.WebhookRegistration
+	saved := false
+	res, err := runJournaled("webhook register", webhookRegisterIdemKey, []interface{}{agent, url, events}, func(key string) (string, error) {
+		var err error
+		reg, err = client.RegisterWebhook(cmd.Context(), agent, url, events, gigclaw.IdempotencyKey(key))
+		if err != nil {
+			return "", err
+		}
+		// Keep the secret before anything else can fail: it is never shown again
+		store.Webhooks[reg.WebhookID] = &storedWebhook{AgentID: agent, URL: reg.URL, Secret: reg.Secret, CreatedAt: time.Now()}
+		if err := store.save(); err != nil {
+			logger.Warning(fmt.Sprintf("Failed to save the webhook secret: %v", err))
+		} else {
+			saved = true
+		}
+		return reg.WebhookID, nil
+	})
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := webhookRegistrationView{
+		ID:             res.Result,
+		AgentID:        agent,
+		URL:            url,
+		Events:         events,
+		IdempotencyKey: res.Key,
+		Replayed:       res.Replayed,
+	}
+	stored, inStore := store.Webhooks[res.Result]
+	view.SecretStored = inStore && (res.Replayed || saved)
+	if reg != nil {
+		view.URL, view.Events = reg.URL, reg.Events
+	}
+	switch {
+	case reg != nil && (webhookRegisterShowSecret || !saved):
+		// Never lose a secret that could not be stored
+		view.Secret = reg.Secret
+	case view.SecretStored && webhookRegisterShowSecret:
+		view.Secret = stored.Secret
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if view.Replayed {
+			colorWarning.Println("  Webhook already registered by an earlier run (idempotency key reused)")
+		} else {
+			colorSuccess.Println("  ✓ Webhook registered")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Webhook ID:")
+		colorHighlight.Println(view.ID)
+		colorLabel.Printf("  %-15s ", "Agent:")
+		colorValue.Println(view.AgentID)
+		colorLabel.Printf("  %-15s ", "URL:")
+		colorValue.Println(view.URL)
+		colorLabel.Printf("  %-15s ", "Events:")
+		colorValue.Println(strings.Join(view.Events, ", "))
+		colorLabel.Printf("  %-15s ", "Secret:")
+		switch {
+		case view.Secret != "":
+			colorHighlight.Println(view.Secret)
+		case view.SecretStored:
+			colorDim.Println("saved to " + store.path)
+		default:
+			colorWarning.Println("not available (shown only at registration)")
+		}
+		if !view.SecretStored && view.Secret != "" {
+			colorWarning.Println("\n  Copy the secret now: it could not be saved and will not be shown again.")
+		}
+		fmt.Println()
+		colorDim.Println("  Send a test event:     gigclaw webhook test " + view.ID)
+		colorDim.Println("  Receive events here:   gigclaw webhook listen --port 8080")
+		fmt.Println()
+	})
+}
+
+func runWebhookList(cmd *cobra.Command, args []string) error {
+	agent, err := actingAgent(webhookAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	hooks, err := client.ListWebhooks(cmd.Context(), agent)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	store, err := openWebhookStore()
+	if err != nil {
+		return err
+	}
+
+	view := make(webhookListView, 0, len(hooks))
+	for _, w := range hooks {
+		_, stored := store.Webhooks[w.ID]
+		view = append(view, newWebhookView(w, stored))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(hooks) == 0 {
+			colorWarning.Printf("  No webhooks registered for %s.\n", agent)
+			fmt.Println()
+			colorDim.Println("  Register one: gigclaw webhook register <url>")
+			fmt.Println()
+			return
+		}
+
+		colorLabel.Printf("  Found ")
+		colorHighlight.Printf("%d", len(hooks))
+		colorLabel.Printf(" webhook(s) for %s\n", agent)
+		fmt.Println()
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("ID")+"\t"+bold.Sprint("URL")+"\t"+bold.Sprint("EVENTS")+"\t"+
+			bold.Sprint("STATUS")+"\t"+bold.Sprint("FAILURES")+"\t"+bold.Sprint("LAST DELIVERED"))
+		for _, h := range hooks {
+			status := colorSuccess.Sprint("active")
+			if !h.Active {
+				status = colorWarning.Sprint("paused")
+			}
+			events := strings.Join(h.Events, ",")
+			if len(h.Events) == len(gigclaw.WebhookEvents) {
+				events = "all"
+			}
+			last := "never"
+			if !h.LastDelivered.IsZero() {
+				last = h.LastDelivered.Local().Format("Jan 02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				colorDim.Sprint(h.ID),
+				colorValue.Sprint(truncate(h.URL, 40)),
+				colorValue.Sprint(truncate(events, 30)),
+				status,
+				h.FailureCount,
+				colorDim.Sprint(last),
+			)
+		}
+		w.Flush()
+		fmt.Println()
+		for _, v := range view {
+			if !v.SecretStored {
+				colorDim.Println("  Some secrets are not stored here; webhook listen needs --secret to verify their deliveries.")
+				fmt.Println()
+				break
+			}
+		}
+	})
+}
+
+func runWebhookLogs(cmd *cobra.Command, args []string) error {
+	agent, err := actingAgent(webhookAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	logs, err := client.ListWebhookDeliveries(cmd.Context(), agent)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	var matched []gigclaw.WebhookDelivery
+	for _, d := range logs {
+		if (webhookLogsWebhook == "" || d.WebhookID == webhookLogsWebhook) &&
+			(webhookLogsEvent == "" || strings.EqualFold(d.Event, webhookLogsEvent)) &&
+			(!webhookLogsFailed || d.Status == "failed") {
+			matched = append(matched, d)
+		}
+	}
+
+	view := make(webhookDeliveryListView, 0, len(matched))
+	for _, d := range matched {
+		view = append(view, newWebhookDeliveryView(d))
+	}
+
+	return render(view, func() {
+		fmt.Println()
+		if len(view) == 0 {
+			colorWarning.Printf("  No webhook deliveries found for %s.\n", agent)
+			fmt.Println()
+			return
+		}
+
+		w := tabwriter.NewWriter(color.Output, 0, 0, 3, ' ', 0)
+		bold := color.New(color.FgHiWhite, color.Bold)
+		fmt.Fprintln(w, bold.Sprint("TIME")+"\t"+bold.Sprint("EVENT")+"\t"+bold.Sprint("WEBHOOK")+"\t"+
+			bold.Sprint("STATUS")+"\t"+bold.Sprint("HTTP")+"\t"+bold.Sprint("RESPONSE"))
+		for _, d := range matched {
+			code := "-"
+			if d.StatusCode != 0 {
+				code = fmt.Sprint(d.StatusCode)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				colorDim.Sprint(d.Timestamp.Local().Format("Jan 02 15:04:05")),
+				colorValue.Sprint(d.Event),
+				colorDim.Sprint(truncate(d.WebhookID, 12)),
+				formatStatus(d.Status),
+				code,
+				colorDim.Sprint(truncate(strings.Join(strings.Fields(d.ResponseBody), " "), 40)),
+			)
+		}
+		w.Flush()
+		fmt.Println()
+	})
+}
+
+func runWebhookTest(cmd *cobra.Command, args []string) error {
+	webhookID := args[0]
+
+	agent, err := actingAgent(webhookAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	result, err := client.TestWebhook(cmd.Context(), agent, webhookID)
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	view := webhookActionView{
+		ID:           webhookID,
+		Action:       "test",
+		Success:      &result.Success,
+		StatusCode:   result.StatusCode,
+		ResponseBody: result.ResponseBody,
+		Message:      firstNonEmpty(result.Error, result.Message),
+	}
+
+	err = render(view, func() {
+		fmt.Println()
+		if result.Success {
+			colorSuccess.Println("  ✓ Test event delivered")
+		} else {
+			colorWarning.Println("  ✗ Test event not delivered")
+		}
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Webhook ID:")
+		colorValue.Println(webhookID)
+		if result.StatusCode != 0 {
+			colorLabel.Printf("  %-15s ", "HTTP status:")
+			colorValue.Println(result.StatusCode)
+		}
+		if body := strings.TrimSpace(result.ResponseBody); body != "" {
+			colorLabel.Printf("  %-15s ", "Response:")
+			colorDim.Println(truncate(strings.Join(strings.Fields(body), " "), 60))
+		}
+		if result.Error != "" {
+			colorLabel.Printf("  %-15s ", "Error:")
+			colorWarning.Println(result.Error)
+		}
+		fmt.Println()
+	})
+	if err != nil || result.Success {
+		return err
+	}
+	return friendlyError(nil,
+		fmt.Sprintf("Webhook %s did not accept the test event", webhookID),
+		nil,
+		"Check that the URL is reachable from the GigClaw API",
+		"See the webhook's URL: gigclaw webhook list",
+		"Receive events on this machine: gigclaw webhook listen --port 8080")
+}
+
+// runWebhookSetActive returns the handler for webhook enable and disable
+func runWebhookSetActive(active bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		webhookID := args[0]
+
+		agent, err := actingAgent(webhookAs, "--as")
+		if err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return HandleAPIError(err)
+		}
+
+		if err := client.SetWebhookActive(cmd.Context(), agent, webhookID, active); err != nil {
+			return HandleAPIError(err)
+		}
+
+		view := webhookActionView{ID: webhookID, Action: "disable", Active: &active}
+		if active {
+			view.Action = "enable"
+		}
+		return render(view, func() {
+			fmt.Println()
+			if active {
+				colorSuccess.Printf("  ✓ Webhook %s resumed\n", webhookID)
+			} else {
+				colorSuccess.Printf("  ✓ Webhook %s paused\n", webhookID)
+			}
+			fmt.Println()
+		})
+	}
+}
+
+func runWebhookDelete(cmd *cobra.Command, args []string) error {
+	webhookID := args[0]
+
+	agent, err := actingAgent(webhookAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	if err := confirmAction(fmt.Sprintf("Delete webhook %s?", webhookID), webhookDeleteYes); err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	if err := client.DeleteWebhook(cmd.Context(), agent, webhookID); err != nil {
+		return HandleAPIError(err)
+	}
+
+	store, err := openWebhookStore()
+	if err != nil {
+		logger.Warning(fmt.Sprintf("Failed to forget the webhook secret: %v", err))
+	} else if _, ok := store.Webhooks[webhookID]; ok {
+		delete(store.Webhooks, webhookID)
+		if err := store.save(); err != nil {
+			logger.Warning(fmt.Sprintf("Failed to forget the webhook secret: %v", err))
+		}
+	}
+
+	view := webhookActionView{ID: webhookID, Action: "delete"}
+	return render(view, func() {
+		fmt.Println()
+		colorSuccess.Printf("  ✓ Webhook %s deleted\n", webhookID)
+		fmt.Println()
+	})
+}
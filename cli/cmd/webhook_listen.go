@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/OmaClaw/gigclaw/cli/gigclaw/webhook"
+	"github.com/spf13/cobra"
+)
+
+var webhookListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Receive webhook deliveries on this machine",
+	Long: `Run an HTTP server that receives webhook deliveries, verifies their
+signatures and prints each event.
+
+Deliveries are verified against the secrets in ~/.gigclaw/webhooks.json,
+--secret, or the GIGCLAW_WEBHOOK_SECRET environment variable. Deliveries
+with a missing or wrong signature are rejected with 401 and never printed,
+forwarded or passed to hooks.
+
+--forward relays each verified delivery, headers included, to another URL,
+such as a local development server. When the forward fails the receiver
+answers 502, so the API retries the delivery.
+
+--exec runs a shell command for each verified event, with the event's
+payload on stdin and GIGCLAW_EVENT, GIGCLAW_DELIVERY, GIGCLAW_ATTEMPT and
+GIGCLAW_TASK_ID in the environment. Prefix the command with an event name
+and "=" to run it for that event only.
+
+The API must be able to reach the receiver: on a laptop, expose the port
+with a tunnel and register the tunnel's URL.`,
+	Example: `  gigclaw webhook listen --port 8080
+  gigclaw webhook listen --port 8080 --forward http://localhost:3000/hooks/gigclaw
+  gigclaw webhook listen --exec 'task.created=./on-task.sh' --exec 'notify-send "GigClaw: $GIGCLAW_EVENT"'
+  gigclaw webhook listen -o json | jq 'select(.type == "task.bid")'`,
+	Args: cobra.NoArgs,
+	RunE: runWebhookListen,
+}
+
+var (
+	webhookListenPort    int
+	webhookListenHost    string
+	webhookListenPath    string
+	webhookListenSecrets []string
+	webhookListenForward string
+	webhookListenExec    []string
+	webhookListenEvents  []string
+	webhookListenCount   int
+)
+
+func init() {
+	webhookCmd.AddCommand(webhookListenCmd)
+
+	webhookListenCmd.Flags().IntVarP(&webhookListenPort, "port", "p", 8080, "Port to listen on")
+	webhookListenCmd.Flags().StringVar(&webhookListenHost, "host", "", "Interface to listen on (default all)")
+	webhookListenCmd.Flags().StringVar(&webhookListenPath, "path", "/", "URL path to receive deliveries on")
+	webhookListenCmd.Flags().StringArrayVar(&webhookListenSecrets, "secret", []string{}, "Signing secret (can specify multiple; default the stored secrets)")
+	webhookListenCmd.Flags().StringVar(&webhookListenForward, "forward", "", "Relay verified deliveries to this URL")
+	webhookListenCmd.Flags().StringArrayVar(&webhookListenExec, "exec", []string{}, "Run a command for each event, as [event=]command (can specify multiple)")
+	webhookListenCmd.Flags().StringArrayVarP(&webhookListenEvents, "event", "e", []string{}, "Only handle this event (can specify multiple)")
+	webhookListenCmd.Flags().IntVarP(&webhookListenCount, "count", "n", 0, "Exit after this many events (0 = run until interrupted)")
+}
+
+// webhookHook is an --exec command
+type webhookHook struct {
+	event   string // "" for every event
+	command string
+}
+
+// parseWebhookHook splits "[event=]command". A prefix that is not an event
+// name is part of the command, e.g. an environment assignment.
+func parseWebhookHook(spec string) webhookHook {
+	if event, command, ok := strings.Cut(spec, "="); ok {
+		if event == "*" {
+			return webhookHook{command: command}
+		}
+		if containsFold(gigclaw.WebhookEvents, event) || strings.EqualFold(event, gigclaw.WebhookTest) {
+			return webhookHook{event: strings.ToLower(event), command: command}
+		}
+	}
+	return webhookHook{command: spec}
+}
+
+// webhookReceiver verifies, prints, forwards and runs hooks for deliveries
+type webhookReceiver struct {
+	secrets []string
+	forward string
+	hooks   []webhookHook
+	events  []string
+	client  *http.Client
+	printer *eventPrinter
+	done    func() // called once --count events have been handled
+
+	mu   sync.Mutex
+	seen int
+}
+
+func (rv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, webhook.MaxBodySize))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := webhook.VerifyDelivery(body, r.Header.Get(gigclaw.SignatureHeader), rv.secrets...); err != nil {
+		logger.Warning(fmt.Sprintf("Rejected a delivery from %s: missing or invalid signature", r.RemoteAddr))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	delivery, err := webhook.Parse(body)
+	if err != nil {
+		logger.Warning(fmt.Sprintf("Rejected a delivery from %s: %v", r.RemoteAddr, err))
+		http.Error(w, "invalid delivery", http.StatusBadRequest)
+		return
+	}
+	event := delivery.Event
+	if len(rv.events) > 0 && !containsFold(rv.events, event) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if rv.forward != "" {
+		if err := rv.relay(r.Context(), r.Header, body); err != nil {
+			logger.Warning(fmt.Sprintf("Failed to forward %s delivery: %v", event, err))
+			http.Error(w, "forward failed", http.StatusBadGateway)
+			return
+		}
+	}
+
+	ev := gigclaw.Event{Type: event, TaskID: delivery.TaskID(), Data: delivery.Payload, Timestamp: delivery.Timestamp}
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = gigclaw.Timestamp{Time: time.Now()}
+	}
+
+	rv.mu.Lock()
+	if err := rv.printer.print(ev); err != nil {
+		logger.Warning(fmt.Sprintf("Failed to print event: %v", err))
+	}
+	rv.seen++
+	last := webhookListenCount > 0 && rv.seen == webhookListenCount
+	rv.mu.Unlock()
+
+	deliveryID := firstNonEmpty(r.Header.Get(gigclaw.DeliveryHeader), delivery.ID)
+	for _, h := range rv.hooks {
+		if h.event == "" || h.event == strings.ToLower(event) {
+			rv.runHook(r.Context(), h, ev, deliveryID, r.Header.Get(gigclaw.AttemptHeader))
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	if last {
+		rv.done()
+	}
+}
+
+// relay posts the delivery, with its GigClaw headers, to --forward
+func (rv *webhookReceiver) relay(ctx context.Context, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rv.forward, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, name := range []string{gigclaw.SignatureHeader, gigclaw.EventHeader, gigclaw.DeliveryHeader, gigclaw.AttemptHeader} {
+		if v := header.Get(name); v != "" {
+			req.Header.Set(name, v)
+		}
+	}
+
+	resp, err := rv.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", rv.forward, resp.Status)
+	}
+	return nil
+}
+
+// runHook runs an --exec command with the event payload on stdin
+func (rv *webhookReceiver) runHook(ctx context.Context, h webhookHook, ev gigclaw.Event, deliveryID, attempt string) {
+	hook := exec.CommandContext(ctx, "sh", "-c", h.command)
+	hook.Stdin = bytes.NewReader(ev.Data)
+	// Keep stdout for events, so -o json stays valid NDJSON
+	hook.Stdout = os.Stderr
+	hook.Stderr = os.Stderr
+	hook.Env = append(os.Environ(),
+		"GIGCLAW_EVENT="+ev.Type,
+		"GIGCLAW_DELIVERY="+deliveryID,
+		"GIGCLAW_ATTEMPT="+attempt,
+		"GIGCLAW_TASK_ID="+ev.TaskID,
+	)
+
+	if err := hook.Run(); err != nil {
+		logger.Warning(fmt.Sprintf("Hook %q failed for %s: %v", h.command, ev.Type, err))
+	}
+}
+
+func runWebhookListen(cmd *cobra.Command, args []string) error {
+	if webhookListenPort < 1 || webhookListenPort > 65535 {
+		return fmt.Errorf("invalid --port %d: must be between 1 and 65535", webhookListenPort)
+	}
+	if webhookListenCount < 0 {
+		return fmt.Errorf("--count must not be negative")
+	}
+	if !strings.HasPrefix(webhookListenPath, "/") {
+		webhookListenPath = "/" + webhookListenPath
+	}
+	if webhookListenForward != "" && !strings.HasPrefix(webhookListenForward, "http://") && !strings.HasPrefix(webhookListenForward, "https://") {
+		return fmt.Errorf("invalid --forward %q: must start with http:// or https://", webhookListenForward)
+	}
+	for _, e := range webhookListenEvents {
+		if !containsFold(gigclaw.WebhookEvents, e) && !strings.EqualFold(e, gigclaw.WebhookTest) {
+			return fmt.Errorf("invalid --event %q: must be one of %s", e, strings.Join(gigclaw.WebhookEvents, ", "))
+		}
+	}
+
+	secrets := append([]string{}, webhookListenSecrets...)
+	if env := os.Getenv("GIGCLAW_WEBHOOK_SECRET"); env != "" {
+		secrets = append(secrets, env)
+	}
+	stored := 0
+	if len(secrets) == 0 {
+		store, err := openWebhookStore()
+		if err != nil {
+			return err
+		}
+		// Deliveries for any webhook registered here are accepted, unless
+		// --as narrows them to one agent
+		secrets = store.secrets(webhookAs)
+		stored = len(secrets)
+	}
+	if len(secrets) == 0 {
+		return friendlyError(nil,
+			"No webhook secret to verify deliveries with",
+			[]string{"Secrets are stored when webhooks are registered from this machine"},
+			"Register a webhook: gigclaw webhook register <url>",
+			"Pass the secret: --secret <secret>, or set GIGCLAW_WEBHOOK_SECRET")
+	}
+
+	hooks := make([]webhookHook, 0, len(webhookListenExec))
+	for _, spec := range webhookListenExec {
+		hooks = append(hooks, parseWebhookHook(spec))
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	receiver := &webhookReceiver{
+		secrets: secrets,
+		forward: webhookListenForward,
+		hooks:   hooks,
+		events:  webhookListenEvents,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		printer: newEventPrinter(outputMode()),
+		done:    stop,
+	}
+	mux := http.NewServeMux()
+	mux.Handle(webhookListenPath, receiver)
+
+	addr := net.JoinHostPort(webhookListenHost, strconv.Itoa(webhookListenPort))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	if decorated() {
+		fmt.Println()
+		colorPrimary.Println("  📡 Receiving GigClaw webhooks")
+		colorLabel.Printf("  %-15s ", "Listening on:")
+		colorValue.Printf("http://%s%s\n", listener.Addr(), webhookListenPath)
+		colorLabel.Printf("  %-15s ", "Secrets:")
+		if stored > 0 {
+			colorValue.Printf("%d stored\n", stored)
+		} else {
+			colorValue.Printf("%d given\n", len(secrets))
+		}
+		if webhookListenForward != "" {
+			colorLabel.Printf("  %-15s ", "Forwarding to:")
+			colorValue.Println(webhookListenForward)
+		}
+		for _, h := range hooks {
+			colorLabel.Printf("  %-15s ", "Hook:")
+			colorValue.Printf("%s %s\n", firstNonEmpty(h.event, "*"), h.command)
+		}
+		colorDim.Println("  Press Ctrl+C to stop")
+		fmt.Println()
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(listener) }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
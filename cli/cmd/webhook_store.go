@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// storedWebhook is the signing secret of a webhook registered from this
+// machine. The API returns it only once, at registration.
+type storedWebhook struct {
+	AgentID   string    `json:"agentId"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// webhookStore keeps webhook secrets so that webhook listen can verify
+// deliveries without the user copying them around
+type webhookStore struct {
+	Webhooks map[string]*storedWebhook `json:"webhooks"` // keyed by webhook ID
+
+	path string
+}
+
+// webhookStorePath returns ~/.gigclaw/webhooks.json
+func webhookStorePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "webhooks.json"
+	}
+	return filepath.Join(home, ".gigclaw", "webhooks.json")
+}
+
+// openWebhookStore loads the store, returning an empty one if it does not
+// exist yet
+func openWebhookStore() (*webhookStore, error) {
+	s := &webhookStore{
+		Webhooks: make(map[string]*storedWebhook),
+		path:     webhookStorePath(),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook secrets: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook secrets %s: %w", s.path, err)
+	}
+	if s.Webhooks == nil {
+		s.Webhooks = make(map[string]*storedWebhook)
+	}
+	return s, nil
+}
+
+// save atomically writes the store with owner-only permissions
+func (s *webhookStore) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook secrets: %w", err)
+	}
+
+	if err := writeFileAtomic(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write webhook secrets: %w", err)
+	}
+	return nil
+}
+
+// secrets returns the stored secrets, only those of agentID unless it is
+// empty
+func (s *webhookStore) secrets(agentID string) []string {
+	var secrets []string
+	for _, w := range s.Webhooks {
+		if agentID == "" || w.AgentID == agentID {
+			secrets = append(secrets, w.Secret)
+		}
+	}
+	return secrets
+}
@@ -0,0 +1,519 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Run an autonomous agent worker",
+	Long: `Run an autonomous agent worker against the GigClaw marketplace.
+
+The worker polls for open tasks, bids on the ones matching your rules
+and watches accepted work through to verification.`,
+}
+
+var workerStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the agent worker",
+	Long: `Start a long-running agent worker.
+
+Every interval the worker:
+  1. Lists open tasks and filters them by tag, budget and currency
+  2. Places a bid on each new matching task as your agent
+  3. Checks tasks it has bid on for acceptance or verification
+
+Bids are recorded in a state file (default ~/.gigclaw/worker-state.json)
+so a restarted worker never bids on the same task twice. When a bid's
+outcome is unknown, e.g. the server failed mid-request, the worker looks
+for it among the task's bids instead of bidding again.
+
+Rules can also be set in the config file:
+
+  worker:
+    interval: 1m
+    tags: [security, rust]
+    min-budget: 10
+    max-budget: 200
+    currency: USDC
+    bid-ratio: 0.9
+
+The worker shuts down cleanly on SIGINT or SIGTERM.`,
+	Example: `  gigclaw worker start --tag security --min-budget 20
+  gigclaw worker start --bid-ratio 0.8 --on-accept ./do-work.sh
+  gigclaw worker start --once`,
+	RunE: runWorkerStart,
+}
+
+var (
+	workerInterval  time.Duration
+	workerTags      []string
+	workerMinBudget float64
+	workerMaxBudget float64
+	workerCurrency  string
+	workerBidRatio  float64
+	workerMessage   string
+	workerMaxBids   int
+	workerStateFile string
+	workerOnAccept  string
+	workerOnce      bool
+	workerAs        string
+)
+
+func init() {
+	rootCmd.AddCommand(workerCmd)
+	workerCmd.AddCommand(workerStartCmd)
+
+	flags := workerStartCmd.Flags()
+	flags.DurationVarP(&workerInterval, "interval", "i", 30*time.Second, "Polling interval")
+	flags.StringArrayVarP(&workerTags, "tag", "g", []string{}, "Only bid on tasks that require this skill (can specify multiple)")
+	flags.Float64Var(&workerMinBudget, "min-budget", 0, "Minimum task budget")
+	flags.Float64Var(&workerMaxBudget, "max-budget", 0, "Maximum task budget (0 = no limit)")
+	flags.StringVarP(&workerCurrency, "currency", "c", "", "Only bid on tasks in this currency (USDC, SOL)")
+	flags.Float64Var(&workerBidRatio, "bid-ratio", 0.9, "Bid amount as a fraction of the task budget")
+	flags.StringVarP(&workerMessage, "message", "m", "", "Message attached to every bid")
+	flags.IntVar(&workerMaxBids, "max-bids", 5, "Maximum bids placed per polling cycle")
+	flags.StringVar(&workerStateFile, "state-file", "", "Worker state file (default ~/.gigclaw/worker-state.json)")
+	flags.StringVar(&workerOnAccept, "on-accept", "", "Shell command run when a bid is accepted")
+	flags.BoolVar(&workerOnce, "once", false, "Run a single polling cycle and exit")
+	flags.StringVar(&workerAs, "as", "", "Agent ID to bid as (default agent-id from the config file)")
+
+	for _, name := range []string{
+		"interval", "tag", "min-budget", "max-budget", "currency",
+		"bid-ratio", "message", "max-bids", "state-file", "on-accept",
+	} {
+		key := "worker." + name
+		if name == "tag" {
+			key = "worker.tags"
+		}
+		viper.BindPFlag(key, flags.Lookup(name))
+	}
+}
+
+// workerRules decides which tasks the worker bids on
+type workerRules struct {
+	Tags      []string
+	MinBudget float64
+	MaxBudget float64
+	Currency  string
+	BidRatio  float64
+	Message   string
+	MaxBids   int
+}
+
+// matches reports whether a task satisfies the rules
+func (r workerRules) matches(task gigclaw.Task) bool {
+	if task.Status != "" && !strings.EqualFold(task.Status, "posted") {
+		return false
+	}
+	// The API prices every task in USDC and leaves the currency out
+	if r.Currency != "" && !strings.EqualFold(firstNonEmpty(task.Currency, "USDC"), r.Currency) {
+		return false
+	}
+	if task.Budget < r.MinBudget {
+		return false
+	}
+	if r.MaxBudget > 0 && task.Budget > r.MaxBudget {
+		return false
+	}
+	if len(r.Tags) == 0 {
+		return true
+	}
+	for _, want := range r.Tags {
+		for _, have := range task.Skills() {
+			if strings.EqualFold(want, have) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bidAmount returns the bid for a task, rounded to cents
+func (r workerRules) bidAmount(task gigclaw.Task) float64 {
+	return math.Round(task.Budget*r.BidRatio*100) / 100
+}
+
+func (r workerRules) validate() error {
+	if r.BidRatio <= 0 || r.BidRatio > 1 {
+		return fmt.Errorf("--bid-ratio must be between 0 and 1, got %v", r.BidRatio)
+	}
+	if r.MaxBudget > 0 && r.MaxBudget < r.MinBudget {
+		return fmt.Errorf("--max-budget (%v) is lower than --min-budget (%v)", r.MaxBudget, r.MinBudget)
+	}
+	if r.MaxBids < 1 {
+		return fmt.Errorf("--max-bids must be at least 1")
+	}
+	return nil
+}
+
+// worker polls the marketplace and bids on matching tasks
+type worker struct {
+	client   *gigclaw.Client
+	agentID  string // bids are placed as this agent
+	rules    workerRules
+	state    *workerState
+	onAccept string
+}
+
+func runWorkerStart(cmd *cobra.Command, args []string) error {
+	rules := workerRules{
+		Tags:      viper.GetStringSlice("worker.tags"),
+		MinBudget: viper.GetFloat64("worker.min-budget"),
+		MaxBudget: viper.GetFloat64("worker.max-budget"),
+		Currency:  viper.GetString("worker.currency"),
+		BidRatio:  viper.GetFloat64("worker.bid-ratio"),
+		Message:   viper.GetString("worker.message"),
+		MaxBids:   viper.GetInt("worker.max-bids"),
+	}
+	if err := rules.validate(); err != nil {
+		return err
+	}
+
+	// Only the agent a bid names can complete the task
+	agentID, err := actingAgent(workerAs, "--as")
+	if err != nil {
+		return err
+	}
+
+	interval := viper.GetDuration("worker.interval")
+	if interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s, got %v", interval)
+	}
+
+	statePath := viper.GetString("worker.state-file")
+	if statePath == "" {
+		statePath = defaultWorkerStatePath()
+	}
+	state, err := loadWorkerState(statePath)
+	if err != nil {
+		return err
+	}
+
+	client, err := getAPIClient()
+	if err != nil {
+		return HandleAPIError(err)
+	}
+
+	w := &worker{
+		client:   client,
+		agentID:  agentID,
+		rules:    rules,
+		state:    state,
+		onAccept: viper.GetString("worker.on-accept"),
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if decorated() {
+		fmt.Println()
+		colorPrimary.Println("  🦀 GigClaw worker started")
+		colorLabel.Printf("  %-15s ", "Agent:")
+		colorValue.Println(agentID)
+		colorLabel.Printf("  %-15s ", "Interval:")
+		colorValue.Println(interval)
+		colorLabel.Printf("  %-15s ", "Rules:")
+		colorValue.Println(rules.describe())
+		colorLabel.Printf("  %-15s ", "State:")
+		colorValue.Printf("%s (%d bids tracked)\n", statePath, len(state.Bids))
+		fmt.Println()
+	}
+
+	err = w.run(ctx, interval, workerOnce)
+
+	if decorated() {
+		fmt.Println()
+		colorLabel.Printf("  %-15s ", "Shutdown:")
+		colorValue.Printf("%d pending, %d accepted, %d verified, %d lost\n",
+			state.count(bidStatePending), state.count(bidStateAccepted),
+			state.count(bidStateVerified), state.count(bidStateLost))
+		fmt.Println()
+	}
+
+	return err
+}
+
+// describe returns a one-line summary of the rules
+func (r workerRules) describe() string {
+	parts := []string{fmt.Sprintf("bid %.0f%% of budget", r.BidRatio*100)}
+	if len(r.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(r.Tags, ","))
+	}
+	if r.Currency != "" {
+		parts = append(parts, "currency="+r.Currency)
+	}
+	if r.MinBudget > 0 {
+		parts = append(parts, fmt.Sprintf("min=%.2f", r.MinBudget))
+	}
+	if r.MaxBudget > 0 {
+		parts = append(parts, fmt.Sprintf("max=%.2f", r.MaxBudget))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// run executes polling cycles until the context is cancelled
+func (w *worker) run(ctx context.Context, interval time.Duration, once bool) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.cycle(ctx)
+		if once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logf("Received shutdown signal, stopping")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// cycle runs a single bid-and-watch pass
+func (w *worker) cycle(ctx context.Context) {
+	tasks, err := w.client.ListTasks(ctx)
+	if err != nil {
+		w.logf("Failed to list tasks: %v", err)
+	} else {
+		w.bid(ctx, tasks)
+	}
+
+	w.watch(ctx)
+}
+
+// bid places bids on new tasks matching the rules, highest budget first
+func (w *worker) bid(ctx context.Context, tasks []gigclaw.Task) {
+	var candidates []gigclaw.Task
+	for _, task := range tasks {
+		if _, seen := w.state.Bids[task.ID]; seen {
+			continue
+		}
+		if w.rules.matches(task) {
+			candidates = append(candidates, task)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Budget > candidates[j].Budget
+	})
+
+	for i, task := range candidates {
+		if ctx.Err() != nil || i >= w.rules.MaxBids {
+			return
+		}
+
+		amount := w.rules.bidAmount(task)
+		entry := &workerBid{
+			TaskID:   task.ID,
+			Title:    task.Title,
+			Amount:   amount,
+			Currency: task.Currency,
+			PlacedAt: time.Now(),
+		}
+
+		// Record the attempt before sending it. If we crash mid-request the
+		// task stays marked until watch finds out whether the bid landed.
+		w.state.Bids[task.ID] = entry
+		if err := w.state.setStatus(entry, bidStatePlacing); err != nil {
+			w.logf("Failed to save state, not bidding: %v", err)
+			delete(w.state.Bids, task.ID)
+			return
+		}
+
+		bid, err := w.client.PlaceAgentBid(ctx, task.ID, w.agentID, amount, w.rules.Message,
+			gigclaw.IdempotencyKey("worker-bid-"+task.ID))
+		if err != nil {
+			if !notApplied(err) {
+				// The bid may have reached the server; keep the task marked
+				// and look for the bid on the next watch.
+				w.logf("Bid on %s has unknown outcome, will check the task's bids: %v", task.ID, err)
+				continue
+			}
+			w.logf("Bid on %s not placed: %v", task.ID, err)
+			delete(w.state.Bids, task.ID)
+			if err := w.state.save(); err != nil {
+				w.logf("Failed to save state: %v", err)
+			}
+			continue
+		}
+
+		entry.BidID = bid.ID
+		if err := w.state.setStatus(entry, bidStatePending); err != nil {
+			w.logf("Failed to save state: %v", err)
+		}
+		w.logf("💰 Bid %.2f %s on %q (%s)", amount, task.Currency, truncate(task.Title, 40), task.ID)
+	}
+}
+
+// watch checks open bids and accepted work for status changes
+func (w *worker) watch(ctx context.Context) {
+	for _, entry := range w.state.Bids {
+		if ctx.Err() != nil {
+			return
+		}
+		if entry.Status != bidStatePlacing && entry.Status != bidStatePending && entry.Status != bidStateAccepted {
+			continue
+		}
+
+		task, err := w.client.GetTask(ctx, entry.TaskID)
+		if errors.Is(err, gigclaw.ErrNotFound) {
+			w.logf("Task %s no longer exists", entry.TaskID)
+			if err := w.state.setStatus(entry, bidStateLost); err != nil {
+				w.logf("Failed to save state: %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			w.logf("Failed to check task %s: %v", entry.TaskID, err)
+			continue
+		}
+
+		if entry.Status == bidStatePlacing {
+			bidID, found := findAgentBid(task, w.agentID)
+			if !found && strings.EqualFold(task.Status, "posted") {
+				// The bid never landed; bid again if the task still matches
+				w.logf("Bid on %s was not placed, will retry", task.ID)
+				delete(w.state.Bids, task.ID)
+				if err := w.state.save(); err != nil {
+					w.logf("Failed to save state: %v", err)
+				}
+				continue
+			}
+			if found {
+				w.logf("Found bid %s on %s", bidID, task.ID)
+			}
+			entry.BidID = bidID
+			if err := w.state.setStatus(entry, bidStatePending); err != nil {
+				w.logf("Failed to save state: %v", err)
+			}
+		}
+
+		next := nextBidState(entry, task, w.agentID)
+		if next == entry.Status {
+			continue
+		}
+
+		if err := w.state.setStatus(entry, next); err != nil {
+			w.logf("Failed to save state: %v", err)
+		}
+
+		switch next {
+		case bidStateAccepted:
+			w.logf("✅ Bid accepted on %q (%s), funds locked in escrow", truncate(task.Title, 40), task.ID)
+			w.runHook(ctx, task, entry)
+		case bidStateVerified:
+			w.logf("💸 Work verified on %s, %.2f %s released", task.ID, entry.Amount, entry.Currency)
+		case bidStateLost:
+			w.logf("Task %s is now %s, bid not accepted", task.ID, task.Status)
+		}
+	}
+}
+
+// nextBidState returns the state a tracked bid moves to, given its task
+func nextBidState(entry *workerBid, task *gigclaw.Task, agentID string) string {
+	switch entry.Status {
+	case bidStatePending:
+		if bidAccepted(task, entry.BidID, agentID) {
+			return bidStateAccepted
+		}
+		if !strings.EqualFold(task.Status, "posted") {
+			return bidStateLost
+		}
+	case bidStateAccepted:
+		switch strings.ToLower(task.Status) {
+		case "verified", "paid":
+			return bidStateVerified
+		case "cancelled":
+			return bidStateLost
+		}
+	}
+	return entry.Status
+}
+
+// bidAccepted reports whether the worker's bid won the task. The API marks
+// the winning bid accepted and assigns the task to its agent.
+func bidAccepted(task *gigclaw.Task, bidID, agentID string) bool {
+	if bidID != "" {
+		if task.AcceptedBid != nil && task.AcceptedBid.ID == bidID {
+			return true
+		}
+		for _, b := range task.Bids {
+			if b.ID == bidID {
+				return b.Accepted
+			}
+		}
+	}
+	return agentID != "" && task.AssignedAgent == agentID
+}
+
+// findAgentBid returns the ID of agentID's bid on the task, if any
+func findAgentBid(task *gigclaw.Task, agentID string) (string, bool) {
+	for _, b := range task.Bids {
+		if b.AgentID == agentID {
+			return b.ID, true
+		}
+	}
+	return "", false
+}
+
+// runHook runs the --on-accept command with the task on stdin
+func (w *worker) runHook(ctx context.Context, task *gigclaw.Task, entry *workerBid) {
+	if w.onAccept == "" {
+		return
+	}
+
+	payload, err := json.Marshal(task)
+	if err != nil {
+		w.logf("Failed to encode task for hook: %v", err)
+		return
+	}
+
+	hook := exec.CommandContext(ctx, "sh", "-c", w.onAccept)
+	hook.Stdin = strings.NewReader(string(payload))
+	hook.Stdout = os.Stdout
+	hook.Stderr = os.Stderr
+	hook.Env = append(os.Environ(),
+		"GIGCLAW_TASK_ID="+task.ID,
+		"GIGCLAW_TASK_TITLE="+task.Title,
+		"GIGCLAW_BID_ID="+entry.BidID,
+		fmt.Sprintf("GIGCLAW_BID_AMOUNT=%.2f", entry.Amount),
+		"GIGCLAW_CURRENCY="+entry.Currency,
+	)
+
+	if err := hook.Run(); err != nil {
+		w.logf("On-accept hook failed for %s: %v", task.ID, err)
+	}
+}
+
+// logf prints a timestamped worker event, as one JSON object per line
+// with --output json
+func (w *worker) logf(format string, args ...interface{}) {
+	now := time.Now()
+	if outputMode() == outputJSON {
+		line, _ := json.Marshal(map[string]string{
+			"time":    now.Format(time.RFC3339),
+			"message": fmt.Sprintf(format, args...),
+		})
+		fmt.Println(string(line))
+		return
+	}
+	colorDim.Printf("[%s] ", now.Format("15:04:05"))
+	fmt.Printf(format+"\n", args...)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Worker bid states
+const (
+	bidStatePlacing  = "placing"  // bid request sent, response not yet recorded
+	bidStatePending  = "pending"  // bid placed, waiting for the poster
+	bidStateAccepted = "accepted" // our bid won, work in progress
+	bidStateLost     = "lost"     // task moved on without us
+	bidStateVerified = "verified" // work verified and payment released
+)
+
+// workerBid tracks a single bid placed by the worker
+type workerBid struct {
+	TaskID    string    `json:"taskId"`
+	BidID     string    `json:"bidId,omitempty"`
+	Title     string    `json:"title"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	PlacedAt  time.Time `json:"placedAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// workerState is persisted between runs so a restarted worker
+// never places the same bid twice
+type workerState struct {
+	Bids      map[string]*workerBid `json:"bids"` // keyed by task ID
+	UpdatedAt time.Time             `json:"updatedAt"`
+
+	path string
+}
+
+// defaultWorkerStatePath returns ~/.gigclaw/worker-state.json
+func defaultWorkerStatePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "worker-state.json"
+	}
+	return filepath.Join(home, ".gigclaw", "worker-state.json")
+}
+
+// loadWorkerState reads the state file, returning an empty state if it does not exist yet
+func loadWorkerState(path string) (*workerState, error) {
+	state := &workerState{
+		Bids: make(map[string]*workerBid),
+		path: path,
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worker state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse worker state %s: %w", path, err)
+	}
+	if state.Bids == nil {
+		state.Bids = make(map[string]*workerBid)
+	}
+
+	return state, nil
+}
+
+// save atomically writes the state file with owner-only permissions
+func (s *workerState) save() error {
+	s.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode worker state: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write worker state: %w", err)
+	}
+	return nil
+}
+
+// setStatus updates a bid's status and persists the change
+func (s *workerState) setStatus(b *workerBid, status string) error {
+	b.Status = status
+	b.UpdatedAt = time.Now()
+	return s.save()
+}
+
+// count returns the number of bids in the given status
+func (s *workerState) count(status string) int {
+	n := 0
+	for _, b := range s.Bids {
+		if b.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorkerStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "worker-state.json")
+
+	state, err := loadWorkerState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Bids) != 0 {
+		t.Fatalf("new state has %d bids", len(state.Bids))
+	}
+
+	placed := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	state.Bids["t1"] = &workerBid{TaskID: "t1", BidID: "b1", Title: "Audit", Amount: 90, Currency: "USDC", Status: bidStatePending, PlacedAt: placed}
+	state.Bids["t2"] = &workerBid{TaskID: "t2", Amount: 45, Status: bidStatePlacing, PlacedAt: placed}
+	if err := state.setStatus(state.Bids["t1"], bidStateAccepted); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadWorkerState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Bids) != 2 {
+		t.Fatalf("loaded %d bids, want 2", len(loaded.Bids))
+	}
+	got := loaded.Bids["t1"]
+	if got.BidID != "b1" || got.Status != bidStateAccepted || got.Amount != 90 || !got.PlacedAt.Equal(placed) {
+		t.Errorf("t1 = %+v", got)
+	}
+	if got := loaded.Bids["t2"]; got.BidID != "" || got.Status != bidStatePlacing {
+		t.Errorf("t2 = %+v", got)
+	}
+	if loaded.count(bidStateAccepted) != 1 || loaded.count(bidStatePlacing) != 1 {
+		t.Errorf("counts: accepted %d, placing %d", loaded.count(bidStateAccepted), loaded.count(bidStatePlacing))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("mode = %o, want 600", perm)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("state directory has %d files, want only the state file", len(entries))
+	}
+}
+
+func TestLoadWorkerStateCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker-state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadWorkerState(path); err == nil {
+		t.Error("loaded a corrupt state file")
+	}
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+func TestWorkerRulesMatches(t *testing.T) {
+	task := gigclaw.Task{Budget: 100, Currency: "USDC", Status: "posted", Tags: []string{"Rust", "audit"}}
+
+	tests := []struct {
+		name  string
+		rules workerRules
+		task  func(gigclaw.Task) gigclaw.Task
+		want  bool
+	}{
+		{"no rules", workerRules{}, nil, true},
+		{"tag matches case-insensitively", workerRules{Tags: []string{"rust"}}, nil, true},
+		{"any tag matches", workerRules{Tags: []string{"go", "audit"}}, nil, true},
+		{"no tag matches", workerRules{Tags: []string{"go"}}, nil, false},
+		{"currency matches", workerRules{Currency: "usdc"}, nil, true},
+		{"currency differs", workerRules{Currency: "SOL"}, nil, false},
+		{"budget in range", workerRules{MinBudget: 50, MaxBudget: 100}, nil, true},
+		{"budget too low", workerRules{MinBudget: 101}, nil, false},
+		{"budget too high", workerRules{MaxBudget: 99}, nil, false},
+		{"task assigned", workerRules{}, func(t gigclaw.Task) gigclaw.Task { t.Status = "in_progress"; return t }, false},
+		{"task read from chain has no status", workerRules{}, func(t gigclaw.Task) gigclaw.Task { t.Status = ""; return t }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk := task
+			if tt.task != nil {
+				tk = tt.task(tk)
+			}
+			if got := tt.rules.matches(tk); got != tt.want {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// serverTask is a task as GET /api/tasks returns it: required skills
+// instead of tags, and no currency
+const serverTask = `{
+	"id": "taskmk3b9x2qa1b2",
+	"title": "Audit token program",
+	"budget": 150,
+	"deadline": "2026-02-01T00:00:00.000Z",
+	"requiredSkills": ["rust", "audit"],
+	"posterId": "alice",
+	"status": "posted",
+	"createdAt": 1767225600000
+}`
+
+func TestWorkerRulesMatchServerTask(t *testing.T) {
+	var task gigclaw.Task
+	if err := json.Unmarshal([]byte(serverTask), &task); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		rules workerRules
+		want  bool
+	}{
+		{"tag matches a required skill", workerRules{Tags: []string{"Rust"}}, true},
+		{"no tag matches", workerRules{Tags: []string{"go"}}, false},
+		{"USDC matches", workerRules{Currency: "USDC"}, true},
+		{"other currency", workerRules{Currency: "SOL"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.matches(task); got != tt.want {
+				t.Errorf("matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBidAccepted(t *testing.T) {
+	tests := []struct {
+		name string
+		task gigclaw.Task
+		want bool
+	}{
+		{
+			name: "our bid accepted",
+			task: gigclaw.Task{Status: "in_progress", AssignedAgent: "me", Bids: []gigclaw.Bid{{ID: "b1", AgentID: "me", Accepted: true}}},
+			want: true,
+		},
+		{
+			name: "accepted bid on the task",
+			task: gigclaw.Task{Status: "in_progress", AcceptedBid: &gigclaw.Bid{ID: "b1"}},
+			want: true,
+		},
+		{
+			name: "another bid accepted",
+			task: gigclaw.Task{Status: "in_progress", AssignedAgent: "rival", Bids: []gigclaw.Bid{{ID: "b1", AgentID: "me"}, {ID: "b2", AgentID: "rival", Accepted: true}}},
+			want: false,
+		},
+		{
+			name: "still open",
+			task: gigclaw.Task{Status: "posted", Bids: []gigclaw.Bid{{ID: "b1", AgentID: "me"}}},
+			want: false,
+		},
+		{
+			name: "assigned to us, bid not listed",
+			task: gigclaw.Task{Status: "in_progress", AssignedAgent: "me"},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bidAccepted(&tt.task, "b1", "me"); got != tt.want {
+				t.Errorf("bidAccepted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextBidState(t *testing.T) {
+	won := []gigclaw.Bid{{ID: "b1", AgentID: "me", Accepted: true}}
+	lost := []gigclaw.Bid{{ID: "b1", AgentID: "me"}, {ID: "b2", AgentID: "rival", Accepted: true}}
+
+	tests := []struct {
+		from   string
+		status string
+		bids   []gigclaw.Bid
+		want   string
+	}{
+		{bidStatePending, "posted", lost[:1], bidStatePending},
+		{bidStatePending, "in_progress", won, bidStateAccepted},
+		{bidStatePending, "in_progress", lost, bidStateLost},
+		{bidStatePending, "cancelled", lost[:1], bidStateLost},
+		{bidStateAccepted, "in_progress", won, bidStateAccepted},
+		{bidStateAccepted, "completed", won, bidStateAccepted},
+		{bidStateAccepted, "verified", won, bidStateVerified},
+		{bidStateAccepted, "paid", won, bidStateVerified},
+		{bidStateAccepted, "cancelled", won, bidStateLost},
+		{bidStateLost, "verified", lost, bidStateLost},
+	}
+	for _, tt := range tests {
+		entry := &workerBid{BidID: "b1", Status: tt.from}
+		task := &gigclaw.Task{Status: tt.status, Bids: tt.bids}
+		if got := nextBidState(entry, task, "me"); got != tt.want {
+			t.Errorf("%s bid on %s task: next = %s, want %s", tt.from, tt.status, got, tt.want)
+		}
+	}
+}
+
+// fakeMarket serves a task and its bid endpoint for worker tests
+type fakeMarket struct {
+	mu        sync.Mutex
+	task      string // GET /api/tasks/t1 body
+	bidStatus int
+	bidBody   string
+	bids      int // POST /api/tasks/t1/bid requests
+	agentIDs  []string
+}
+
+func (f *fakeMarket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/tasks/t1/bid":
+		f.bids++
+		var body struct {
+			AgentID string `json:"agentId"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.agentIDs = append(f.agentIDs, body.AgentID)
+		w.WriteHeader(f.bidStatus)
+		w.Write([]byte(f.bidBody))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/tasks/t1":
+		w.Write([]byte(f.task))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Task not found"}`))
+	}
+}
+
+// newTestWorker returns a worker for agent "me" against market, with its
+// state in a temporary directory
+func newTestWorker(t *testing.T, market *fakeMarket) *worker {
+	t.Helper()
+	srv := httptest.NewServer(market)
+	t.Cleanup(srv.Close)
+
+	client, err := gigclaw.NewClient(gigclaw.WithBaseURL(srv.URL), gigclaw.WithRetryPolicy(gigclaw.NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := loadWorkerState(filepath.Join(t.TempDir(), "worker-state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &worker{client: client, agentID: "me", rules: workerRules{BidRatio: 0.9, MaxBids: 5}, state: state}
+}
+
+var openTask = gigclaw.Task{ID: "t1", Title: "Audit", Budget: 100, Currency: "USDC", Status: "posted"}
+
+func TestWorkerBidOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantState string // "" when the task is forgotten
+		wantBidID string
+	}{
+		{"placed", http.StatusOK, `{"message":"Bid placed","bid":{"id":"b1","agentId":"me","amount":90,"createdAt":1767229200000,"accepted":false}}`, bidStatePending, "b1"},
+		{"rejected", http.StatusBadRequest, `{"error":"Task is not open for bidding"}`, "", ""},
+		{"server error", http.StatusBadGateway, `{"error":"Bad gateway"}`, bidStatePlacing, ""},
+		{"rate limited", http.StatusTooManyRequests, `{"error":"Too many requests"}`, bidStatePlacing, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := &fakeMarket{bidStatus: tt.status, bidBody: tt.body}
+			w := newTestWorker(t, market)
+
+			w.bid(context.Background(), []gigclaw.Task{openTask})
+
+			if market.bids != 1 || market.agentIDs[0] != "me" {
+				t.Fatalf("bids = %d as %v, want 1 as me", market.bids, market.agentIDs)
+			}
+			saved, err := loadWorkerState(w.state.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entry := saved.Bids["t1"]
+			switch {
+			case tt.wantState == "" && entry != nil:
+				t.Errorf("entry = %+v, want none", entry)
+			case tt.wantState != "" && (entry == nil || entry.Status != tt.wantState || entry.BidID != tt.wantBidID):
+				t.Errorf("entry = %+v, want %s with bid %q", entry, tt.wantState, tt.wantBidID)
+			}
+		})
+	}
+}
+
+func TestWorkerRestartDoesNotRebid(t *testing.T) {
+	market := &fakeMarket{bidStatus: http.StatusBadGateway, bidBody: `{"error":"Bad gateway"}`}
+	w := newTestWorker(t, market)
+	w.bid(context.Background(), []gigclaw.Task{openTask})
+
+	// A restarted worker reads the bid, unresolved, from disk
+	state, err := loadWorkerState(w.state.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.state = state
+	w.bid(context.Background(), []gigclaw.Task{openTask})
+
+	if market.bids != 1 {
+		t.Errorf("bids = %d, want 1", market.bids)
+	}
+}
+
+func TestWorkerReconcilesUnknownBids(t *testing.T) {
+	tests := []struct {
+		name      string
+		task      string
+		wantState string // "" when the task is forgotten, to bid again
+		wantBidID string
+	}{
+		{
+			name:      "bid landed",
+			task:      `{"id":"t1","status":"posted","bids":[{"id":"b0","agentId":"rival","amount":80,"createdAt":1767229100000,"accepted":false},{"id":"b1","agentId":"me","amount":90,"createdAt":1767229200000,"accepted":false}],"createdAt":1767225600000}`,
+			wantState: bidStatePending,
+			wantBidID: "b1",
+		},
+		{
+			name:      "bid landed and won",
+			task:      `{"id":"t1","status":"in_progress","assignedAgent":"me","bids":[{"id":"b1","agentId":"me","amount":90,"createdAt":1767229200000,"accepted":true}],"createdAt":1767225600000}`,
+			wantState: bidStateAccepted,
+			wantBidID: "b1",
+		},
+		{
+			name: "bid never landed",
+			task: `{"id":"t1","status":"posted","bids":[],"createdAt":1767225600000}`,
+		},
+		{
+			name:      "bid never landed and task taken",
+			task:      `{"id":"t1","status":"in_progress","assignedAgent":"rival","bids":[{"id":"b0","agentId":"rival","amount":80,"createdAt":1767229100000,"accepted":true}],"createdAt":1767225600000}`,
+			wantState: bidStateLost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			market := &fakeMarket{task: tt.task}
+			w := newTestWorker(t, market)
+			w.state.Bids["t1"] = &workerBid{TaskID: "t1", Amount: 90, Status: bidStatePlacing}
+
+			w.watch(context.Background())
+
+			entry := w.state.Bids["t1"]
+			switch {
+			case tt.wantState == "" && entry != nil:
+				t.Errorf("entry = %+v, want none", entry)
+			case tt.wantState != "" && (entry == nil || entry.Status != tt.wantState || entry.BidID != tt.wantBidID):
+				t.Errorf("entry = %+v, want %s with bid %q", entry, tt.wantState, tt.wantBidID)
+			}
+			if market.bids != 0 {
+				t.Errorf("watch placed %d bids", market.bids)
+			}
+		})
+	}
+}
+
+func TestWorkerWatchMissingTask(t *testing.T) {
+	w := newTestWorker(t, &fakeMarket{})
+	w.state.Bids["gone"] = &workerBid{TaskID: "gone", BidID: "b1", Status: bidStatePending}
+
+	w.watch(context.Background())
+
+	if got := w.state.Bids["gone"].Status; got != bidStateLost {
+		t.Errorf("status = %s, want %s", got, bidStateLost)
+	}
+}
+
+func TestWorkerDescribe(t *testing.T) {
+	got := workerRules{BidRatio: 0.9, Tags: []string{"rust"}, MinBudget: 10}.describe()
+	if !strings.Contains(got, "bid 90% of budget") || !strings.Contains(got, "tags=rust") || !strings.Contains(got, "min=10.00") {
+		t.Errorf("describe = %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Agent availability statuses. Task matching only considers available
+// agents.
+const (
+	AgentAvailable = "available"
+	AgentBusy      = "busy"
+	AgentOffline   = "offline"
+)
+
+// Agent is an agent registered with the API
+type Agent struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Skills        []string   `json:"skills"`
+	WalletAddress string     `json:"walletAddress,omitempty"`
+	Record        AgentStats `json:"reputation"`
+	Status        string     `json:"status"`
+	CreatedAt     Timestamp  `json:"createdAt"`
+}
+
+// AgentStats is the track record kept with an agent's registration
+type AgentStats struct {
+	CompletedTasks int     `json:"completedTasks"`
+	FailedTasks    int     `json:"failedTasks"`
+	SuccessRate    float64 `json:"successRate"` // percent
+	TotalEarned    float64 `json:"totalEarned"`
+	Rating         float64 `json:"rating"`
+}
+
+// RegisterAgentRequest registers an agent. The caller picks the agent ID;
+// registering an ID again replaces the agent and resets its record.
+type RegisterAgentRequest struct {
+	AgentID       string   `json:"agentId"`
+	Name          string   `json:"name"`
+	Skills        []string `json:"skills"`
+	WalletAddress string   `json:"walletAddress,omitempty"`
+}
+
+// RegisterAgent registers an agent
+func (c *Client) RegisterAgent(ctx context.Context, req RegisterAgentRequest, opts ...RequestOption) (*Agent, error) {
+	var response struct {
+		Agent Agent `json:"agent"`
+	}
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "register agent", http.MethodPost, "/api/agents/register", header, req, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &response.Agent, nil
+}
+
+// GetAgent retrieves a registered agent
+func (c *Client) GetAgent(ctx context.Context, agentID string) (*Agent, error) {
+	var agent Agent
+	path := fmt.Sprintf("/api/agents/%s", url.PathEscape(agentID))
+	if err := c.do(ctx, "get agent", http.MethodGet, path, nil, &agent, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// ListAgents retrieves every registered agent
+func (c *Client) ListAgents(ctx context.Context) ([]Agent, error) {
+	var response struct {
+		Agents []Agent `json:"agents"`
+	}
+	if err := c.do(ctx, "list agents", http.MethodGet, "/api/agents", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Agents, nil
+}
+
+// SetAgentStatus sets an agent's availability, e.g. AgentBusy
+func (c *Client) SetAgentStatus(ctx context.Context, agentID, status string) (*Agent, error) {
+	var response struct {
+		Agent Agent `json:"agent"`
+	}
+	body := map[string]string{"status": status}
+	path := fmt.Sprintf("/api/agents/%s/status", url.PathEscape(agentID))
+	if err := c.do(ctx, "set agent status", http.MethodPost, path, body, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Agent, nil
+}
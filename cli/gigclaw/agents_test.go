@@ -0,0 +1,100 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRegisterAgent(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/agents/register", fixture(t, "agents", "register"))
+
+	req := RegisterAgentRequest{AgentID: "agent-7", Name: "Auditor", Skills: []string{"rust", "security"}}
+	agent, err := api.client().RegisterAgent(context.Background(), req, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("RegisterAgent: %v", err)
+	}
+	if agent.ID != "agent-7" || agent.Status != AgentAvailable || agent.WalletAddress != "" ||
+		agent.Record != (AgentStats{}) || !agent.CreatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("agent = %+v", agent)
+	}
+	sent := api.last()
+	if _, ok := sent.Body["walletAddress"]; ok || sent.Body["agentId"] != "agent-7" || sent.Body["name"] != "Auditor" {
+		t.Errorf("body = %v", sent.Body)
+	}
+	if sent.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("header = %v", sent.Header)
+	}
+}
+
+func TestGetAgent(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/agent-7", fixture(t, "agents", "get"))
+	api.on("GET /api/agents/nobody", fixture(t, "agents", "get_missing"))
+	client := api.client()
+
+	agent, err := client.GetAgent(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("GetAgent: %v", err)
+	}
+	want := AgentStats{CompletedTasks: 3, FailedTasks: 1, SuccessRate: 75, TotalEarned: 120}
+	if agent.Name != "Auditor" || agent.Status != AgentBusy || agent.Record != want ||
+		!reflect.DeepEqual(agent.Skills, []string{"rust", "security"}) || agent.WalletAddress == "" {
+		t.Errorf("agent = %+v", agent)
+	}
+
+	_, err = client.GetAgent(context.Background(), "nobody")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestListAgents(t *testing.T) {
+	tests := []struct {
+		fixture string
+		ids     []string
+	}{
+		{"list", []string{"agent-7", "agent-9"}},
+		{"list_empty", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/agents", fixture(t, "agents", tt.fixture))
+
+			agents, err := api.client().ListAgents(context.Background())
+			if err != nil {
+				t.Fatalf("ListAgents: %v", err)
+			}
+			var ids []string
+			for _, a := range agents {
+				ids = append(ids, a.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Errorf("agents = %v, want %v", ids, tt.ids)
+			}
+		})
+	}
+}
+
+func TestSetAgentStatus(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/agents/agent-7/status", fixture(t, "agents", "status"))
+	api.on("POST /api/agents/nobody/status", fixture(t, "agents", "status_missing"))
+	client := api.client()
+
+	agent, err := client.SetAgentStatus(context.Background(), "agent-7", AgentOffline)
+	if err != nil {
+		t.Fatalf("SetAgentStatus: %v", err)
+	}
+	if agent.Status != AgentOffline || agent.Record.CompletedTasks != 3 {
+		t.Errorf("agent = %+v", agent)
+	}
+	if body := api.last().Body; body["status"] != AgentOffline {
+		t.Errorf("body = %v", body)
+	}
+
+	_, err = client.SetAgentStatus(context.Background(), "nobody", AgentBusy)
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
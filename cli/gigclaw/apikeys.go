@@ -0,0 +1,161 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// API key permission actions
+const (
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionDelete = "delete"
+	ActionAdmin  = "admin" // implies every other action
+)
+
+// AllResources is the permission resource matching every resource
+const AllResources = "*"
+
+// Permission grants actions on a resource, e.g. tasks
+type Permission struct {
+	Resource string   `json:"resource"`
+	Actions  []string `json:"actions"`
+}
+
+// RateLimit caps the requests a key may make per window
+type RateLimit struct {
+	WindowMs    int64 `json:"windowMs"`
+	MaxRequests int   `json:"maxRequests"`
+}
+
+// Window returns the rate limit window as a duration
+func (r RateLimit) Window() time.Duration {
+	return time.Duration(r.WindowMs) * time.Millisecond
+}
+
+// APIKey describes an API key. The key itself is only returned by
+// CreateAPIKey.
+type APIKey struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions"`
+	RateLimit   RateLimit    `json:"rateLimit"`
+	CreatedAt   Timestamp    `json:"createdAt"`
+	ExpiresAt   Timestamp    `json:"expiresAt"` // zero if the key never expires
+	LastUsedAt  Timestamp    `json:"lastUsedAt"`
+	UseCount    int          `json:"useCount"`
+	Active      bool         `json:"active"`
+}
+
+// NewAPIKey is a newly created API key
+type NewAPIKey struct {
+	ID          string       `json:"id"`
+	Key         string       `json:"key"` // shown only once
+	Name        string       `json:"name"`
+	AgentID     string       `json:"agentId,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	ExpiresAt   Timestamp    `json:"expiresAt"`
+}
+
+// CreateAPIKeyRequest holds the settings of a new API key. Without
+// permissions the key may read and write every resource; without a rate
+// limit it may make 100 requests a minute.
+//
+// A key created with an AgentID acts for that agent; posters need one to
+// cancel their tasks and rate the agents who did them. Anyone may create an
+// agent's first key, but further ones only with one of its keys.
+type CreateAPIKeyRequest struct {
+	Name          string       `json:"name"`              // 1-100 characters
+	AgentID       string       `json:"agentId,omitempty"` // agent the key acts for
+	Permissions   []Permission `json:"permissions,omitempty"`
+	RateLimit     *RateLimit   `json:"rateLimit,omitempty"`
+	ExpiresInDays int          `json:"expiresInDays,omitempty"` // 1-365; 0 never expires
+}
+
+// APIKeyStats is the usage of an API key
+type APIKeyStats struct {
+	KeyID      string    `json:"keyId"`
+	Name       string    `json:"name"`
+	UseCount   int       `json:"useCount"`
+	LastUsedAt Timestamp `json:"lastUsedAt"`
+	CreatedAt  Timestamp `json:"createdAt"`
+	Active     bool      `json:"active"`
+}
+
+// KeyInfo describes the API key the client authenticates with
+type KeyInfo struct {
+	Valid       bool         `json:"valid"`
+	KeyID       string       `json:"keyId"`
+	UserID      string       `json:"userId"`
+	Permissions []Permission `json:"permissions"`
+	RateLimit   RateLimit    `json:"rateLimit"`
+}
+
+// Allows reports whether permissions grant action on resource, as the API
+// checks them
+func Allows(permissions []Permission, resource, action string) bool {
+	for _, p := range permissions {
+		if p.Resource != resource && p.Resource != AllResources {
+			continue
+		}
+		for _, a := range p.Actions {
+			if a == action || a == ActionAdmin {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CreateAPIKey mints an API key owned by the caller's user
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest, opts ...RequestOption) (*NewAPIKey, error) {
+	var response struct {
+		APIKey NewAPIKey `json:"apiKey"`
+	}
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "create API key", http.MethodPost, "/api/auth/keys", header, req, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &response.APIKey, nil
+}
+
+// ListAPIKeys retrieves the caller's API keys
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var response struct {
+		Keys  []APIKey `json:"keys"`
+		Count int      `json:"count"`
+	}
+	if err := c.do(ctx, "list API keys", http.MethodGet, "/api/auth/keys", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Keys, nil
+}
+
+// RevokeAPIKey deactivates one of the caller's API keys
+func (c *Client) RevokeAPIKey(ctx context.Context, keyID string) error {
+	path := fmt.Sprintf("/api/auth/keys/%s", url.PathEscape(keyID))
+	return c.do(ctx, "revoke API key", http.MethodDelete, path, nil, nil, http.StatusOK)
+}
+
+// GetAPIKeyStats retrieves the usage of one of the caller's API keys
+func (c *Client) GetAPIKeyStats(ctx context.Context, keyID string) (*APIKeyStats, error) {
+	var stats APIKeyStats
+	path := fmt.Sprintf("/api/auth/keys/%s/stats", url.PathEscape(keyID))
+	if err := c.do(ctx, "get API key stats", http.MethodGet, path, nil, &stats, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ValidateAPIKey describes the API key the client authenticates with. It
+// fails with ErrUnauthorized when the key is missing or not recognised.
+func (c *Client) ValidateAPIKey(ctx context.Context) (*KeyInfo, error) {
+	var info KeyInfo
+	if err := c.do(ctx, "validate API key", http.MethodGet, "/api/auth/keys/validate", nil, &info, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
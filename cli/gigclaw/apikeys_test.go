@@ -0,0 +1,217 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKeyID = "key_1767225600000_k3j9x2m1q"
+
+// keyClient returns a client for api that authenticates with key
+func keyClient(t *testing.T, api *mockAPI, key string) *Client {
+	t.Helper()
+	client, err := NewClient(WithBaseURL(api.srv.URL), WithAPIKey(key), WithRetryPolicy(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/auth/keys", fixture(t, "apikeys", "create"))
+
+	key, err := api.client().CreateAPIKey(context.Background(), CreateAPIKeyRequest{
+		Name:    "worker-7",
+		AgentID: "agent-7",
+		Permissions: []Permission{
+			{Resource: "tasks", Actions: []string{ActionRead, ActionWrite}},
+			{Resource: "bids", Actions: []string{ActionWrite}},
+		},
+		RateLimit:     &RateLimit{WindowMs: 60000, MaxRequests: 30},
+		ExpiresInDays: 90,
+	}, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if key.ID != testKeyID || !strings.HasPrefix(key.Key, "gk_") || key.AgentID != "agent-7" || len(key.Permissions) != 2 ||
+		!key.ExpiresAt.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("key = %+v", key)
+	}
+	req := api.last()
+	limit, _ := req.Body["rateLimit"].(map[string]interface{})
+	perms, _ := req.Body["permissions"].([]interface{})
+	if req.Body["name"] != "worker-7" || req.Body["agentId"] != "agent-7" || req.Body["expiresInDays"] != 90.0 || limit["maxRequests"] != 30.0 || len(perms) != 2 {
+		t.Errorf("body = %v", req.Body)
+	}
+	if req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("header = %v", req.Header)
+	}
+}
+
+func TestCreateAPIKeyDefaults(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/auth/keys", fixture(t, "apikeys", "create_defaults"))
+
+	key, err := api.client().CreateAPIKey(context.Background(), CreateAPIKeyRequest{Name: "defaults"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if !key.ExpiresAt.IsZero() || !Allows(key.Permissions, "escrow", ActionWrite) {
+		t.Errorf("key = %+v", key)
+	}
+	body := api.last().Body
+	for _, field := range []string{"agentId", "permissions", "rateLimit", "expiresInDays"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("default %s sent: %v", field, body)
+		}
+	}
+}
+
+func TestCreateAPIKeyInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/auth/keys", fixture(t, "apikeys", "create_invalid"))
+
+	_, err := api.client().CreateAPIKey(context.Background(), CreateAPIKeyRequest{Name: "worker-7", ExpiresInDays: 400})
+	apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "expiresInDays" {
+		t.Errorf("details = %+v", apiErr.Details)
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/auth/keys", fixture(t, "apikeys", "list"))
+
+	keys, err := api.client().ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("ListAPIKeys: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("keys = %+v", keys)
+	}
+	if k := keys[0]; k.ID != testKeyID || k.UseCount != 7 || k.RateLimit.Window() != time.Minute ||
+		k.ExpiresAt.IsZero() || k.LastUsedAt.IsZero() {
+		t.Errorf("key = %+v", k)
+	}
+	if k := keys[1]; !k.ExpiresAt.IsZero() || !k.LastUsedAt.IsZero() || !k.Active {
+		t.Errorf("key = %+v", k)
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("DELETE /api/auth/keys/"+testKeyID, fixture(t, "apikeys", "revoke"))
+	api.on("DELETE /api/auth/keys/key_gone", fixture(t, "apikeys", "missing"))
+	client := api.client()
+
+	if err := client.RevokeAPIKey(context.Background(), testKeyID); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+	if req := api.last(); req.Method != http.MethodDelete || req.Path != "/api/auth/keys/"+testKeyID {
+		t.Errorf("request = %+v", req)
+	}
+
+	err := client.RevokeAPIKey(context.Background(), "key_gone")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestGetAPIKeyStats(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		useCount int
+		used     bool
+	}{
+		{"stats", 7, true},
+		{"stats_unused", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/auth/keys/"+testKeyID+"/stats", fixture(t, "apikeys", tt.fixture))
+
+			stats, err := api.client().GetAPIKeyStats(context.Background(), testKeyID)
+			if err != nil {
+				t.Fatalf("GetAPIKeyStats: %v", err)
+			}
+			if stats.UseCount != tt.useCount || stats.LastUsedAt.IsZero() == tt.used || stats.CreatedAt.IsZero() {
+				t.Errorf("stats = %+v", stats)
+			}
+		})
+	}
+
+	api := newMockAPI(t)
+	api.on("GET /api/auth/keys/key_gone/stats", fixture(t, "apikeys", "missing"))
+	_, err := api.client().GetAPIKeyStats(context.Background(), "key_gone")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/auth/keys/validate", fixture(t, "apikeys", "validate"))
+
+	info, err := keyClient(t, api, "gk_q8Xv2LmN4pR7tY1wZ3bC5dF6gH9jK0aS2eU4iO6lP8r").ValidateAPIKey(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateAPIKey: %v", err)
+	}
+	if !info.Valid || info.KeyID != testKeyID || info.RateLimit.MaxRequests != 100 || !Allows(info.Permissions, "tasks", ActionWrite) {
+		t.Errorf("info = %+v", info)
+	}
+	if got := api.last().Header.Get("X-API-Key"); got != "gk_q8Xv2LmN4pR7tY1wZ3bC5dF6gH9jK0aS2eU4iO6lP8r" {
+		t.Errorf("X-API-Key = %q", got)
+	}
+}
+
+func TestValidateAPIKeyErrors(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		status   int
+		sentinel error
+	}{
+		{"validate_none", http.StatusUnauthorized, ErrUnauthorized},
+		{"key_required", http.StatusUnauthorized, ErrUnauthorized},
+		{"key_expired", http.StatusForbidden, ErrForbidden},
+		{"rate_limited", http.StatusTooManyRequests, ErrRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/auth/keys/validate", fixture(t, "apikeys", tt.fixture))
+
+			_, err := api.client().ValidateAPIKey(context.Background())
+			apiErr := wantAPIError(t, err, tt.status, tt.sentinel)
+			if tt.status == http.StatusTooManyRequests && apiErr.RetryAfter != time.Minute {
+				t.Errorf("retry after = %v, want 1m", apiErr.RetryAfter)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	perms := []Permission{
+		{Resource: "tasks", Actions: []string{ActionRead, ActionWrite}},
+		{Resource: "disputes", Actions: []string{ActionAdmin}},
+	}
+	tests := []struct {
+		resource, action string
+		want             bool
+	}{
+		{"tasks", ActionWrite, true},
+		{"tasks", ActionDelete, false},
+		{"disputes", ActionDelete, true},
+		{"escrow", ActionRead, false},
+	}
+	for _, tt := range tests {
+		if got := Allows(perms, tt.resource, tt.action); got != tt.want {
+			t.Errorf("Allows(%s, %s) = %v, want %v", tt.resource, tt.action, got, tt.want)
+		}
+	}
+
+	all := []Permission{{Resource: AllResources, Actions: []string{ActionRead}}}
+	if !Allows(all, "escrow", ActionRead) || Allows(all, "escrow", ActionWrite) {
+		t.Error("wildcard resource mismatch")
+	}
+}
@@ -0,0 +1,38 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Proposal is a bid submitted through the bids API, which carries an
+// estimate and the skills the agent brings
+type Proposal struct {
+	ID             string    `json:"id"`
+	TaskID         string    `json:"taskId"`
+	AgentID        string    `json:"agentId"`
+	ProposedPrice  float64   `json:"proposedPrice"`
+	EstimatedHours int       `json:"estimatedHours,omitempty"`
+	RelevantSkills []string  `json:"relevantSkills,omitempty"`
+	Message        string    `json:"message"`
+	Status         string    `json:"status"` // pending, accepted, rejected
+	CreatedAt      Timestamp `json:"createdAt"`
+}
+
+// ListProposalsResponse represents the API response for listing proposals
+type ListProposalsResponse struct {
+	Bids  []Proposal `json:"bids"`
+	Count int        `json:"count"`
+}
+
+// ListTaskProposals retrieves the proposals submitted for a task
+func (c *Client) ListTaskProposals(ctx context.Context, taskID string) ([]Proposal, error) {
+	var response ListProposalsResponse
+	path := fmt.Sprintf("/api/bids/task/%s", url.PathEscape(taskID))
+	if err := c.do(ctx, "list bids", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Bids, nil
+}
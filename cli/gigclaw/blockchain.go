@@ -0,0 +1,81 @@
+package gigclaw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransactionStatus is the on-chain confirmation state of a transaction
+type TransactionStatus struct {
+	Signature string          `json:"signature"`
+	Status    string          `json:"status"` // processed, confirmed, finalized or unknown
+	Err       json.RawMessage `json:"err,omitempty"`
+	Explorer  string          `json:"explorer"`
+}
+
+// Failed reports whether the transaction landed with an error
+func (s *TransactionStatus) Failed() bool {
+	return len(s.Err) > 0 && string(s.Err) != "null"
+}
+
+// VerifyTransaction looks up a transaction signature on chain
+func (c *Client) VerifyTransaction(ctx context.Context, signature string) (*TransactionStatus, error) {
+	var status TransactionStatus
+	path := fmt.Sprintf("/api/blockchain/verify/%s", url.PathEscape(signature))
+	if err := c.do(ctx, "verify transaction", http.MethodGet, path, nil, &status, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ChainStatus is the state of the GigClaw program on chain
+type ChainStatus struct {
+	Status       string `json:"status"` // active, not_deployed or error
+	ProgramID    string `json:"programId"`
+	Network      string `json:"network"`
+	OnChainTasks int    `json:"onChainTasks"`
+	Message      string `json:"message,omitempty"`
+}
+
+// Active reports whether the program is deployed and reachable
+func (s *ChainStatus) Active() bool {
+	return s.Status == "active"
+}
+
+// GetChainStatus retrieves the state of the program on chain
+func (c *Client) GetChainStatus(ctx context.Context) (*ChainStatus, error) {
+	var status ChainStatus
+	if err := c.do(ctx, "get chain status", http.MethodGet, "/api/blockchain/status", nil, &status, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// OnChainReputation is an agent's reputation account on chain
+type OnChainReputation struct {
+	Agent          string  `json:"agent"`   // wallet address
+	Account        string  `json:"account"` // reputation account address
+	CompletedTasks int     `json:"completedTasks"`
+	FailedTasks    int     `json:"failedTasks"`
+	TotalEarned    float64 `json:"totalEarned"` // USDC
+	SuccessRate    float64 `json:"successRate"` // percent
+	RatingSum      int     `json:"ratingSum"`
+	RatingCount    int     `json:"ratingCount"`
+	Rating         float64 `json:"rating"` // average stars, 0 when unrated
+	Network        string  `json:"network"`
+	Explorer       string  `json:"explorer"`
+}
+
+// GetOnChainReputation reads the reputation account of an agent's wallet.
+// It returns ErrNotFound when the account was never initialized.
+func (c *Client) GetOnChainReputation(ctx context.Context, wallet string) (*OnChainReputation, error) {
+	var rep OnChainReputation
+	path := fmt.Sprintf("/api/blockchain/reputation/%s", url.PathEscape(wallet))
+	if err := c.do(ctx, "get on-chain reputation", http.MethodGet, path, nil, &rep, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &rep, nil
+}
@@ -0,0 +1,99 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const testSignature = "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"
+
+func TestGetChainStatus(t *testing.T) {
+	tests := []struct {
+		fixture string
+		active  bool
+		network string
+	}{
+		{"status", true, "devnet"},
+		// The network is only in the deployment block, which is not decoded
+		{"status_not_deployed", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/blockchain/status", fixture(t, "blockchain", tt.fixture))
+
+			status, err := api.client().GetChainStatus(context.Background())
+			if err != nil {
+				t.Fatalf("GetChainStatus: %v", err)
+			}
+			if status.Active() != tt.active || status.Network != tt.network {
+				t.Errorf("status = %+v", status)
+			}
+			if tt.active && (status.OnChainTasks != 2 || status.ProgramID == "") {
+				t.Errorf("status = %+v", status)
+			}
+			if !tt.active && status.Message != "Program not found" {
+				t.Errorf("status = %+v", status)
+			}
+		})
+	}
+}
+
+func TestGetChainStatusError(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/blockchain/status", fixture(t, "blockchain", "status_error"))
+
+	_, err := api.client().GetChainStatus(context.Background())
+	wantAPIError(t, err, http.StatusInternalServerError, ErrServer)
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	tests := []struct {
+		fixture string
+		status  string
+		failed  bool
+	}{
+		{"verify", "finalized", false},
+		{"verify_failed", "confirmed", true},
+		{"verify_unknown", "unknown", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/blockchain/verify/"+testSignature, fixture(t, "blockchain", tt.fixture))
+
+			tx, err := api.client().VerifyTransaction(context.Background(), testSignature)
+			if err != nil {
+				t.Fatalf("VerifyTransaction: %v", err)
+			}
+			if tx.Signature != testSignature || tx.Status != tt.status || tx.Failed() != tt.failed || tx.Explorer == "" {
+				t.Errorf("transaction = %+v", tx)
+			}
+		})
+	}
+}
+
+func TestGetOnChainReputation(t *testing.T) {
+	const wallet = "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+	api := newMockAPI(t)
+	api.on("GET /api/blockchain/reputation/"+wallet, fixture(t, "blockchain", "reputation"))
+	api.on("GET /api/blockchain/reputation/uninitialized", fixture(t, "blockchain", "reputation_missing"))
+	api.on("GET /api/blockchain/reputation/not-a-key", fixture(t, "blockchain", "reputation_invalid"))
+	client := api.client()
+
+	rep, err := client.GetOnChainReputation(context.Background(), wallet)
+	if err != nil {
+		t.Fatalf("GetOnChainReputation: %v", err)
+	}
+	// The API never writes the account; it holds what the program recorded
+	if rep.Agent != wallet || rep.Account == "" || rep.CompletedTasks != 0 || rep.RatingSum != 9 ||
+		rep.RatingCount != 2 || rep.Rating != 4.5 || rep.Network != "devnet" {
+		t.Errorf("on-chain reputation = %+v", rep)
+	}
+
+	_, err = client.GetOnChainReputation(context.Background(), "uninitialized")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+	_, err = client.GetOnChainReputation(context.Background(), "not-a-key")
+	wantAPIError(t, err, http.StatusBadRequest, ErrBadRequest)
+}
@@ -0,0 +1,275 @@
+// Package gigclaw is a Go client for the GigClaw agent marketplace API.
+//
+// Create a client with NewClient and functional options:
+//
+//	client, err := gigclaw.NewClient(
+//		gigclaw.WithAPIKey(os.Getenv("GIGCLAW_API_KEY")),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	tasks, err := client.ListTasks(ctx)
+//
+// Every method takes a context.Context and returns typed errors; API
+// failures are reported as *APIError.
+package gigclaw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the production GigClaw API
+const DefaultBaseURL = "https://gigclaw-production.up.railway.app"
+
+// Logger receives debug output from the client
+type Logger interface {
+	Debug(msg string, fields ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+
+// Client handles API communication with retry logic
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	retry      RetryPolicy
+	logger     Logger
+
+	// sleep waits between retries; replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithBaseURL sets the API base URL (default DefaultBaseURL)
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithAPIKey sets the API key sent as a bearer token
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithHTTPClient sets the underlying HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy sets the retry policy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithLogger sets the logger used for debug output
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// NewClient creates a new API client
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+		logger:     nopLogger{},
+		sleep:      sleepContext,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.baseURL == "" {
+		return nil, fmt.Errorf("gigclaw: base URL is required")
+	}
+	if c.httpClient == nil {
+		return nil, fmt.Errorf("gigclaw: HTTP client is required")
+	}
+	if err := c.retry.validate(); err != nil {
+		return nil, err
+	}
+	if c.logger == nil {
+		c.logger = nopLogger{}
+	}
+
+	return c, nil
+}
+
+// BaseURL returns the API base URL the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// doRequest makes an HTTP request, retrying according to the client's
+// RetryPolicy. The body is replayed in full on every attempt.
+func (c *Client) doRequest(ctx context.Context, method, path string, header http.Header, body []byte) (*http.Response, error) {
+	url := c.baseURL + path
+	c.logger.Debug("Making request", method, url)
+
+	policy := c.retry
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(ctx, method, url, header, body)
+		if ctx.Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, ctx.Err()
+		}
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			lastErr = err
+			c.logger.Debug(fmt.Sprintf("Request failed: %v", err))
+			if attempt >= policy.MaxRetries {
+				return nil, err
+			}
+			if !idempotentMethod(method) && !safeToRetryError(err) {
+				// The request may have reached the server
+				return nil, err
+			}
+			wait = policy.backoff(attempt + 1)
+
+		case !retryableStatus(resp.StatusCode):
+			return resp, nil
+
+		default:
+			if attempt >= policy.MaxRetries {
+				return resp, nil
+			}
+			if !idempotentMethod(method) && !safeToRetryStatus(resp.StatusCode) {
+				return resp, nil
+			}
+
+			wait = policy.backoff(attempt + 1)
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && safeToRetryStatus(resp.StatusCode) {
+				if policy.MaxRetryAfter > 0 && retryAfter > policy.MaxRetryAfter {
+					// Too long to wait; let the caller decide
+					return resp, nil
+				}
+				if retryAfter > wait {
+					wait = retryAfter
+				}
+			}
+
+			lastErr = fmt.Errorf("server returned %s", resp.Status)
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBody))
+			resp.Body.Close()
+		}
+
+		c.logger.Debug(fmt.Sprintf("Retry attempt %d/%d after %v (%v)", attempt+1, policy.MaxRetries, wait, lastErr))
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends a single request, bounded by the per-attempt timeout
+func (c *Client) attempt(ctx context.Context, method, url string, header http.Header, body []byte) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if c.retry.PerAttemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.retry.PerAttemptTimeout)
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		// API key routes read X-API-Key; keep Authorization for proxies
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Keep the attempt context alive until the caller has read the body
+	resp.Body = cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// do sends a request and decodes a JSON response into out. Any status
+// outside expected is returned as an *APIError.
+func (c *Client) do(ctx context.Context, op, method, path string, in, out interface{}, expected ...int) error {
+	return c.doWithHeader(ctx, op, method, path, nil, in, out, expected...)
+}
+
+// doWithHeader is do with extra request headers
+func (c *Client) doWithHeader(ctx context.Context, op, method, path string, header http.Header, in, out interface{}, expected ...int) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s request: %w", op, err)
+		}
+	}
+
+	resp, err := c.doRequest(ctx, method, path, header, body)
+	if err != nil {
+		return &RequestError{Op: op, Err: err}
+	}
+	defer resp.Body.Close()
+
+	ok := false
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return newAPIError(op, resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return &DecodeError{Op: op, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AgentAway is an availability only discovery profiles have, besides
+// AgentAvailable, AgentBusy and AgentOffline
+const AgentAway = "away"
+
+// Agent search sort orders
+const (
+	SortReputation = "reputation" // default
+	SortRating     = "rating"
+	SortEarnings   = "earnings"
+	SortTasks      = "tasks"
+	SortRecent     = "recent"
+)
+
+// Recommendation urgencies
+const (
+	UrgencyLow    = "low"
+	UrgencyMedium = "medium" // default
+	UrgencyHigh   = "high"
+)
+
+// AgentProfile is an agent's public discovery profile
+type AgentProfile struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Skills         []ProfileSkill    `json:"skills"`
+	Reputation     ProfileReputation `json:"reputation"`
+	Availability   Availability      `json:"availability"`
+	Rates          Rates             `json:"rates"`
+	Stats          ProfileStats      `json:"stats"`
+	CreatedAt      Timestamp         `json:"createdAt"`
+	LastActiveAt   Timestamp         `json:"lastActiveAt"`
+	Portfolio      []PortfolioItem   `json:"portfolio,omitempty"`
+	Certifications []string          `json:"certifications,omitempty"`
+	Languages      []string          `json:"languages,omitempty"`
+	Timezone       string            `json:"timezone,omitempty"`
+}
+
+// SkillNames returns the names of the profile's skills
+func (p AgentProfile) SkillNames() []string {
+	names := make([]string, 0, len(p.Skills))
+	for _, s := range p.Skills {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// ProfileSkill is a skill on a discovery profile
+type ProfileSkill struct {
+	Name         string `json:"name"`
+	Level        int    `json:"level"` // 1-100
+	Category     string `json:"category"`
+	Endorsements int    `json:"endorsements"`
+	Verified     bool   `json:"verified"`
+}
+
+// ProfileReputation is the reputation shown on a discovery profile
+type ProfileReputation struct {
+	Score          float64 `json:"score"`  // 0-100
+	Rating         float64 `json:"rating"` // 1-5
+	ReviewCount    int     `json:"reviewCount"`
+	CompletedTasks int     `json:"completedTasks"`
+	FailedTasks    int     `json:"failedTasks"`
+	TotalEarned    float64 `json:"totalEarned"`
+	SuccessRate    float64 `json:"successRate"` // percent
+}
+
+// Availability is when an agent can take work
+type Availability struct {
+	Status              string    `json:"status"` // AgentAvailable, AgentBusy, AgentAway or AgentOffline
+	NextAvailableAt     Timestamp `json:"nextAvailableAt"`
+	MaxConcurrentTasks  int       `json:"maxConcurrentTasks"`
+	CurrentWorkload     int       `json:"currentWorkload"`
+	TypicalResponseTime string    `json:"typicalResponseTime"` // e.g. "< 1 hour"
+}
+
+// Rates is what an agent charges. Zero means not stated.
+type Rates struct {
+	HourlyRate     float64 `json:"hourlyRate,omitempty"`
+	MinProjectRate float64 `json:"minProjectRate,omitempty"`
+	Currency       string  `json:"currency"`
+	Negotiable     bool    `json:"negotiable"`
+}
+
+// ProfileStats is an agent's track record on a discovery profile
+type ProfileStats struct {
+	TotalTasksCompleted int       `json:"totalTasksCompleted"`
+	TotalEarnings       float64   `json:"totalEarnings"`
+	AverageRating       float64   `json:"averageRating"`
+	OnTimeDelivery      float64   `json:"onTimeDelivery"`   // percent
+	RepeatClientRate    float64   `json:"repeatClientRate"` // percent
+	MemberSince         Timestamp `json:"memberSince"`
+}
+
+// PortfolioItem is past work shown on a discovery profile
+type PortfolioItem struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	TaskID      string    `json:"taskId,omitempty"`
+	Rating      float64   `json:"rating,omitempty"`
+	CompletedAt Timestamp `json:"completedAt"`
+}
+
+// SearchAgentsOptions filters, sorts and pages an agent search. Zero values
+// mean no filter.
+type SearchAgentsOptions struct {
+	Skills        []string // agents must have one skill or category containing one of these
+	MinReputation int      // 0-100
+	MinRating     float64  // 1-5
+	Availability  string   // AgentAvailable, AgentBusy, AgentAway or AgentOffline
+	MaxRate       float64  // hourly; agents without a rate always match
+	Category      string
+	Sort          string // SortReputation, SortRating, SortEarnings, SortTasks or SortRecent
+	Limit         int    // 1-100; 0 for the server's default of 20
+	Offset        int
+}
+
+// query encodes the options as query parameters
+func (o SearchAgentsOptions) query() url.Values {
+	q := url.Values{}
+	if len(o.Skills) > 0 {
+		q.Set("skills", strings.Join(o.Skills, ","))
+	}
+	if o.MinReputation > 0 {
+		q.Set("minReputation", strconv.Itoa(o.MinReputation))
+	}
+	if o.MinRating > 0 {
+		q.Set("minRating", strconv.FormatFloat(o.MinRating, 'f', -1, 64))
+	}
+	if o.Availability != "" {
+		q.Set("availability", o.Availability)
+	}
+	if o.MaxRate > 0 {
+		q.Set("maxRate", strconv.FormatFloat(o.MaxRate, 'f', -1, 64))
+	}
+	if o.Category != "" {
+		q.Set("category", o.Category)
+	}
+	if o.Sort != "" {
+		q.Set("sortBy", o.Sort)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return q
+}
+
+func (o SearchAgentsOptions) validate() error {
+	switch o.Sort {
+	case "", SortReputation, SortRating, SortEarnings, SortTasks, SortRecent:
+	default:
+		return fmt.Errorf("unknown sort %q", o.Sort)
+	}
+	switch o.Availability {
+	case "", AgentAvailable, AgentBusy, AgentAway, AgentOffline:
+	default:
+		return fmt.Errorf("unknown availability %q", o.Availability)
+	}
+	if o.MinReputation < 0 || o.MinReputation > 100 {
+		return fmt.Errorf("minimum reputation must be between 0 and 100")
+	}
+	if o.MinRating != 0 && (o.MinRating < 1 || o.MinRating > 5) {
+		return fmt.Errorf("minimum rating must be between 1 and 5")
+	}
+	if o.Limit < 0 || o.Offset < 0 {
+		return fmt.Errorf("limit and offset must not be negative")
+	}
+	return nil
+}
+
+// AgentSearchResult is one page of an agent search
+type AgentSearchResult struct {
+	Agents     []AgentProfile `json:"agents"`
+	Pagination struct {
+		Total   int  `json:"total"`
+		Limit   int  `json:"limit"`
+		Offset  int  `json:"offset"`
+		HasMore bool `json:"hasMore"`
+	} `json:"pagination"`
+}
+
+// SearchAgents searches discovery profiles
+func (c *Client) SearchAgents(ctx context.Context, opts SearchAgentsOptions) (*AgentSearchResult, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	path := "/api/agents/discover"
+	if q := opts.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var result AgentSearchResult
+	if err := c.do(ctx, "search agents", http.MethodGet, path, nil, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAgentProfile retrieves an agent's discovery profile
+func (c *Client) GetAgentProfile(ctx context.Context, agentID string) (*AgentProfile, error) {
+	var response struct {
+		Agent AgentProfile `json:"agent"`
+	}
+	path := fmt.Sprintf("/api/agents/discover/%s", url.PathEscape(agentID))
+	if err := c.do(ctx, "get agent profile", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Agent, nil
+}
+
+// AgentComparison compares agents side by side
+type AgentComparison struct {
+	// Agents holds the agents found, in the order asked for. Their
+	// profiles carry no description, portfolio or timestamps.
+	Agents []AgentProfile `json:"agents"`
+	Best   struct {
+		HighestRated    AgentProfile `json:"highestRated"`
+		MostExperienced AgentProfile `json:"mostExperienced"` // most tasks completed
+		MostAffordable  AgentProfile `json:"mostAffordable"`  // lowest hourly rate
+		BestSuccessRate AgentProfile `json:"bestSuccessRate"`
+	} `json:"comparison"`
+}
+
+// CompareAgents compares agents. IDs that are not found are left out;
+// ErrNotFound is returned only when none are found.
+func (c *Client) CompareAgents(ctx context.Context, agentIDs []string) (*AgentComparison, error) {
+	if len(agentIDs) == 0 {
+		return nil, fmt.Errorf("no agents to compare")
+	}
+	var comparison AgentComparison
+	path := "/api/agents/discover/compare?" + url.Values{"ids": {strings.Join(agentIDs, ",")}}.Encode()
+	if err := c.do(ctx, "compare agents", http.MethodPost, path, nil, &comparison, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &comparison, nil
+}
+
+// TopAgents retrieves the agents with the best reputation that have a skill
+// in category. limit is 1-50; 0 for the server's default of 10.
+func (c *Client) TopAgents(ctx context.Context, category string, limit int) ([]AgentProfile, error) {
+	var response struct {
+		Agents []AgentProfile `json:"agents"`
+	}
+	path := fmt.Sprintf("/api/agents/discover/top/%s", url.PathEscape(category))
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	if err := c.do(ctx, "top agents", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Agents, nil
+}
+
+// RecommendAgentsRequest describes the work agents are recommended for
+type RecommendAgentsRequest struct {
+	Skills  []string // required
+	Budget  float64  // hourly; 0 for none
+	Urgency string   // UrgencyLow, UrgencyMedium or UrgencyHigh
+	Limit   int      // 1-20; 0 for the server's default of 5
+}
+
+// AgentRecommendation is an agent recommended for work
+type AgentRecommendation struct {
+	Agent        AgentProfile `json:"agent"`
+	MatchScore   int          `json:"matchScore"` // 0-100
+	MatchReasons []string     `json:"matchReasons"`
+}
+
+// RecommendAgents recommends the agents that fit work best
+func (c *Client) RecommendAgents(ctx context.Context, req RecommendAgentsRequest) ([]AgentRecommendation, error) {
+	if len(req.Skills) == 0 {
+		return nil, fmt.Errorf("skills are required")
+	}
+	switch req.Urgency {
+	case "", UrgencyLow, UrgencyMedium, UrgencyHigh:
+	default:
+		return nil, fmt.Errorf("unknown urgency %q", req.Urgency)
+	}
+
+	q := url.Values{"skills": {strings.Join(req.Skills, ",")}}
+	if req.Budget > 0 {
+		q.Set("budget", strconv.FormatFloat(req.Budget, 'f', -1, 64))
+	}
+	if req.Urgency != "" {
+		q.Set("urgency", req.Urgency)
+	}
+	if req.Limit > 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	var response struct {
+		Recommendations []AgentRecommendation `json:"recommendations"`
+	}
+	path := "/api/agents/discover/recommend?" + q.Encode()
+	if err := c.do(ctx, "recommend agents", http.MethodPost, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Recommendations, nil
+}
+
+// AgentCategory is a skill category and how many agents have it
+type AgentCategory struct {
+	Name      string   `json:"name"`
+	Count     int      `json:"count"`
+	TopAgents []string `json:"topAgents"` // names of up to three agents
+}
+
+// ListAgentCategories retrieves the skill categories of discovery profiles
+func (c *Client) ListAgentCategories(ctx context.Context) ([]AgentCategory, error) {
+	var response struct {
+		Categories []AgentCategory `json:"categories"`
+	}
+	if err := c.do(ctx, "list agent categories", http.MethodGet, "/api/agents/discover/categories", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Categories, nil
+}
+
+// DiscoveryStats summarizes the agents that can be discovered
+type DiscoveryStats struct {
+	TotalAgents         int     `json:"totalAgents"`
+	AvailableNow        int     `json:"availableNow"`
+	AverageRating       float64 `json:"averageRating"`
+	TotalTasksCompleted int     `json:"totalTasksCompleted"`
+	TotalEarnings       float64 `json:"totalEarnings"`
+	TopSkills           []struct {
+		Name     string `json:"name"`
+		Count    int    `json:"count"`
+		AvgLevel int    `json:"avgLevel"`
+	} `json:"topSkills"`
+	AvailabilityBreakdown map[string]int `json:"availabilityBreakdown"` // agents per availability
+}
+
+// GetDiscoveryStats retrieves statistics on discovery profiles
+func (c *Client) GetDiscoveryStats(ctx context.Context) (*DiscoveryStats, error) {
+	var response struct {
+		Stats DiscoveryStats `json:"stats"`
+	}
+	if err := c.do(ctx, "get discovery stats", http.MethodGet, "/api/agents/discover/stats/overview", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Stats, nil
+}
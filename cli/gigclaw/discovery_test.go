@@ -0,0 +1,249 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearchAgents(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/discover", fixture(t, "discovery", "search"))
+
+	result, err := api.client().SearchAgents(context.Background(), SearchAgentsOptions{
+		Skills:        []string{"rust", "solana"},
+		MinReputation: 80,
+		MinRating:     4.5,
+		Availability:  AgentAway,
+		MaxRate:       60,
+		Sort:          SortRating,
+		Limit:         1,
+	})
+	if err != nil {
+		t.Fatalf("SearchAgents: %v", err)
+	}
+	want := url.Values{"skills": {"rust,solana"}, "minReputation": {"80"}, "minRating": {"4.5"}, "availability": {"away"},
+		"maxRate": {"60"}, "sortBy": {"rating"}, "limit": {"1"}}
+	if q := api.last().Query; !reflect.DeepEqual(q, want) {
+		t.Errorf("query = %v, want %v", q, want)
+	}
+	if result.Pagination.Total != 2 || result.Pagination.Limit != 1 || !result.Pagination.HasMore || len(result.Agents) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	a := result.Agents[0]
+	if a.ID != "agent-7" || a.Availability.Status != AgentAway || a.Rates.HourlyRate != 45 || a.Reputation.SuccessRate != 97.5 ||
+		!reflect.DeepEqual(a.SkillNames(), []string{"rust", "security"}) {
+		t.Errorf("profile = %+v", a)
+	}
+	if !a.Availability.NextAvailableAt.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)) ||
+		!a.Stats.MemberSince.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) ||
+		len(a.Portfolio) != 1 || a.Portfolio[0].TaskID != testTaskID || a.Portfolio[0].CompletedAt.IsZero() {
+		t.Errorf("profile = %+v", a)
+	}
+}
+
+func TestSearchAgentsEmpty(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/discover", fixture(t, "discovery", "search_empty"))
+
+	result, err := api.client().SearchAgents(context.Background(), SearchAgentsOptions{})
+	if err != nil {
+		t.Fatalf("SearchAgents: %v", err)
+	}
+	if len(result.Agents) != 0 || result.Pagination.HasMore {
+		t.Errorf("result = %+v", result)
+	}
+	if q := api.last().Query; len(q) != 0 {
+		t.Errorf("query = %v, want none", q)
+	}
+}
+
+func TestSearchAgentsInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/discover", fixture(t, "discovery", "search_invalid"))
+	client := api.client()
+
+	// Options the server would reject are refused without a request
+	for _, opts := range []SearchAgentsOptions{
+		{Sort: "cheapest"},
+		{Availability: "asleep"},
+		{MinReputation: 101},
+		{MinRating: 0.5},
+		{Limit: -1},
+	} {
+		if _, err := client.SearchAgents(context.Background(), opts); err == nil {
+			t.Errorf("SearchAgents(%+v): want error", opts)
+		}
+	}
+	if n := api.count(); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+
+	_, err := client.SearchAgents(context.Background(), SearchAgentsOptions{Limit: 500})
+	apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "limit" {
+		t.Errorf("details = %+v", apiErr.Details)
+	}
+}
+
+func TestGetAgentProfile(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/discover/agent-7", fixture(t, "discovery", "profile"))
+	api.on("GET /api/agents/discover/nobody", fixture(t, "discovery", "profile_missing"))
+	client := api.client()
+
+	profile, err := client.GetAgentProfile(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("GetAgentProfile: %v", err)
+	}
+	if profile.Name != "Auditor" || profile.Description == "" || profile.Timezone != "UTC" ||
+		!reflect.DeepEqual(profile.Languages, []string{"en"}) || profile.Stats.OnTimeDelivery != 95 {
+		t.Errorf("profile = %+v", profile)
+	}
+
+	_, err = client.GetAgentProfile(context.Background(), "nobody")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestCompareAgents(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/agents/discover/compare", fixture(t, "discovery", "compare"))
+
+	comparison, err := api.client().CompareAgents(context.Background(), []string{"agent-7", "agent-9"})
+	if err != nil {
+		t.Fatalf("CompareAgents: %v", err)
+	}
+	if req := api.last(); req.Query.Get("ids") != "agent-7,agent-9" || req.Body != nil {
+		t.Errorf("request = %+v", req)
+	}
+	if len(comparison.Agents) != 2 || comparison.Agents[1].ID != "agent-9" || !comparison.Agents[0].CreatedAt.IsZero() {
+		t.Errorf("agents = %+v", comparison.Agents)
+	}
+	best := comparison.Best
+	if best.HighestRated.ID != "agent-7" || best.MostExperienced.ID != "agent-7" ||
+		best.MostAffordable.ID != "agent-9" || best.BestSuccessRate.ID != "agent-9" {
+		t.Errorf("best = %+v", best)
+	}
+}
+
+func TestCompareAgentsMissing(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/agents/discover/compare", fixture(t, "discovery", "compare_missing"))
+	client := api.client()
+
+	if _, err := client.CompareAgents(context.Background(), nil); err == nil {
+		t.Error("CompareAgents with no IDs: want error")
+	}
+	_, err := client.CompareAgents(context.Background(), []string{"nobody", "ghost"})
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestTopAgents(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/discover/top/smart contracts", fixture(t, "discovery", "top"))
+	client := api.client()
+
+	top, err := client.TopAgents(context.Background(), "smart contracts", 3)
+	if err != nil {
+		t.Fatalf("TopAgents: %v", err)
+	}
+	if len(top) != 1 || top[0].ID != "agent-7" {
+		t.Errorf("top = %+v", top)
+	}
+	if q := api.last().Query; q.Get("limit") != "3" {
+		t.Errorf("query = %v", q)
+	}
+
+	if _, err := client.TopAgents(context.Background(), "smart contracts", 0); err != nil {
+		t.Fatalf("TopAgents: %v", err)
+	}
+	if q := api.last().Query; len(q) != 0 {
+		t.Errorf("query = %v, want the server's default limit", q)
+	}
+
+	api.on("GET /api/agents/discover/top/smart contracts", fixture(t, "discovery", "top_invalid"))
+	_, err = client.TopAgents(context.Background(), "smart contracts", 80)
+	wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+}
+
+func TestRecommendAgents(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/agents/discover/recommend", fixture(t, "discovery", "recommend"))
+
+	recs, err := api.client().RecommendAgents(context.Background(), RecommendAgentsRequest{
+		Skills: []string{"rust"}, Budget: 50, Urgency: UrgencyHigh, Limit: 2,
+	})
+	if err != nil {
+		t.Fatalf("RecommendAgents: %v", err)
+	}
+	want := url.Values{"skills": {"rust"}, "budget": {"50"}, "urgency": {"high"}, "limit": {"2"}}
+	if q := api.last().Query; !reflect.DeepEqual(q, want) {
+		t.Errorf("query = %v, want %v", q, want)
+	}
+	if len(recs) != 2 || recs[0].Agent.ID != "agent-7" || recs[0].MatchScore != 81 || len(recs[0].MatchReasons) != 3 {
+		t.Errorf("recommendations = %+v", recs)
+	}
+}
+
+func TestRecommendAgentsInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/agents/discover/recommend", fixture(t, "discovery", "recommend_invalid"))
+	client := api.client()
+
+	for _, req := range []RecommendAgentsRequest{{}, {Skills: []string{"rust"}, Urgency: "asap"}} {
+		if _, err := client.RecommendAgents(context.Background(), req); err == nil {
+			t.Errorf("RecommendAgents(%+v): want error", req)
+		}
+	}
+	if n := api.count(); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+
+	_, err := client.RecommendAgents(context.Background(), RecommendAgentsRequest{Skills: []string{"rust"}, Limit: 40})
+	wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+}
+
+func TestListAgentCategories(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/agents/discover/categories", fixture(t, "discovery", "categories"))
+
+	categories, err := api.client().ListAgentCategories(context.Background())
+	if err != nil {
+		t.Fatalf("ListAgentCategories: %v", err)
+	}
+	if len(categories) != 3 || categories[0].Name != "smart contracts" || categories[0].Count != 1 ||
+		!reflect.DeepEqual(categories[2].TopAgents, []string{"Writer"}) {
+		t.Errorf("categories = %+v", categories)
+	}
+}
+
+func TestGetDiscoveryStats(t *testing.T) {
+	tests := []struct {
+		fixture string
+		agents  int
+		skills  int
+	}{
+		{"stats", 2, 3},
+		{"stats_empty", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/agents/discover/stats/overview", fixture(t, "discovery", tt.fixture))
+
+			stats, err := api.client().GetDiscoveryStats(context.Background())
+			if err != nil {
+				t.Fatalf("GetDiscoveryStats: %v", err)
+			}
+			if stats.TotalAgents != tt.agents || len(stats.TopSkills) != tt.skills || len(stats.AvailabilityBreakdown) != 4 {
+				t.Errorf("stats = %+v", stats)
+			}
+			if tt.agents > 0 && (stats.AvailabilityBreakdown[AgentAway] != 1 || stats.TopSkills[0].AvgLevel != 90 || stats.AverageRating != 4.4) {
+				t.Errorf("stats = %+v", stats)
+			}
+		})
+	}
+}
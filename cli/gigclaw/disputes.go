@@ -0,0 +1,254 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Dispute statuses
+const (
+	DisputeOpen        = "open"
+	DisputeUnderReview = "under_review"
+	DisputeResolved    = "resolved"
+)
+
+// Dispute resolutions, deciding where the escrowed funds go
+const (
+	ResolutionRefundPoster = "refund_poster"
+	ResolutionPayAgent     = "pay_agent"
+	ResolutionSplit        = "split"
+)
+
+// Evidence types
+const (
+	EvidenceMessage    = "message"
+	EvidenceDelivery   = "delivery"
+	EvidenceScreenshot = "screenshot"
+	EvidenceOther      = "other"
+)
+
+// Limits enforced by the API
+const (
+	MinDisputeReason    = 10
+	MaxDisputeReason    = 500
+	MaxEvidenceContent  = 2000
+	MaxResolutionReason = 1000
+)
+
+// Dispute is a disagreement over a task, decided by an arbitrator
+type Dispute struct {
+	ID               string     `json:"id"`
+	TaskID           string     `json:"taskId"`
+	InitiatorID      string     `json:"initiatorId"`
+	RespondentID     string     `json:"respondentId"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"` // open, under_review, resolved
+	Resolution       string     `json:"resolution,omitempty"`
+	ArbitratorID     string     `json:"arbitratorId,omitempty"`
+	ResolutionReason string     `json:"resolutionReason,omitempty"`
+	CreatedAt        Timestamp  `json:"createdAt"`
+	ResolvedAt       Timestamp  `json:"resolvedAt"`
+	Evidence         []Evidence `json:"evidence"`
+}
+
+// Evidence is a submission by one of the parties to a dispute
+type Evidence struct {
+	PartyID     string    `json:"partyId"`
+	Type        string    `json:"type"` // message, delivery, screenshot, other
+	Content     string    `json:"content"`
+	SubmittedAt Timestamp `json:"submittedAt"`
+}
+
+// DisputeEvent is a state change in a dispute's history
+type DisputeEvent struct {
+	Time    time.Time
+	Kind    string // opened, evidence or resolved
+	PartyID string // who acted
+	Detail  string
+}
+
+// Timeline returns the dispute's state changes, oldest first
+func (d *Dispute) Timeline() []DisputeEvent {
+	events := []DisputeEvent{{Time: d.CreatedAt.Time, Kind: "opened", PartyID: d.InitiatorID, Detail: d.Reason}}
+	for _, e := range d.Evidence {
+		events = append(events, DisputeEvent{Time: e.SubmittedAt.Time, Kind: "evidence", PartyID: e.PartyID, Detail: e.Type})
+	}
+	if d.Status == DisputeResolved {
+		detail := d.Resolution
+		if d.ResolutionReason != "" {
+			detail += ": " + d.ResolutionReason
+		}
+		events = append(events, DisputeEvent{Time: d.ResolvedAt.Time, Kind: "resolved", PartyID: d.ArbitratorID, Detail: detail})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
+
+// OpenDisputeRequest holds the fields of a new dispute
+type OpenDisputeRequest struct {
+	TaskID       string `json:"taskId"`
+	InitiatorID  string `json:"initiatorId"`
+	RespondentID string `json:"respondentId"`
+	Reason       string `json:"reason"` // 10-500 characters
+}
+
+// EvidenceRequest holds a piece of evidence to submit
+type EvidenceRequest struct {
+	PartyID string `json:"partyId"`
+	Type    string `json:"type"`
+	Content string `json:"content"` // at most 2000 characters
+}
+
+// ResolveDisputeRequest holds an arbitrator's decision
+type ResolveDisputeRequest struct {
+	ArbitratorID string `json:"arbitratorId"`
+	Resolution   string `json:"resolution"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// DisputeResponse represents the API response for a dispute change
+type DisputeResponse struct {
+	Message string  `json:"message"`
+	Dispute Dispute `json:"dispute"`
+}
+
+// DisputeFilter narrows ListDisputes. Empty fields match everything.
+type DisputeFilter struct {
+	Status      string
+	TaskID      string
+	InitiatorID string
+}
+
+// ListDisputesResponse represents the API response for listing disputes
+type ListDisputesResponse struct {
+	Disputes []Dispute `json:"disputes"`
+	Count    int       `json:"count"`
+	Total    int       `json:"total"` // before filtering
+}
+
+// AgentDisputes are the disputes an agent is a party to
+type AgentDisputes struct {
+	AgentID  string    `json:"agentId"`
+	Disputes []Dispute `json:"disputes"`
+	Stats    struct {
+		Total      int `json:"total"`
+		Initiated  int `json:"initiated"`
+		Respondent int `json:"respondent"`
+		Open       int `json:"open"`
+		Resolved   int `json:"resolved"`
+	} `json:"stats"`
+}
+
+// DisputeStats summarises all disputes on the marketplace
+type DisputeStats struct {
+	Total       int `json:"total"`
+	Open        int `json:"open"`
+	UnderReview int `json:"underReview"`
+	Resolved    int `json:"resolved"`
+	Resolutions struct {
+		RefundPoster int `json:"refundPoster"`
+		PayAgent     int `json:"payAgent"`
+		Split        int `json:"split"`
+	} `json:"resolutions"`
+	AverageResolutionMs *int64 `json:"averageResolutionTime"` // null until one is resolved
+}
+
+// AverageResolutionTime returns the mean time to resolve a dispute, or 0
+// if none has been resolved
+func (s DisputeStats) AverageResolutionTime() time.Duration {
+	if s.AverageResolutionMs == nil {
+		return 0
+	}
+	return time.Duration(*s.AverageResolutionMs) * time.Millisecond
+}
+
+// OpenDispute raises a dispute over a task. The API allows one unresolved
+// dispute per task; a second one fails with ErrConflict.
+func (c *Client) OpenDispute(ctx context.Context, req OpenDisputeRequest, opts ...RequestOption) (*Dispute, error) {
+	var response DisputeResponse
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "open dispute", http.MethodPost, "/api/disputes", header, req, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &response.Dispute, nil
+}
+
+// SubmitEvidence adds evidence to an unresolved dispute. Only the
+// initiator and the respondent may submit evidence.
+func (c *Client) SubmitEvidence(ctx context.Context, disputeID string, req EvidenceRequest, opts ...RequestOption) (*Dispute, error) {
+	return c.updateDispute(ctx, "submit evidence", disputeID, "evidence", req, opts)
+}
+
+// ResolveDispute decides a dispute, directing the escrowed funds
+func (c *Client) ResolveDispute(ctx context.Context, disputeID string, req ResolveDisputeRequest, opts ...RequestOption) (*Dispute, error) {
+	return c.updateDispute(ctx, "resolve dispute", disputeID, "resolve", req, opts)
+}
+
+// updateDispute posts an action to /api/disputes/:id/<action>
+func (c *Client) updateDispute(ctx context.Context, op, disputeID, action string, payload interface{}, opts []RequestOption) (*Dispute, error) {
+	var response DisputeResponse
+	path := fmt.Sprintf("/api/disputes/%s/%s", url.PathEscape(disputeID), action)
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, op, http.MethodPost, path, header, payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Dispute, nil
+}
+
+// GetDispute retrieves a dispute with its evidence
+func (c *Client) GetDispute(ctx context.Context, disputeID string) (*Dispute, error) {
+	var response struct {
+		Dispute Dispute `json:"dispute"`
+	}
+	path := fmt.Sprintf("/api/disputes/%s", url.PathEscape(disputeID))
+	if err := c.do(ctx, "get dispute", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Dispute, nil
+}
+
+// ListDisputes retrieves disputes, newest first
+func (c *Client) ListDisputes(ctx context.Context, filter DisputeFilter) ([]Dispute, error) {
+	query := url.Values{}
+	for k, v := range map[string]string{"status": filter.Status, "taskId": filter.TaskID, "initiatorId": filter.InitiatorID} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	path := "/api/disputes"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var response ListDisputesResponse
+	if err := c.do(ctx, "list disputes", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Disputes, nil
+}
+
+// ListAgentDisputes retrieves the disputes an agent initiated or
+// responds to, newest first
+func (c *Client) ListAgentDisputes(ctx context.Context, agentID string) (*AgentDisputes, error) {
+	var response AgentDisputes
+	path := fmt.Sprintf("/api/disputes/agent/%s", url.PathEscape(agentID))
+	if err := c.do(ctx, "list agent disputes", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetDisputeStats retrieves marketplace-wide dispute statistics
+func (c *Client) GetDisputeStats(ctx context.Context) (*DisputeStats, error) {
+	var response struct {
+		Stats DisputeStats `json:"stats"`
+	}
+	if err := c.do(ctx, "get dispute stats", http.MethodGet, "/api/disputes/stats/overview", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Stats, nil
+}
@@ -0,0 +1,211 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testDisputeID = "dispute-1767225600000-k3j9x2m1q"
+
+func TestOpenDispute(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/disputes", fixture(t, "disputes", "open"))
+	client := api.client()
+
+	d, err := client.OpenDispute(context.Background(),
+		OpenDisputeRequest{TaskID: "task1a2b3c4d5e6f", InitiatorID: "alice", RespondentID: "bob", Reason: "Never delivered"},
+		IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("OpenDispute: %v", err)
+	}
+	if d.ID != testDisputeID || d.Status != DisputeOpen || !d.CreatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("dispute = %+v", d)
+	}
+	req := api.last()
+	if req.Body["respondentId"] != "bob" || req.Body["reason"] != "Never delivered" || req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestOpenDisputeErrors(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		status   int
+		sentinel error
+	}{
+		{"open_conflict", http.StatusConflict, ErrConflict},
+		{"open_invalid", http.StatusBadRequest, ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/disputes", fixture(t, "disputes", tt.fixture))
+
+			_, err := api.client().OpenDispute(context.Background(), OpenDisputeRequest{TaskID: "task1a2b3c4d5e6f"})
+			wantAPIError(t, err, tt.status, tt.sentinel)
+		})
+	}
+}
+
+func TestSubmitEvidence(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/disputes/"+testDisputeID+"/evidence", fixture(t, "disputes", "evidence"))
+	client := api.client()
+
+	d, err := client.SubmitEvidence(context.Background(), testDisputeID, EvidenceRequest{PartyID: "alice", Type: EvidenceMessage, Content: "log"})
+	if err != nil {
+		t.Fatalf("SubmitEvidence: %v", err)
+	}
+	if len(d.Evidence) != 1 || d.Evidence[0].SubmittedAt.IsZero() {
+		t.Errorf("evidence = %+v", d.Evidence)
+	}
+	if body := api.last().Body; body["partyId"] != "alice" || body["type"] != "message" {
+		t.Errorf("body = %v", body)
+	}
+
+	api.on("POST /api/disputes/"+testDisputeID+"/evidence", fixture(t, "disputes", "evidence_forbidden"))
+	_, err = client.SubmitEvidence(context.Background(), testDisputeID, EvidenceRequest{PartyID: "eve", Type: EvidenceOther, Content: "x"})
+	wantAPIError(t, err, http.StatusForbidden, ErrForbidden)
+}
+
+func TestResolveDispute(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/disputes/"+testDisputeID+"/resolve", fixture(t, "disputes", "resolve"))
+	client := api.client()
+
+	d, err := client.ResolveDispute(context.Background(), testDisputeID, ResolveDisputeRequest{ArbitratorID: "arb", Resolution: ResolutionSplit})
+	if err != nil {
+		t.Fatalf("ResolveDispute: %v", err)
+	}
+	if d.Status != DisputeResolved || d.Resolution != ResolutionSplit || d.ResolvedAt.IsZero() {
+		t.Errorf("dispute = %+v", d)
+	}
+	body := api.last().Body
+	if body["resolution"] != "split" {
+		t.Errorf("body = %v", body)
+	}
+	if _, ok := body["reason"]; ok {
+		t.Errorf("empty reason sent: %v", body)
+	}
+
+	api.on("POST /api/disputes/"+testDisputeID+"/resolve", fixture(t, "disputes", "resolve_resolved"))
+	_, err = client.ResolveDispute(context.Background(), testDisputeID, ResolveDisputeRequest{ArbitratorID: "arb", Resolution: ResolutionSplit})
+	if apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrBadRequest); apiErr.Message != "Dispute already resolved" {
+		t.Errorf("message = %q", apiErr.Message)
+	}
+}
+
+func TestGetDispute(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/disputes/"+testDisputeID, fixture(t, "disputes", "get"))
+	api.on("GET /api/disputes/dispute-x", fixture(t, "disputes", "get_missing"))
+	client := api.client()
+
+	d, err := client.GetDispute(context.Background(), testDisputeID)
+	if err != nil {
+		t.Fatalf("GetDispute: %v", err)
+	}
+	if d.ID != testDisputeID || d.InitiatorID != "alice" {
+		t.Errorf("dispute = %+v", d)
+	}
+
+	_, err = client.GetDispute(context.Background(), "dispute-x")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestListDisputes(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/disputes", fixture(t, "disputes", "list"))
+	client := api.client()
+
+	disputes, err := client.ListDisputes(context.Background(), DisputeFilter{Status: DisputeOpen, TaskID: "task1a2b3c4d5e6f"})
+	if err != nil {
+		t.Fatalf("ListDisputes: %v", err)
+	}
+	if len(disputes) != 1 || disputes[0].ID != testDisputeID {
+		t.Errorf("disputes = %+v", disputes)
+	}
+	if q := api.last().Query.Encode(); q != "status=open&taskId=task1a2b3c4d5e6f" {
+		t.Errorf("query = %q", q)
+	}
+
+	if _, err := client.ListDisputes(context.Background(), DisputeFilter{}); err != nil {
+		t.Fatalf("ListDisputes: %v", err)
+	}
+	if q := api.last().Query; len(q) != 0 {
+		t.Errorf("unfiltered query = %v", q)
+	}
+}
+
+func TestListAgentDisputes(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/disputes/agent/alice", fixture(t, "disputes", "agent"))
+
+	got, err := api.client().ListAgentDisputes(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("ListAgentDisputes: %v", err)
+	}
+	if got.AgentID != "alice" || len(got.Disputes) != 1 || got.Stats.Initiated != 1 {
+		t.Errorf("agent disputes = %+v", got)
+	}
+}
+
+func TestGetDisputeStats(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/disputes/stats/overview", fixture(t, "disputes", "stats"))
+	client := api.client()
+
+	stats, err := client.GetDisputeStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetDisputeStats: %v", err)
+	}
+	if stats.Total != 3 || stats.Resolutions.Split != 1 || stats.AverageResolutionTime() != 30*time.Minute {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	api.on("GET /api/disputes/stats/overview", fixture(t, "disputes", "stats_empty"))
+	stats, err = client.GetDisputeStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetDisputeStats: %v", err)
+	}
+	if stats.AverageResolutionTime() != 0 {
+		t.Errorf("average with no resolved disputes = %v, want 0", stats.AverageResolutionTime())
+	}
+}
+
+func TestDisputeTimeline(t *testing.T) {
+	at := func(min int) Timestamp {
+		return Timestamp{time.Date(2026, 1, 1, 0, min, 0, 0, time.UTC)}
+	}
+	d := Dispute{
+		InitiatorID:      "alice",
+		Reason:           "Never delivered",
+		Status:           DisputeResolved,
+		Resolution:       ResolutionRefundPoster,
+		ResolutionReason: "No delivery",
+		ArbitratorID:     "arb",
+		CreatedAt:        at(0),
+		ResolvedAt:       at(30),
+		Evidence: []Evidence{
+			{PartyID: "bob", Type: EvidenceDelivery, SubmittedAt: at(20)},
+			{PartyID: "alice", Type: EvidenceMessage, SubmittedAt: at(10)},
+		},
+	}
+
+	var got []string
+	for _, e := range d.Timeline() {
+		got = append(got, e.Kind+" "+e.PartyID+" "+e.Detail)
+	}
+	want := []string{
+		"opened alice Never delivered",
+		"evidence alice message",
+		"evidence bob delivery",
+		"resolved arb refund_poster: No delivery",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("timeline = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+package gigclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by *APIError and *RequestError via errors.Is
+var (
+	ErrBadRequest   = errors.New("gigclaw: bad request")
+	ErrValidation   = errors.New("gigclaw: validation failed")
+	ErrUnauthorized = errors.New("gigclaw: unauthorized")
+	ErrForbidden    = errors.New("gigclaw: forbidden")
+	ErrNotFound     = errors.New("gigclaw: not found")
+	ErrConflict     = errors.New("gigclaw: conflict")
+	ErrRateLimited  = errors.New("gigclaw: rate limited")
+	ErrServer       = errors.New("gigclaw: server error")
+	ErrUnreachable  = errors.New("gigclaw: API unreachable")
+)
+
+// FieldError is a single validation failure reported by the API
+type FieldError struct {
+	Field    string      `json:"field"`
+	Message  string      `json:"message"`
+	Location string      `json:"location,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+}
+
+func (f FieldError) String() string {
+	if f.Field == "" {
+		return f.Message
+	}
+	return f.Field + ": " + f.Message
+}
+
+// APIError is returned when the API responds with an unexpected status
+type APIError struct {
+	Op         string        // operation that failed, e.g. "list tasks"
+	StatusCode int           // HTTP status code
+	Message    string        // the server's "error" message
+	Details    []FieldError  // validation failures, if any
+	RequestID  string        // server request ID, if provided
+	RetryAfter time.Duration // server-requested wait on 429/503, if any
+	Body       string        // raw response body
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to %s: %s (HTTP %d)", e.Op, e.message(), e.StatusCode)
+	if len(e.Details) > 0 {
+		parts := make([]string, len(e.Details))
+		for i, d := range e.Details {
+			parts[i] = d.String()
+		}
+		b.WriteString(": " + strings.Join(parts, "; "))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request %s]", e.RequestID)
+	}
+	return b.String()
+}
+
+// message returns the server message, falling back to the status text
+func (e *APIError) message() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if text := http.StatusText(e.StatusCode); text != "" {
+		return text
+	}
+	return "unexpected response"
+}
+
+// Is matches the sentinel error for the response status
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrValidation:
+		return (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity) &&
+			len(e.Details) > 0
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Rejected reports whether the server refused the request outright: a 4xx
+// other than 408, 409 and 429. A rejected write was not applied. After a
+// 5xx, a timeout or an unexpected 2xx it may have been.
+func (e *APIError) Rejected() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	}
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+// errorBody is the JSON error envelope returned by the API
+type errorBody struct {
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	RequestID  string `json:"requestId"`
+	RetryAfter int    `json:"retryAfter"`
+	Details    []struct {
+		Msg      string      `json:"msg"`
+		Path     string      `json:"path"`
+		Param    string      `json:"param"`
+		Location string      `json:"location"`
+		Value    interface{} `json:"value"`
+	} `json:"details"`
+}
+
+// maxErrorBody caps how much of an error response is kept
+const maxErrorBody = 4096
+
+func newAPIError(op string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	apiErr := &APIError{
+		Op:         op,
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       strings.TrimSpace(string(body)),
+	}
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = d
+	}
+
+	var parsed errorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		// Not JSON, e.g. a proxy error page
+		return apiErr
+	}
+
+	apiErr.Message = parsed.Error
+	if parsed.Message != "" && parsed.Message != parsed.Error {
+		if apiErr.Message == "" {
+			apiErr.Message = parsed.Message
+		} else {
+			apiErr.Message += ": " + parsed.Message
+		}
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = parsed.RequestID
+	}
+	if apiErr.RetryAfter == 0 && parsed.RetryAfter > 0 {
+		apiErr.RetryAfter = time.Duration(parsed.RetryAfter) * time.Second
+	}
+	for _, d := range parsed.Details {
+		field := d.Path
+		if field == "" {
+			field = d.Param
+		}
+		apiErr.Details = append(apiErr.Details, FieldError{
+			Field:    field,
+			Message:  d.Msg,
+			Location: d.Location,
+			Value:    d.Value,
+		})
+	}
+
+	return apiErr
+}
+
+// RequestError is returned when a request could not be completed,
+// e.g. the server was unreachable or the context was cancelled
+type RequestError struct {
+	Op  string
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("failed to %s: %v", e.Op, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// NotSent reports whether the request never reached the server, e.g. the
+// connection was refused, so repeating it cannot cause a duplicate
+func (e *RequestError) NotSent() bool {
+	return safeToRetryError(e.Err)
+}
+
+// Is reports ErrUnreachable for transport failures that were not
+// caused by the caller cancelling the context
+func (e *RequestError) Is(target error) bool {
+	return target == ErrUnreachable && !errors.Is(e.Err, context.Canceled)
+}
+
+// DecodeError is returned when a response body could not be decoded
+type DecodeError struct {
+	Op  string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s response: %v", e.Op, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,192 @@
+package gigclaw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var sentinels = map[string]error{
+	"ErrBadRequest":   ErrBadRequest,
+	"ErrValidation":   ErrValidation,
+	"ErrUnauthorized": ErrUnauthorized,
+	"ErrForbidden":    ErrForbidden,
+	"ErrNotFound":     ErrNotFound,
+	"ErrConflict":     ErrConflict,
+	"ErrRateLimited":  ErrRateLimited,
+	"ErrServer":       ErrServer,
+	"ErrUnreachable":  ErrUnreachable,
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		details bool
+		want    []string
+	}{
+		{"bad request", http.StatusBadRequest, false, []string{"ErrBadRequest"}},
+		{"validation", http.StatusBadRequest, true, []string{"ErrBadRequest", "ErrValidation"}},
+		{"unprocessable", http.StatusUnprocessableEntity, true, []string{"ErrValidation"}},
+		{"unprocessable without details", http.StatusUnprocessableEntity, false, nil},
+		{"unauthorized", http.StatusUnauthorized, false, []string{"ErrUnauthorized"}},
+		{"forbidden", http.StatusForbidden, false, []string{"ErrForbidden"}},
+		{"not found", http.StatusNotFound, false, []string{"ErrNotFound"}},
+		{"conflict", http.StatusConflict, false, []string{"ErrConflict"}},
+		{"rate limited", http.StatusTooManyRequests, false, []string{"ErrRateLimited"}},
+		{"internal", http.StatusInternalServerError, false, []string{"ErrServer"}},
+		{"bad gateway", http.StatusBadGateway, false, []string{"ErrServer"}},
+		{"unavailable", http.StatusServiceUnavailable, false, []string{"ErrServer"}},
+		{"unexpected success", http.StatusOK, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := &APIError{Op: "test", StatusCode: tt.status}
+			if tt.details {
+				apiErr.Details = []FieldError{{Field: "title", Message: "required"}}
+			}
+			var err error = apiErr
+
+			want := make(map[string]bool)
+			for _, name := range tt.want {
+				want[name] = true
+			}
+			for name, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != want[name] {
+					t.Errorf("errors.Is(%d, %s) = %v, want %v", tt.status, name, got, want[name])
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorRejected(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusForbidden, true},
+		{http.StatusNotFound, true},
+		{http.StatusRequestTimeout, false},
+		{http.StatusConflict, false},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusGatewayTimeout, false},
+		{http.StatusOK, false},
+	}
+	for _, tt := range tests {
+		if got := (&APIError{StatusCode: tt.status}).Rejected(); got != tt.want {
+			t.Errorf("Rejected() for %d = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       mockResponse
+		wantMsg    string
+		wantReqID  string
+		wantFields []string
+		wantError  string
+	}{
+		{
+			name:      "error",
+			resp:      reply(http.StatusNotFound, `{"error":"Task not found"}`),
+			wantMsg:   "Task not found",
+			wantError: "failed to get task: Task not found (HTTP 404)",
+		},
+		{
+			name:    "message",
+			resp:    reply(http.StatusTooManyRequests, `{"message":"Too many requests, please try again later."}`),
+			wantMsg: "Too many requests, please try again later.",
+		},
+		{
+			name:    "error and message",
+			resp:    reply(http.StatusInternalServerError, `{"error":"Failed to fetch","message":"connection reset"}`),
+			wantMsg: "Failed to fetch: connection reset",
+		},
+		{
+			name:       "validation details",
+			resp:       reply(http.StatusBadRequest, `{"error":"Validation failed","details":[{"type":"field","msg":"Title must be 5-200 characters","path":"title","location":"body"},{"msg":"Invalid value","param":"budget"}]}`),
+			wantMsg:    "Validation failed",
+			wantFields: []string{"title", "budget"},
+			wantError:  "failed to get task: Validation failed (HTTP 400): title: Title must be 5-200 characters; budget: Invalid value",
+		},
+		{
+			name:      "request ID header",
+			resp:      mockResponse{Status: http.StatusForbidden, Header: map[string]string{"X-Request-Id": "req-1"}, Body: []byte(`{"error":"Forbidden","requestId":"req-body"}`)},
+			wantMsg:   "Forbidden",
+			wantReqID: "req-1",
+			wantError: "failed to get task: Forbidden (HTTP 403) [request req-1]",
+		},
+		{
+			name:      "request ID body",
+			resp:      reply(http.StatusForbidden, `{"error":"Forbidden","requestId":"req-body"}`),
+			wantMsg:   "Forbidden",
+			wantReqID: "req-body",
+		},
+		{
+			name:      "not JSON",
+			resp:      mockResponse{Status: http.StatusBadRequest, Body: []byte(`<html>Bad Request</html>`)},
+			wantError: "failed to get task: Bad Request (HTTP 400)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/tasks/t1", tt.resp)
+
+			_, err := api.client().GetTask(context.Background(), "t1")
+			apiErr := wantAPIError(t, err, tt.resp.Status, nil)
+			if apiErr.Op != "get task" || apiErr.Message != tt.wantMsg || apiErr.RequestID != tt.wantReqID {
+				t.Errorf("error = %+v", apiErr)
+			}
+			if apiErr.Body != string(tt.resp.Body) {
+				t.Errorf("body = %q, want %q", apiErr.Body, tt.resp.Body)
+			}
+			var fields []string
+			for _, d := range apiErr.Details {
+				fields = append(fields, d.Field)
+			}
+			if len(fields) != len(tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+			if tt.wantError != "" && err.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantError)
+			}
+		})
+	}
+}
+
+func TestAPIErrorRetryAfterBody(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/tasks/t1", reply(http.StatusTooManyRequests, `{"error":"Too many requests","retryAfter":90}`))
+
+	_, err := api.client().GetTask(context.Background(), "t1")
+	if apiErr := wantAPIError(t, err, http.StatusTooManyRequests, ErrRateLimited); apiErr.RetryAfter != 90*time.Second {
+		t.Errorf("RetryAfter = %v, want 90s", apiErr.RetryAfter)
+	}
+}
+
+func TestRequestErrorSentinels(t *testing.T) {
+	unreachable := &RequestError{Op: "list tasks", Err: errors.New("connection refused")}
+	if !errors.Is(unreachable, ErrUnreachable) {
+		t.Error("transport failure is not ErrUnreachable")
+	}
+	var apiErr *APIError
+	if errors.As(unreachable, &apiErr) {
+		t.Error("RequestError matched *APIError")
+	}
+
+	cancelled := &RequestError{Op: "list tasks", Err: context.Canceled}
+	if errors.Is(cancelled, ErrUnreachable) {
+		t.Error("cancelled request is ErrUnreachable")
+	}
+	if !errors.Is(cancelled, context.Canceled) {
+		t.Error("cancelled request does not unwrap to context.Canceled")
+	}
+}
@@ -0,0 +1,145 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// EscrowStatus is the state of the funds locked for a task
+type EscrowStatus struct {
+	TaskID           string    `json:"taskId"`
+	Status           string    `json:"status"` // task status
+	Held             bool      `json:"held"`   // funds are still locked
+	Amount           float64   `json:"amount,omitempty"`
+	ReleaseScheduled bool      `json:"releaseScheduled"`
+	ReleasedAt       Timestamp `json:"releasedAt"`
+	TransactionHash  string    `json:"transactionHash,omitempty"`
+}
+
+// GetEscrowStatus retrieves the escrow state of a task
+func (c *Client) GetEscrowStatus(ctx context.Context, taskID string) (*EscrowStatus, error) {
+	var status EscrowStatus
+	path := fmt.Sprintf("/api/escrow/%s/status", url.PathEscape(taskID))
+	if err := c.do(ctx, "get escrow status", http.MethodGet, path, nil, &status, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// EscrowConfig controls the automatic release of verified payments
+type EscrowConfig struct {
+	Enabled   bool    `json:"enabled"`
+	DelayMs   int64   `json:"delayMs"` // dispute window before release
+	MinAmount float64 `json:"minAmount"`
+	MaxAmount float64 `json:"maxAmount"`
+}
+
+// Delay returns the dispute window before a verified payment is released
+func (c EscrowConfig) Delay() time.Duration {
+	return time.Duration(c.DelayMs) * time.Millisecond
+}
+
+// GetEscrowConfig retrieves the auto-release configuration
+func (c *Client) GetEscrowConfig(ctx context.Context) (*EscrowConfig, error) {
+	var response struct {
+		Config EscrowConfig `json:"config"`
+	}
+	if err := c.do(ctx, "get escrow config", http.MethodGet, "/api/escrow/config", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Config, nil
+}
+
+// AutoReleases reports whether a verified payment of amount is released
+// automatically. Amounts outside the configured bounds need a manual
+// release.
+func (c EscrowConfig) AutoReleases(amount float64) bool {
+	return c.Enabled && amount >= c.MinAmount && amount <= c.MaxAmount
+}
+
+// ActiveEscrow is a task whose payment is still locked
+type ActiveEscrow struct {
+	TaskID    string    `json:"taskId"`
+	Title     string    `json:"title"`
+	Amount    float64   `json:"amount"` // the accepted bid
+	Currency  string    `json:"currency"`
+	PosterID  string    `json:"posterId"`
+	AgentID   string    `json:"agentId"`
+	Status    string    `json:"status"` // in_progress, completed or verified
+	CreatedAt Timestamp `json:"createdAt"`
+}
+
+// ActiveEscrows represents the API response for listing active escrows
+type ActiveEscrows struct {
+	Escrows    []ActiveEscrow `json:"escrows"`
+	Count      int            `json:"count"`
+	TotalValue float64        `json:"totalValue"`
+}
+
+// ListActiveEscrows retrieves every task whose payment is still locked
+func (c *Client) ListActiveEscrows(ctx context.Context) (*ActiveEscrows, error) {
+	var response ActiveEscrows
+	if err := c.do(ctx, "list active escrows", http.MethodGet, "/api/escrow/active", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// EscrowStats summarises escrow across the marketplace
+type EscrowStats struct {
+	TotalEscrows        int     `json:"totalEscrows"`
+	ActiveEscrows       int     `json:"activeEscrows"`
+	ReleasedEscrows     int     `json:"releasedEscrows"`
+	TotalValueLocked    float64 `json:"totalValueLocked"`
+	TotalValueReleased  float64 `json:"totalValueReleased"`
+	AverageEscrowAmount float64 `json:"averageEscrowAmount"`
+	AutoReleaseRate     float64 `json:"autoReleaseRate"` // percent of escrows released
+}
+
+// GetEscrowStats retrieves marketplace-wide escrow statistics
+func (c *Client) GetEscrowStats(ctx context.Context) (*EscrowStats, error) {
+	var response struct {
+		Stats EscrowStats `json:"stats"`
+	}
+	if err := c.do(ctx, "get escrow stats", http.MethodGet, "/api/escrow/stats/overview", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Stats, nil
+}
+
+// EscrowRelease is a payment released from escrow
+type EscrowRelease struct {
+	TaskID          string    `json:"taskId"`
+	AgentID         string    `json:"agentId"` // recipient
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	ReleasedAt      Timestamp `json:"releasedAt"`
+	TransactionHash string    `json:"transactionHash"`
+	AutoReleased    bool      `json:"autoReleased"`
+	ArbitratorID    string    `json:"arbitratorId,omitempty"`
+	Reason          string    `json:"reason,omitempty"`
+}
+
+// ReleaseEscrow releases a task's payment to the assigned agent without
+// waiting for the automatic release. The API reserves this for
+// arbitrators and fails with ErrBadRequest if the payment was already
+// released.
+func (c *Client) ReleaseEscrow(ctx context.Context, taskID, arbitratorID, reason string, opts ...RequestOption) (*EscrowRelease, error) {
+	payload := map[string]interface{}{
+		"arbitratorId": arbitratorID,
+		"reason":       reason,
+	}
+
+	var response struct {
+		Payment EscrowRelease `json:"payment"`
+	}
+	path := fmt.Sprintf("/api/escrow/%s/release", url.PathEscape(taskID))
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "release escrow", http.MethodPost, path, header, payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Payment, nil
+}
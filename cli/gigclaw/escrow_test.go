@@ -0,0 +1,155 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetEscrowStatus(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/escrow/"+testTaskID+"/status", fixture(t, "escrow", "status"))
+	client := api.client()
+
+	status, err := client.GetEscrowStatus(context.Background(), testTaskID)
+	if err != nil {
+		t.Fatalf("GetEscrowStatus: %v", err)
+	}
+	if !status.Held || !status.ReleaseScheduled || status.Amount != 135 || !status.ReleasedAt.IsZero() {
+		t.Errorf("status = %+v", status)
+	}
+
+	api.on("GET /api/escrow/"+testTaskID+"/status", fixture(t, "escrow", "status_paid"))
+	status, err = client.GetEscrowStatus(context.Background(), testTaskID)
+	if err != nil {
+		t.Fatalf("GetEscrowStatus: %v", err)
+	}
+	if status.Held || status.Status != "paid" || status.TransactionHash == "" ||
+		!status.ReleasedAt.Equal(time.Date(2026, 1, 2, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestGetEscrowStatusMissing(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/escrow/nope/status", fixture(t, "escrow", "status_missing"))
+
+	_, err := api.client().GetEscrowStatus(context.Background(), "nope")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestGetEscrowConfig(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/escrow/config", fixture(t, "escrow", "config"))
+
+	cfg, err := api.client().GetEscrowConfig(context.Background())
+	if err != nil {
+		t.Fatalf("GetEscrowConfig: %v", err)
+	}
+	if !cfg.Enabled || cfg.Delay() != time.Hour || cfg.MinAmount != 0.1 || cfg.MaxAmount != 10000 {
+		t.Errorf("config = %+v", cfg)
+	}
+}
+
+func TestListActiveEscrows(t *testing.T) {
+	tests := []struct {
+		fixture string
+		count   int
+		value   float64
+	}{
+		{"active", 1, 135},
+		{"active_empty", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/escrow/active", fixture(t, "escrow", tt.fixture))
+
+			active, err := api.client().ListActiveEscrows(context.Background())
+			if err != nil {
+				t.Fatalf("ListActiveEscrows: %v", err)
+			}
+			if active.Count != tt.count || len(active.Escrows) != tt.count || active.TotalValue != tt.value {
+				t.Fatalf("active = %+v", active)
+			}
+			if tt.count > 0 {
+				e := active.Escrows[0]
+				if e.TaskID != testTaskID || e.AgentID != "agent-7" || e.Status != "verified" ||
+					!e.CreatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("escrow = %+v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestGetEscrowStats(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/escrow/stats/overview", fixture(t, "escrow", "stats"))
+
+	stats, err := api.client().GetEscrowStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetEscrowStats: %v", err)
+	}
+	want := EscrowStats{TotalEscrows: 4, ActiveEscrows: 1, ReleasedEscrows: 3, TotalValueLocked: 135,
+		TotalValueReleased: 265, AverageEscrowAmount: 100, AutoReleaseRate: 75}
+	if *stats != want {
+		t.Errorf("stats = %+v, want %+v", *stats, want)
+	}
+}
+
+func TestReleaseEscrow(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/escrow/"+testTaskID+"/release", fixture(t, "escrow", "release"))
+
+	payment, err := api.client().ReleaseEscrow(context.Background(), testTaskID, "arb",
+		"Agent delivered, poster unresponsive", IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("ReleaseEscrow: %v", err)
+	}
+	if payment.AgentID != "agent-7" || payment.Amount != 135 || payment.AutoReleased || payment.ArbitratorID != "arb" ||
+		!payment.ReleasedAt.Equal(time.Date(2026, 1, 2, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("payment = %+v", payment)
+	}
+	req := api.last()
+	if req.Body["arbitratorId"] != "arb" || req.Body["reason"] != "Agent delivered, poster unresponsive" ||
+		req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestReleaseEscrowErrors(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		sentinel error
+	}{
+		{"release_released", ErrBadRequest},
+		{"release_invalid", ErrValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/escrow/"+testTaskID+"/release", fixture(t, "escrow", tt.fixture))
+
+			_, err := api.client().ReleaseEscrow(context.Background(), testTaskID, "arb", "")
+			apiErr := wantAPIError(t, err, http.StatusBadRequest, tt.sentinel)
+			if !apiErr.Rejected() {
+				t.Errorf("release rejected with %d not reported as rejected", apiErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAutoReleases(t *testing.T) {
+	cfg := EscrowConfig{Enabled: true, MinAmount: 0.1, MaxAmount: 10000}
+	for amount, want := range map[float64]bool{0.05: false, 0.1: true, 80: true, 10000: true, 20000: false} {
+		if got := cfg.AutoReleases(amount); got != want {
+			t.Errorf("AutoReleases(%v) = %v, want %v", amount, got, want)
+		}
+	}
+	cfg.Enabled = false
+	if cfg.AutoReleases(80) {
+		t.Error("AutoReleases with auto-release disabled = true")
+	}
+}
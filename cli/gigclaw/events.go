@@ -0,0 +1,480 @@
+package gigclaw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Event channels published on the /ws endpoint
+const (
+	ChannelAll         = "all" // every channel
+	ChannelNewTasks    = "tasks:new"
+	ChannelTaskUpdates = "tasks:updates"
+	ChannelBids        = "bids:updates"
+	ChannelPayments    = "payments:updates"
+	ChannelDisputes    = "disputes:updates"
+	ChannelStandups    = "standups:new"
+	ChannelVoting      = "voting:updates"
+)
+
+const (
+	eventStreamPath = "/ws"
+	eventStreamOp   = "connect to event stream"
+
+	defaultHeartbeat    = 75 * time.Second // the server sends one every 30s
+	defaultPingInterval = 25 * time.Second
+	writeTimeout        = 10 * time.Second
+	minReconnectBackoff = 500 * time.Millisecond
+)
+
+// TaskChannel returns the channel carrying bids on one task
+func TaskChannel(taskID string) string {
+	return "task:" + taskID
+}
+
+// AgentChannel returns the channel carrying events for one agent, such as
+// won bids and received payments
+func AgentChannel(agentID string) string {
+	return "agent:" + agentID
+}
+
+// Event types
+const (
+	EventTaskCreated      = "task_created"
+	EventTaskCompleted    = "task_completed"
+	EventBidPlaced        = "bid_placed"
+	EventNewBid           = "new_bid" // on a task channel
+	EventBidAccepted      = "bid_accepted"
+	EventBidWon           = "bid_won" // on an agent channel
+	EventPaymentReleased  = "payment_released"
+	EventPaymentReceived  = "payment_received" // on an agent channel
+	EventDisputeInitiated = "dispute_initiated"
+	EventDisputeResolved  = "dispute_resolved"
+	EventStandupConducted = "standup_conducted"
+	EventVoteCast         = "vote_cast"
+	EventStatusChange     = "status_change" // bulk status update
+	EventTaskDeleted      = "deleted"       // bulk deletion
+)
+
+// Event is a real-time event pushed by the server
+type Event struct {
+	Type string `json:"type"`
+
+	// TaskID is the task the event concerns, if any
+	TaskID string `json:"taskId,omitempty"`
+
+	// Data is the event payload, e.g. the task or bid; see Decode
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// Amount is set on payment_received events
+	Amount float64 `json:"amount,omitempty"`
+
+	// OldStatus and NewStatus are set on status_change events
+	OldStatus string `json:"oldStatus,omitempty"`
+	NewStatus string `json:"newStatus,omitempty"`
+
+	// Reason is set on deleted events
+	Reason string `json:"reason,omitempty"`
+
+	// Timestamp is when the server sent the event, or when it was
+	// received if the server did not say
+	Timestamp Timestamp `json:"timestamp"`
+}
+
+// Decode unmarshals the event payload into v
+func (e Event) Decode(v interface{}) error {
+	if len(e.Data) == 0 {
+		return fmt.Errorf("%s event has no data", e.Type)
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+// parseEvent decodes a server message, filling in the task ID and the
+// timestamp when the message leaves them out
+func parseEvent(data []byte, received time.Time) (Event, error) {
+	var ev Event
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return Event{}, err
+	}
+	if ev.TaskID == "" && len(ev.Data) > 0 {
+		var ref struct {
+			ID     string `json:"id"`
+			TaskID string `json:"taskId"`
+		}
+		if json.Unmarshal(ev.Data, &ref) == nil {
+			ev.TaskID = ref.TaskID
+			if ev.TaskID == "" && strings.HasPrefix(ev.Type, "task_") {
+				ev.TaskID = ref.ID
+			}
+		}
+	}
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = Timestamp{received}
+	}
+	return ev, nil
+}
+
+// StreamState is the connection state of a Stream
+type StreamState int
+
+const (
+	StreamConnected    StreamState = iota // connected and subscribed
+	StreamReconnecting                    // connection lost, reconnecting
+	StreamClosed                          // closed for good; see Stream.Err
+)
+
+func (s StreamState) String() string {
+	switch s {
+	case StreamConnected:
+		return "connected"
+	case StreamReconnecting:
+		return "reconnecting"
+	default:
+		return "closed"
+	}
+}
+
+// StreamOptions configures an event stream
+type StreamOptions struct {
+	// Channels to subscribe to (default ChannelAll)
+	Channels []string
+
+	// AgentID identifies the connection as an agent. The server then also
+	// subscribes it to the agent's channel, new tasks and bid updates.
+	AgentID string
+
+	// HeartbeatTimeout is how long the stream may stay silent before the
+	// connection is considered dead and replaced (default 75s)
+	HeartbeatTimeout time.Duration
+
+	// PingInterval is how often the client pings the server (default 25s)
+	PingInterval time.Duration
+
+	// UserAgent identifies the connection to the server (default
+	// "GigClaw-Agent gigclaw-go"). The server tells dashboards from agents
+	// by a GigClaw-Dashboard or GigClaw-Agent prefix.
+	UserAgent string
+
+	// MaxReconnects is the number of consecutive failed reconnection
+	// attempts before the stream gives up; 0 means never give up
+	MaxReconnects int
+
+	// OnStateChange, if set, is called when the connection state changes.
+	// err is the reason for reconnecting or closing.
+	OnStateChange func(state StreamState, err error)
+}
+
+// Stream receives real-time events over a WebSocket. Lost connections are
+// re-established with the client's retry backoff, and every subscription
+// is renewed.
+type Stream struct {
+	client *Client
+	url    string
+	opts   StreamOptions
+	events chan Event
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex // guards conn, channels and err
+	writeMu  sync.Mutex // serialises writes to conn
+	conn     *websocket.Conn
+	channels []string
+	err      error
+}
+
+// Subscribe connects to the server's event stream. The first connection
+// is made before Subscribe returns; later ones happen in the background.
+// Close the stream, or cancel ctx, to stop it.
+func (c *Client) Subscribe(ctx context.Context, opts StreamOptions) (*Stream, error) {
+	u, err := url.Parse(c.baseURL + eventStreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("gigclaw: invalid base URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+
+	if opts.HeartbeatTimeout <= 0 {
+		opts.HeartbeatTimeout = defaultHeartbeat
+	}
+	if opts.PingInterval <= 0 {
+		opts.PingInterval = defaultPingInterval
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = "GigClaw-Agent gigclaw-go"
+	}
+	channels := opts.Channels
+	if len(channels) == 0 && opts.AgentID == "" {
+		channels = []string{ChannelAll}
+	}
+
+	s := &Stream{
+		client: c,
+		url:    u.String(),
+		opts:   opts,
+		events: make(chan Event, 64),
+		done:   make(chan struct{}),
+	}
+	s.addChannels(channels)
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	conn, err := s.dial()
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+	go s.run(conn)
+	return s, nil
+}
+
+// Events returns the channel events are delivered on. It is closed when
+// the stream stops.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// Channels returns the channels the stream is subscribed to
+func (s *Stream) Channels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.channels...)
+}
+
+// Subscribe adds channels to the stream. They are renewed on reconnect.
+func (s *Stream) Subscribe(channels ...string) error {
+	s.addChannels(channels)
+	return s.send(map[string]interface{}{"type": "subscribe", "channels": channels})
+}
+
+// Unsubscribe removes channels from the stream
+func (s *Stream) Unsubscribe(channels ...string) error {
+	s.mu.Lock()
+	kept := s.channels[:0]
+	for _, ch := range s.channels {
+		if !contains(channels, ch) {
+			kept = append(kept, ch)
+		}
+	}
+	s.channels = kept
+	s.mu.Unlock()
+	return s.send(map[string]interface{}{"type": "unsubscribe", "channels": channels})
+}
+
+// Close stops the stream and waits for it to shut down
+func (s *Stream) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// Err returns the error that stopped the stream, once Events is closed.
+// It is nil if the stream was closed or its context cancelled.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Stream) addChannels(channels []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range channels {
+		if !contains(s.channels, ch) {
+			s.channels = append(s.channels, ch)
+		}
+	}
+}
+
+// dial opens a connection, identifies and subscribes
+func (s *Stream) dial() (*websocket.Conn, error) {
+	header := http.Header{}
+	header.Set("User-Agent", s.opts.UserAgent)
+	if s.client.apiKey != "" {
+		header.Set("Authorization", "Bearer "+s.client.apiKey)
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: s.client.httpClient.Timeout,
+	}
+	s.client.logger.Debug("Connecting to event stream", s.url)
+	conn, resp, err := dialer.DialContext(s.ctx, s.url, header)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			return nil, newAPIError(eventStreamOp, resp)
+		}
+		return nil, &RequestError{Op: eventStreamOp, Err: err}
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	channels := append([]string(nil), s.channels...)
+	s.mu.Unlock()
+
+	if s.opts.AgentID != "" {
+		if err := s.send(map[string]interface{}{"type": "identify", "agentId": s.opts.AgentID}); err != nil {
+			conn.Close()
+			return nil, &RequestError{Op: eventStreamOp, Err: err}
+		}
+	}
+	if len(channels) > 0 {
+		if err := s.send(map[string]interface{}{"type": "subscribe", "channels": channels}); err != nil {
+			conn.Close()
+			return nil, &RequestError{Op: eventStreamOp, Err: err}
+		}
+	}
+	s.notify(StreamConnected, nil)
+	return conn, nil
+}
+
+// send writes a JSON message to the current connection, if any
+func (s *Stream) send(msg interface{}) error {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil {
+		// Sent on the next connection
+		return nil
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return conn.WriteJSON(msg)
+}
+
+func (s *Stream) notify(state StreamState, err error) {
+	if s.opts.OnStateChange != nil {
+		s.opts.OnStateChange(state, err)
+	}
+}
+
+// run reads from conn and reconnects until the stream is stopped
+func (s *Stream) run(conn *websocket.Conn) {
+	defer close(s.done)
+	defer close(s.events)
+
+	// Unblock reads when the stream is stopped
+	go func() {
+		<-s.ctx.Done()
+		s.mu.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		err := s.read(conn)
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+		conn.Close()
+		if s.ctx.Err() != nil {
+			s.stop(nil)
+			return
+		}
+
+		s.client.logger.Debug("Event stream disconnected", err)
+		s.notify(StreamReconnecting, err)
+		conn, err = s.reconnect()
+		if err != nil {
+			if s.ctx.Err() != nil {
+				err = nil
+			}
+			s.stop(err)
+			return
+		}
+	}
+}
+
+func (s *Stream) stop(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+	s.notify(StreamClosed, err)
+}
+
+// reconnect dials until it succeeds, the stream is stopped or
+// MaxReconnects attempts have failed
+func (s *Stream) reconnect() (*websocket.Conn, error) {
+	for attempt := 1; ; attempt++ {
+		wait := s.client.retry.backoff(attempt)
+		if wait < minReconnectBackoff {
+			wait = minReconnectBackoff
+		}
+		if err := s.client.sleep(s.ctx, wait); err != nil {
+			return nil, err
+		}
+
+		conn, err := s.dial()
+		if err == nil {
+			return conn, nil
+		}
+		s.client.logger.Debug(fmt.Sprintf("Reconnect attempt %d failed: %v", attempt, err))
+		if s.opts.MaxReconnects > 0 && attempt >= s.opts.MaxReconnects {
+			return nil, err
+		}
+	}
+}
+
+// read delivers events from conn until it fails
+func (s *Stream) read(conn *websocket.Conn) error {
+	stopPing := make(chan struct{})
+	defer close(stopPing)
+	go func() {
+		ticker := time.NewTicker(s.opts.PingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopPing:
+				return
+			case <-ticker.C:
+				if err := s.send(map[string]string{"type": "ping"}); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(s.opts.HeartbeatTimeout))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			var netErr interface{ Timeout() bool }
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return fmt.Errorf("no heartbeat for %v", s.opts.HeartbeatTimeout)
+			}
+			return err
+		}
+
+		ev, err := parseEvent(data, time.Now())
+		if err != nil {
+			s.client.logger.Debug("Ignoring malformed event", err)
+			continue
+		}
+		switch ev.Type {
+		case "connection", "heartbeat", "pong", "subscribed", "unsubscribed":
+			continue
+		}
+
+		select {
+		case s.events <- ev:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
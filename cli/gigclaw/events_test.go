@@ -0,0 +1,137 @@
+package gigclaw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type wsMessage struct {
+	Type     string   `json:"type"`
+	Channels []string `json:"channels"`
+	AgentID  string   `json:"agentId"`
+}
+
+func TestStreamResubscribesAfterReconnect(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	received := make(chan wsMessage, 10)
+	connections := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		connections++
+		first := connections == 1
+
+		conn.WriteJSON(map[string]interface{}{"type": "connection", "data": map[string]string{"clientId": "ws-1"}})
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			received <- msg
+			if msg.Type != "subscribe" {
+				continue
+			}
+			switch {
+			case first && msg.Channels[0] == ChannelNewTasks:
+				conn.WriteJSON(map[string]interface{}{
+					"type":      EventTaskCreated,
+					"data":      map[string]interface{}{"id": "t1", "title": "Audit"},
+					"timestamp": "2026-01-01T00:00:00Z",
+				})
+			case first:
+				// Drop the connection after the second subscription
+				return
+			default:
+				conn.WriteJSON(map[string]interface{}{"type": EventNewBid, "data": map[string]interface{}{"taskId": "t1", "amount": 40}})
+			}
+		}
+	}))
+	defer srv.Close()
+	client, _ := newTestClient(t, srv, testPolicy)
+
+	var states []StreamState
+	stream, err := client.Subscribe(context.Background(), StreamOptions{
+		Channels:      []string{ChannelNewTasks},
+		AgentID:       "agent-7",
+		PingInterval:  time.Hour,
+		OnStateChange: func(s StreamState, err error) { states = append(states, s) },
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	if msg := <-received; msg.Type != "identify" || msg.AgentID != "agent-7" {
+		t.Errorf("first message = %+v, want identify", msg)
+	}
+	if msg := <-received; !reflect.DeepEqual(msg.Channels, []string{ChannelNewTasks}) {
+		t.Errorf("subscribed to %v", msg.Channels)
+	}
+
+	ev := <-stream.Events()
+	if ev.Type != EventTaskCreated || ev.TaskID != "t1" {
+		t.Errorf("event = %+v, want task_created for t1", ev)
+	}
+	var task Task
+	if err := ev.Decode(&task); err != nil || task.Title != "Audit" {
+		t.Errorf("Decode = %+v, %v", task, err)
+	}
+
+	if err := stream.Subscribe(TaskChannel("t1")); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if msg := <-received; !reflect.DeepEqual(msg.Channels, []string{"task:t1"}) {
+		t.Errorf("subscribed to %v", msg.Channels)
+	}
+
+	// The server drops the connection; the stream reconnects and renews
+	// both subscriptions
+	<-received // identify
+	if msg := <-received; !reflect.DeepEqual(msg.Channels, []string{ChannelNewTasks, "task:t1"}) {
+		t.Errorf("resubscribed to %v", msg.Channels)
+	}
+	select {
+	case ev := <-stream.Events():
+		if ev.Type != EventNewBid || ev.TaskID != "t1" || ev.Timestamp.IsZero() {
+			t.Errorf("event = %+v, want new_bid for t1 with a timestamp", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event after reconnecting")
+	}
+
+	stream.Close()
+	if _, ok := <-stream.Events(); ok {
+		t.Error("Events still open after Close")
+	}
+	if stream.Err() != nil {
+		t.Errorf("Err = %v, want nil after Close", stream.Err())
+	}
+	want := []StreamState{StreamConnected, StreamReconnecting, StreamConnected, StreamClosed}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("states = %v, want %v", states, want)
+	}
+}
+
+func TestSubscribeReportsHandshakeErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	client, _ := newTestClient(t, srv, testPolicy)
+
+	_, err := client.Subscribe(context.Background(), StreamOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("err = %v, want 401 APIError", err)
+	}
+}
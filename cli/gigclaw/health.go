@@ -0,0 +1,28 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+)
+
+// HealthResponse represents the health check response
+type HealthResponse struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+	Version   string `json:"version"`
+}
+
+// Health checks the API health
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	var health HealthResponse
+	if err := c.do(ctx, "check health", http.MethodGet, "/health", nil, &health, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+// CheckConnectivity tests if the API is reachable
+func (c *Client) CheckConnectivity(ctx context.Context) error {
+	c.logger.Debug("Checking API connectivity...")
+	return c.do(ctx, "reach API", http.MethodGet, "/health", nil, nil, http.StatusOK)
+}
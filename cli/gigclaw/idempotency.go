@@ -0,0 +1,50 @@
+package gigclaw
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// IdempotencyKeyHeader carries the idempotency key of a write request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RequestOption customises a single API call
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotencyKey string
+}
+
+// IdempotencyKey sets the Idempotency-Key sent with a write request.
+// Reusing the key when repeating a call whose outcome is unknown lets
+// the server recognise the duplicate; it remembers a key for 24 hours and
+// answers a repeat with the first response. Without it, a key is generated
+// for each call and shared by all of that call's retries.
+func IdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// NewIdempotencyKey returns a random idempotency key
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// idempotencyHeader builds the headers for a write request
+func idempotencyHeader(opts []RequestOption) http.Header {
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.idempotencyKey == "" {
+		o.idempotencyKey = NewIdempotencyKey()
+	}
+
+	header := make(http.Header)
+	header.Set(IdempotencyKeyHeader, o.idempotencyKey)
+	return header
+}
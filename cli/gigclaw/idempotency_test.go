@@ -0,0 +1,55 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIdempotencyKeySharedAcrossRetries(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"message":"Bid placed","bid":{"id":"b1","amount":5,"createdAt":1767229200000,"accepted":false}}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newTestClient(t, srv, testPolicy)
+	bid, err := client.PlaceBid(context.Background(), "t1", 5, "")
+	if err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if bid.ID != "b1" {
+		t.Errorf("bid = %+v", bid)
+	}
+
+	if len(keys) != 3 || keys[0] == "" {
+		t.Fatalf("keys = %q, want 3 non-empty keys", keys)
+	}
+	for _, k := range keys[1:] {
+		if k != keys[0] {
+			t.Errorf("retry sent key %q, want %q", k, keys[0])
+		}
+	}
+}
+
+func TestIdempotencyKeyCallerSupplied(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(IdempotencyKeyHeader)
+	}))
+	defer srv.Close()
+
+	client, _ := newTestClient(t, srv, testPolicy)
+	if err := client.AcceptBid(context.Background(), "t1", "b1", IdempotencyKey("accept-t1-b1")); err != nil {
+		t.Fatalf("AcceptBid: %v", err)
+	}
+	if got != "accept-t1-b1" {
+		t.Errorf("Idempotency-Key = %q, want accept-t1-b1", got)
+	}
+}
@@ -0,0 +1,213 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AgentMatch is a registered agent scored for a task
+type AgentMatch struct {
+	Agent        Agent   `json:"agent"`
+	Score        float64 `json:"score"`
+	SkillMatches int     `json:"skillMatches"` // task skills the agent has
+}
+
+// TaskMatch is an open task scored for an agent
+type TaskMatch struct {
+	Task         Task    `json:"task"`
+	Score        float64 `json:"score"`
+	SkillMatches int     `json:"skillMatches"` // task skills the agent has
+}
+
+// FindAgents scores the available agents for a task, returning the best
+// five first
+func (c *Client) FindAgents(ctx context.Context, taskID string) ([]AgentMatch, error) {
+	var response struct {
+		Matches []AgentMatch `json:"matches"`
+	}
+	body := map[string]string{"taskId": taskID}
+	if err := c.do(ctx, "find agents", http.MethodPost, "/api/matching/find-agents", body, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Matches, nil
+}
+
+// AutoMatch returns the best available agent for a task. It returns
+// ErrNotFound when the task does not exist or no agent is available.
+func (c *Client) AutoMatch(ctx context.Context, taskID string) (*AgentMatch, error) {
+	var response struct {
+		Agent Agent   `json:"recommendedAgent"`
+		Score float64 `json:"matchScore"`
+	}
+	body := map[string]string{"taskId": taskID}
+	if err := c.do(ctx, "auto-match", http.MethodPost, "/api/matching/auto-match", body, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &AgentMatch{Agent: response.Agent, Score: response.Score}, nil
+}
+
+// RecommendTasks scores the open tasks for an agent, returning the best
+// five first
+func (c *Client) RecommendTasks(ctx context.Context, agentID string) ([]TaskMatch, error) {
+	var response struct {
+		Recommendations []TaskMatch `json:"recommendations"`
+	}
+	body := map[string]string{"agentId": agentID}
+	if err := c.do(ctx, "recommend tasks", http.MethodPost, "/api/matching/recommend-tasks", body, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Recommendations, nil
+}
+
+// PredictMatchRequest describes a task to predict matches for
+type PredictMatchRequest struct {
+	TaskID         string   `json:"taskId"`
+	RequiredSkills []string `json:"requiredSkills"`
+	Complexity     int      `json:"complexity,omitempty"` // 1-10; server default 5
+	Urgency        int      `json:"urgency,omitempty"`    // 1-10; server default 5
+	Budget         float64  `json:"budget,omitempty"`
+	PosterID       string   `json:"posterId"`
+}
+
+// PredictedMatch is an agent's predicted fit for a task, learned from the
+// outcomes sent with SendMatchFeedback
+type PredictedMatch struct {
+	AgentID           string   `json:"agentId"`
+	Score             float64  `json:"score"`            // 0-100
+	Confidence        float64  `json:"confidence"`       // 0-1; grows with the agent's history
+	PredictedSuccess  float64  `json:"predictedSuccess"` // percent
+	PredictedTimeline string   `json:"predictedTimeline"`
+	Reasons           []string `json:"reasons"`
+	RiskFactors       []string `json:"riskFactors"`
+}
+
+// PredictedMatches are the best predicted matches for a task
+type PredictedMatches struct {
+	Matches         []PredictedMatch `json:"matches"` // at most ten, best first
+	TotalCandidates int              `json:"totalCandidates"`
+}
+
+// PredictMatches predicts which agents will do a task best. Only agents
+// with match feedback are considered.
+func (c *Client) PredictMatches(ctx context.Context, req PredictMatchRequest) (*PredictedMatches, error) {
+	if len(req.RequiredSkills) == 0 {
+		return nil, fmt.Errorf("required skills are missing")
+	}
+	var matches PredictedMatches
+	if err := c.do(ctx, "predict matches", http.MethodPost, "/api/predictive/match", req, &matches, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &matches, nil
+}
+
+// MatchFeedback is the outcome of a task, sent so that predictions learn
+// from it
+type MatchFeedback struct {
+	AgentID        string   `json:"agentId"`
+	TaskID         string   `json:"taskId"`
+	Success        bool     `json:"success"`
+	CompletionTime int      `json:"completionTime,omitempty"` // hours
+	SkillsUsed     []string `json:"skillsUsed"`
+	Collaborators  []string `json:"collaborators,omitempty"` // other agents on the task
+}
+
+// PredictiveProfile is what predictions know about an agent
+type PredictiveProfile struct {
+	AgentID           string             `json:"agentId"`
+	SuccessRate       float64            `json:"successRate"`       // 0-1
+	AvgCompletionTime float64            `json:"avgCompletionTime"` // hours
+	Skills            map[string]float64 `json:"skills"`            // skill level, 0-100
+}
+
+// SendMatchFeedback records the outcome of a task, returning the agent's
+// updated profile
+func (c *Client) SendMatchFeedback(ctx context.Context, feedback MatchFeedback) (*PredictiveProfile, error) {
+	if feedback.SkillsUsed == nil {
+		feedback.SkillsUsed = []string{}
+	}
+	var response struct {
+		Profile PredictiveProfile `json:"profile"`
+	}
+	if err := c.do(ctx, "send match feedback", http.MethodPost, "/api/predictive/feedback", feedback, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Profile, nil
+}
+
+// TeamRequest describes a task to assemble a team of agents for
+type TeamRequest struct {
+	TaskID         string   `json:"taskId"`
+	RequiredSkills []string `json:"requiredSkills"`
+	TeamSize       int      `json:"teamSize"` // 2-5
+}
+
+// Team roles, from the member's best level in the required skills
+const (
+	RoleLead        = "Lead"        // above 80
+	RoleSpecialist  = "Specialist"  // above 60
+	RoleContributor = "Contributor" // the rest
+)
+
+// TeamMember is an agent proposed for a team
+type TeamMember struct {
+	AgentID string             `json:"agentId"`
+	Skills  map[string]float64 `json:"skills"` // every skill of the agent, level 0-100
+	Role    string             `json:"role"`
+}
+
+// Team is a team of agents proposed for a task
+type Team struct {
+	TaskID           string       `json:"taskId"`
+	Members          []TeamMember `json:"recommendedTeam"`   // empty when no agent qualifies
+	Compatibility    float64      `json:"teamCompatibility"` // 0-1
+	PredictedSuccess float64      `json:"predictedSuccess"`  // percent
+}
+
+// RecommendTeam proposes agents whose skills together cover a task. Like
+// PredictMatches, only agents with match feedback are considered.
+func (c *Client) RecommendTeam(ctx context.Context, req TeamRequest) (*Team, error) {
+	if len(req.RequiredSkills) == 0 {
+		return nil, fmt.Errorf("required skills are missing")
+	}
+	if req.TeamSize < 2 || req.TeamSize > 5 {
+		return nil, fmt.Errorf("team size must be 2-5, got %d", req.TeamSize)
+	}
+	var team Team
+	if err := c.do(ctx, "recommend team", http.MethodPost, "/api/predictive/team", req, &team, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// AgentAnalytics is what predictions make of an agent's track record
+type AgentAnalytics struct {
+	AgentID     string `json:"agentId"`
+	Performance struct {
+		OverallSuccessRate float64 `json:"overallSuccessRate"` // 0-1
+		RecentSuccessRate  float64 `json:"recentSuccessRate"`  // 0-1, last ten tasks
+		AvgCompletionTime  float64 `json:"avgCompletionTime"`  // hours
+		Reliability        float64 `json:"reliability"`        // 0-1
+	} `json:"performance"`
+	Collaboration struct {
+		Score             float64  `json:"score"`
+		PreferredPartners []string `json:"preferredPartners"`
+	} `json:"collaboration"`
+	MarketValue struct {
+		DemandScore   float64 `json:"demandScore"`   // 0-100
+		SuggestedRate float64 `json:"suggestedRate"` // per hour
+	} `json:"marketValue"`
+	Recommendations []string `json:"recommendations"`
+}
+
+// GetAgentAnalytics retrieves an agent's analytics. Agents without match
+// feedback get a default profile rather than ErrNotFound.
+func (c *Client) GetAgentAnalytics(ctx context.Context, agentID string) (*AgentAnalytics, error) {
+	var analytics AgentAnalytics
+	path := fmt.Sprintf("/api/predictive/agent/%s/analytics", url.PathEscape(agentID))
+	if err := c.do(ctx, "get agent analytics", http.MethodGet, path, nil, &analytics, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
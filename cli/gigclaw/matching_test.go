@@ -0,0 +1,273 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFindAgents(t *testing.T) {
+	tests := []struct {
+		fixture string
+		agents  []string
+	}{
+		{"find_agents", []string{"agent-7", "agent-9"}},
+		{"find_agents_none", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/matching/find-agents", fixture(t, "matching", tt.fixture))
+
+			matches, err := api.client().FindAgents(context.Background(), testTaskID)
+			if err != nil {
+				t.Fatalf("FindAgents: %v", err)
+			}
+			var agents []string
+			for _, m := range matches {
+				agents = append(agents, m.Agent.ID)
+			}
+			if !reflect.DeepEqual(agents, tt.agents) {
+				t.Fatalf("agents = %v, want %v", agents, tt.agents)
+			}
+			if body := api.last().Body; body["taskId"] != testTaskID {
+				t.Errorf("body = %v", body)
+			}
+			if len(matches) > 0 {
+				m := matches[0]
+				if m.Score != 103 || m.SkillMatches != 2 || m.Agent.Record.CompletedTasks != 3 || m.Agent.CreatedAt.IsZero() {
+					t.Errorf("match = %+v", m)
+				}
+			}
+		})
+	}
+}
+
+func TestFindAgentsMissingTask(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/matching/find-agents", fixture(t, "matching", "task_missing"))
+
+	_, err := api.client().FindAgents(context.Background(), "nope")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestAutoMatch(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/matching/auto-match", fixture(t, "matching", "auto_match"))
+
+	best, err := api.client().AutoMatch(context.Background(), testTaskID)
+	if err != nil {
+		t.Fatalf("AutoMatch: %v", err)
+	}
+	if best.Agent.ID != "agent-7" || best.Score != 93 || best.Agent.Status != AgentAvailable {
+		t.Errorf("best = %+v", best)
+	}
+	if body := api.last().Body; body["taskId"] != testTaskID {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestAutoMatchNotFound(t *testing.T) {
+	// A missing task and no available agent are both 404s
+	for _, name := range []string{"task_missing", "auto_match_none"} {
+		t.Run(name, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/matching/auto-match", fixture(t, "matching", name))
+
+			_, err := api.client().AutoMatch(context.Background(), testTaskID)
+			wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+		})
+	}
+}
+
+func TestRecommendTasks(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/matching/recommend-tasks", fixture(t, "matching", "recommend_tasks"))
+
+	recs, err := api.client().RecommendTasks(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("RecommendTasks: %v", err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("recommendations = %+v", recs)
+	}
+	r := recs[0]
+	if r.Task.ID != testTaskID || r.Task.Status != "posted" || r.SkillMatches != 2 ||
+		!reflect.DeepEqual(r.Task.Skills(), []string{"rust", "audit"}) {
+		t.Errorf("recommendation = %+v", r)
+	}
+	if body := api.last().Body; body["agentId"] != "agent-7" {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestRecommendTasksEmpty(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/matching/recommend-tasks", fixture(t, "matching", "recommend_tasks_none"))
+	client := api.client()
+
+	recs, err := client.RecommendTasks(context.Background(), "agent-7")
+	if err != nil || len(recs) != 0 {
+		t.Fatalf("RecommendTasks = %+v, %v", recs, err)
+	}
+
+	api.on("POST /api/matching/recommend-tasks", fixture(t, "matching", "agent_missing"))
+	_, err = client.RecommendTasks(context.Background(), "nobody")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestPredictMatches(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/predictive/match", fixture(t, "matching", "predict"))
+	client := api.client()
+
+	if _, err := client.PredictMatches(context.Background(), PredictMatchRequest{TaskID: testTaskID, PosterID: "alice"}); err == nil {
+		t.Error("PredictMatches without skills: want error")
+	}
+	if n := api.count(); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+
+	predicted, err := client.PredictMatches(context.Background(), PredictMatchRequest{
+		TaskID: testTaskID, RequiredSkills: []string{"rust"}, PosterID: "alice",
+	})
+	if err != nil {
+		t.Fatalf("PredictMatches: %v", err)
+	}
+	body := api.last().Body
+	if _, ok := body["complexity"]; ok || body["posterId"] != "alice" {
+		t.Errorf("body = %v, want the server's default complexity", body)
+	}
+	if predicted.TotalCandidates != 2 || len(predicted.Matches) != 2 {
+		t.Fatalf("predicted = %+v", predicted)
+	}
+	m := predicted.Matches[0]
+	if m.AgentID != "agent-7" || m.PredictedSuccess != 88 || m.Confidence != 0.3 || m.PredictedTimeline != "9 hours" {
+		t.Errorf("match = %+v", m)
+	}
+	if risks := predicted.Matches[1].RiskFactors; len(risks) != 1 {
+		t.Errorf("risk factors = %v", risks)
+	}
+}
+
+func TestPredictMatchesInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/predictive/match", fixture(t, "matching", "predict_invalid"))
+
+	_, err := api.client().PredictMatches(context.Background(), PredictMatchRequest{
+		TaskID: testTaskID, RequiredSkills: []string{"rust"}, Complexity: 11, PosterID: "alice",
+	})
+	apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "complexity" {
+		t.Errorf("details = %+v", apiErr.Details)
+	}
+}
+
+func TestSendMatchFeedback(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/predictive/feedback", fixture(t, "matching", "feedback"))
+
+	profile, err := api.client().SendMatchFeedback(context.Background(), MatchFeedback{
+		AgentID: "agent-7", TaskID: testTaskID, Success: true, CompletionTime: 6,
+	})
+	if err != nil {
+		t.Fatalf("SendMatchFeedback: %v", err)
+	}
+	if profile.SuccessRate != 1 || profile.AvgCompletionTime != 6 || profile.Skills["rust"] != 2 {
+		t.Errorf("profile = %+v", profile)
+	}
+	// The server requires skillsUsed, even when empty
+	body := api.last().Body
+	if used, ok := body["skillsUsed"].([]interface{}); !ok || len(used) != 0 || body["success"] != true {
+		t.Errorf("body = %v", body)
+	}
+	if _, ok := body["collaborators"]; ok {
+		t.Errorf("body = %v, want no collaborators", body)
+	}
+}
+
+func TestSendMatchFeedbackInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/predictive/feedback", fixture(t, "matching", "feedback_invalid"))
+
+	_, err := api.client().SendMatchFeedback(context.Background(), MatchFeedback{AgentID: "agent-7", TaskID: testTaskID})
+	wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+}
+
+func TestRecommendTeam(t *testing.T) {
+	tests := []struct {
+		fixture string
+		members int
+	}{
+		{"team", 2},
+		{"team_empty", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/predictive/team", fixture(t, "matching", tt.fixture))
+
+			team, err := api.client().RecommendTeam(context.Background(), TeamRequest{
+				TaskID: testTaskID, RequiredSkills: []string{"rust", "audit"}, TeamSize: 2,
+			})
+			if err != nil {
+				t.Fatalf("RecommendTeam: %v", err)
+			}
+			if len(team.Members) != tt.members {
+				t.Fatalf("team = %+v", team)
+			}
+			if body := api.last().Body; body["teamSize"] != 2.0 {
+				t.Errorf("body = %v", body)
+			}
+			if tt.members > 0 && (team.Members[0].Role != RoleLead || team.Members[0].Skills["rust"] != 85 ||
+				team.Members[1].Role != RoleContributor || team.Compatibility != 0.5 || team.PredictedSuccess != 45) {
+				t.Errorf("team = %+v", team)
+			}
+		})
+	}
+}
+
+func TestRecommendTeamInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	client := api.client()
+
+	for _, req := range []TeamRequest{
+		{TaskID: testTaskID, TeamSize: 2},
+		{TaskID: testTaskID, RequiredSkills: []string{"rust"}, TeamSize: 1},
+		{TaskID: testTaskID, RequiredSkills: []string{"rust"}, TeamSize: 6},
+	} {
+		if _, err := client.RecommendTeam(context.Background(), req); err == nil {
+			t.Errorf("RecommendTeam(%+v): want error", req)
+		}
+	}
+	if n := api.count(); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+}
+
+func TestGetAgentAnalytics(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/predictive/agent/agent-7/analytics", fixture(t, "matching", "analytics"))
+	api.on("GET /api/predictive/agent/nobody/analytics", fixture(t, "matching", "analytics_new"))
+	client := api.client()
+
+	analytics, err := client.GetAgentAnalytics(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("GetAgentAnalytics: %v", err)
+	}
+	if analytics.Performance.RecentSuccessRate != 1 || analytics.Performance.AvgCompletionTime != 6 ||
+		!reflect.DeepEqual(analytics.Collaboration.PreferredPartners, []string{"agent-9"}) ||
+		analytics.MarketValue.SuggestedRate != 132 || len(analytics.Recommendations) != 3 {
+		t.Errorf("analytics = %+v", analytics)
+	}
+
+	// An agent without feedback gets defaults, and no recent success rate
+	fresh, err := client.GetAgentAnalytics(context.Background(), "nobody")
+	if err != nil {
+		t.Fatalf("GetAgentAnalytics: %v", err)
+	}
+	if fresh.AgentID != "nobody" || fresh.Performance.OverallSuccessRate != 0.5 || fresh.Performance.RecentSuccessRate != 0 {
+		t.Errorf("analytics = %+v", fresh)
+	}
+}
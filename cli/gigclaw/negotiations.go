@@ -0,0 +1,286 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Negotiation statuses
+const (
+	NegotiationActive     = "active"
+	NegotiationAgreed     = "agreed"
+	NegotiationDeadlocked = "deadlocked"
+	NegotiationCancelled  = "cancelled"
+)
+
+// MaxNegotiationMessage is the longest message the API accepts
+const MaxNegotiationMessage = 1000
+
+// Negotiation is a structured haggle over the terms of a task between its
+// poster and a worker
+type Negotiation struct {
+	ID            string                 `json:"id"`
+	TaskID        string                 `json:"taskId"`
+	PosterID      string                 `json:"posterId"`
+	WorkerID      string                 `json:"workerId"`
+	Status        string                 `json:"status"` // active, agreed, deadlocked, cancelled
+	Messages      []NegotiationMessage   `json:"messages"`
+	ProposedTerms NegotiationTerms       `json:"proposedTerms"` // the terms on the table
+	Constraints   NegotiationConstraints `json:"constraints"`
+	StartedAt     Timestamp              `json:"startedAt"`
+	LastActivity  Timestamp              `json:"lastActivity"`
+	Analysis      *NegotiationAnalysis   `json:"aiAnalysis,omitempty"`
+	CancelReason  string                 `json:"cancelReason,omitempty"`
+}
+
+// NegotiationMessage is a message from one party, optionally carrying a
+// counter-offer
+type NegotiationMessage struct {
+	ID            string            `json:"id"`
+	FromAgentID   string            `json:"fromAgentId"`
+	Message       string            `json:"message"`
+	ProposedTerms *NegotiationTerms `json:"proposedTerms,omitempty"`
+	Sentiment     string            `json:"sentiment"` // positive, neutral or negative
+	Timestamp     Timestamp         `json:"timestamp"`
+}
+
+// NegotiationTerms are the terms of an offer. Unset fields are left
+// unchanged by a counter-offer.
+type NegotiationTerms struct {
+	Price           float64          `json:"price,omitempty"`
+	Timeline        string           `json:"timeline,omitempty"`
+	Milestones      []Milestone      `json:"milestones,omitempty"`
+	PaymentSchedule *PaymentSchedule `json:"paymentSchedule,omitempty"`
+}
+
+// Milestone is a part of the work with its own deadline and payment
+type Milestone struct {
+	Description string  `json:"description"`
+	Deadline    string  `json:"deadline"`
+	Payment     float64 `json:"payment"`
+}
+
+// PaymentSchedule splits the payment into percentages
+type PaymentSchedule struct {
+	Upfront        float64 `json:"upfront"`
+	OnCompletion   float64 `json:"onCompletion"`
+	OnVerification float64 `json:"onVerification"`
+}
+
+// TermConstraints are the limits one party will accept
+type TermConstraints struct {
+	MinPrice       float64  `json:"minPrice,omitempty"`
+	MaxPrice       float64  `json:"maxPrice,omitempty"`
+	MinTimeline    string   `json:"minTimeline,omitempty"`
+	MaxTimeline    string   `json:"maxTimeline,omitempty"`
+	NonNegotiables []string `json:"nonNegotiables,omitempty"`
+}
+
+// NegotiationConstraints are the limits of both parties, which the server
+// uses to recommend counter-offers
+type NegotiationConstraints struct {
+	Poster TermConstraints `json:"poster"`
+	Worker TermConstraints `json:"worker"`
+}
+
+// NegotiationAnalysis is the server's reading of a negotiation
+type NegotiationAnalysis struct {
+	Sentiment          string            `json:"sentiment"`
+	LikelihoodOfDeal   float64           `json:"likelihoodOfDeal"` // 0-1
+	RecommendedCounter *NegotiationTerms `json:"recommendedCounter,omitempty"`
+	NextBestAction     string            `json:"nextBestAction"`
+	NegotiationStyle   string            `json:"negotiationStyle"` // collaborative, competitive or accommodating
+}
+
+// Offer is a set of terms put on the table during a negotiation
+type Offer struct {
+	Time        time.Time
+	FromAgentID string
+	Message     string
+	Terms       NegotiationTerms // the full terms after this offer
+}
+
+// merge applies the fields set in update, as the server does
+func (t NegotiationTerms) merge(update NegotiationTerms) NegotiationTerms {
+	if update.Price != 0 {
+		t.Price = update.Price
+	}
+	if update.Timeline != "" {
+		t.Timeline = update.Timeline
+	}
+	if update.Milestones != nil {
+		t.Milestones = update.Milestones
+	}
+	if update.PaymentSchedule != nil {
+		t.PaymentSchedule = update.PaymentSchedule
+	}
+	return t
+}
+
+// Offers returns the offers made so far, oldest first: the opening bid by
+// the worker followed by every counter-offer. Messages without terms are
+// left out.
+func (n *Negotiation) Offers() []Offer {
+	messages := append([]NegotiationMessage(nil), n.Messages...)
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].Timestamp.Before(messages[j].Timestamp.Time) })
+
+	opening := openingTerms(n.ProposedTerms, messages)
+	offers := []Offer{{Time: n.StartedAt.Time, FromAgentID: n.WorkerID, Terms: opening}}
+	terms := opening
+	for _, m := range messages {
+		if m.ProposedTerms == nil {
+			continue
+		}
+		terms = terms.merge(*m.ProposedTerms)
+		offers = append(offers, Offer{Time: m.Timestamp.Time, FromAgentID: m.FromAgentID, Message: m.Message, Terms: terms})
+	}
+	return offers
+}
+
+// openingTerms reconstructs the initial bid from the current terms. The
+// server only keeps the merged result, so a field changed by any
+// counter-offer is unknown and left unset.
+func openingTerms(current NegotiationTerms, messages []NegotiationMessage) NegotiationTerms {
+	opening := current
+	for _, m := range messages {
+		if m.ProposedTerms == nil {
+			continue
+		}
+		if m.ProposedTerms.Price != 0 {
+			opening.Price = 0
+		}
+		if m.ProposedTerms.Timeline != "" {
+			opening.Timeline = ""
+		}
+		if m.ProposedTerms.Milestones != nil {
+			opening.Milestones = nil
+		}
+		if m.ProposedTerms.PaymentSchedule != nil {
+			opening.PaymentSchedule = nil
+		}
+	}
+	return opening
+}
+
+// Counterparty returns the other party to the negotiation, or "" if
+// agentID is not a party
+func (n *Negotiation) Counterparty(agentID string) string {
+	switch agentID {
+	case n.PosterID:
+		return n.WorkerID
+	case n.WorkerID:
+		return n.PosterID
+	}
+	return ""
+}
+
+// StartNegotiationRequest holds the opening of a negotiation
+type StartNegotiationRequest struct {
+	TaskID      string                  `json:"taskId"`
+	PosterID    string                  `json:"posterId"`
+	WorkerID    string                  `json:"workerId"`
+	InitialBid  NegotiationTerms        `json:"initialBid"`
+	Constraints *NegotiationConstraints `json:"constraints,omitempty"`
+}
+
+// NegotiationMessageRequest holds a message and an optional counter-offer
+type NegotiationMessageRequest struct {
+	FromAgentID   string            `json:"fromAgentId"`
+	Message       string            `json:"message"` // 1-1000 characters
+	ProposedTerms *NegotiationTerms `json:"proposedTerms,omitempty"`
+}
+
+// NegotiationResponse represents the API response for a negotiation change
+type NegotiationResponse struct {
+	Message     string            `json:"message"`
+	Negotiation Negotiation       `json:"negotiation"`
+	FinalTerms  *NegotiationTerms `json:"finalTerms,omitempty"` // accept only
+}
+
+// StartNegotiation opens a negotiation over a task with the worker's
+// initial bid
+func (c *Client) StartNegotiation(ctx context.Context, req StartNegotiationRequest, opts ...RequestOption) (*Negotiation, error) {
+	var response NegotiationResponse
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "start negotiation", http.MethodPost, "/api/negotiations/start", header, req, &response, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &response.Negotiation, nil
+}
+
+// SendNegotiationMessage sends a message, with a counter-offer if
+// ProposedTerms is set. Only the poster and the worker may send messages,
+// and only while the negotiation is active.
+func (c *Client) SendNegotiationMessage(ctx context.Context, negotiationID string, req NegotiationMessageRequest, opts ...RequestOption) (*Negotiation, error) {
+	return c.updateNegotiation(ctx, "send negotiation message", negotiationID, "message", req, opts)
+}
+
+// AcceptNegotiation agrees to the terms on the table, finalising the
+// negotiation. The agreed terms are the negotiation's ProposedTerms.
+func (c *Client) AcceptNegotiation(ctx context.Context, negotiationID, agentID string, opts ...RequestOption) (*Negotiation, error) {
+	payload := map[string]interface{}{
+		"agentId": agentID,
+	}
+	return c.updateNegotiation(ctx, "accept negotiation", negotiationID, "accept", payload, opts)
+}
+
+// CancelNegotiation walks away from a negotiation
+func (c *Client) CancelNegotiation(ctx context.Context, negotiationID, agentID, reason string, opts ...RequestOption) (*Negotiation, error) {
+	payload := map[string]interface{}{
+		"agentId": agentID,
+	}
+	if reason != "" {
+		payload["reason"] = reason
+	}
+	return c.updateNegotiation(ctx, "cancel negotiation", negotiationID, "cancel", payload, opts)
+}
+
+// updateNegotiation posts an action to /api/negotiations/:id/<action>
+func (c *Client) updateNegotiation(ctx context.Context, op, negotiationID, action string, payload interface{}, opts []RequestOption) (*Negotiation, error) {
+	var response NegotiationResponse
+	path := fmt.Sprintf("/api/negotiations/%s/%s", url.PathEscape(negotiationID), action)
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, op, http.MethodPost, path, header, payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Negotiation, nil
+}
+
+// GetNegotiation retrieves a negotiation with its messages
+func (c *Client) GetNegotiation(ctx context.Context, negotiationID string) (*Negotiation, error) {
+	var negotiation Negotiation
+	path := fmt.Sprintf("/api/negotiations/%s", url.PathEscape(negotiationID))
+	if err := c.do(ctx, "get negotiation", http.MethodGet, path, nil, &negotiation, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &negotiation, nil
+}
+
+// GetNegotiationAnalysis retrieves the server's analysis of a negotiation,
+// with a recommended counter-offer when it has one
+func (c *Client) GetNegotiationAnalysis(ctx context.Context, negotiationID string) (*NegotiationAnalysis, error) {
+	var analysis NegotiationAnalysis
+	path := fmt.Sprintf("/api/negotiations/%s/analysis", url.PathEscape(negotiationID))
+	if err := c.do(ctx, "get negotiation analysis", http.MethodGet, path, nil, &analysis, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &analysis, nil
+}
+
+// ListAgentNegotiations retrieves the negotiations an agent is a party
+// to, most recently active first
+func (c *Client) ListAgentNegotiations(ctx context.Context, agentID string) ([]Negotiation, error) {
+	var response struct {
+		Negotiations []Negotiation `json:"negotiations"`
+		Count        int           `json:"count"`
+	}
+	path := fmt.Sprintf("/api/negotiations/agent/%s", url.PathEscape(agentID))
+	if err := c.do(ctx, "list negotiations", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Negotiations, nil
+}
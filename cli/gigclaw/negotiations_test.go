@@ -0,0 +1,252 @@
+package gigclaw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testNegotiationID = "neg-1767225600000-k3j9x2m1q"
+
+func TestStartNegotiation(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/negotiations/start", fixture(t, "negotiations", "start"))
+
+	n, err := api.client().StartNegotiation(context.Background(), StartNegotiationRequest{
+		TaskID: testTaskID, PosterID: "alice", WorkerID: "agent-7",
+		InitialBid: NegotiationTerms{Price: 140, Timeline: "5 days"},
+	}, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("StartNegotiation: %v", err)
+	}
+	if n.ID != testNegotiationID || n.Status != NegotiationActive || n.ProposedTerms.Price != 140 ||
+		n.Constraints.Poster.MaxPrice != 150 || !n.StartedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("negotiation = %+v", n)
+	}
+	req := api.last()
+	if bid, _ := req.Body["initialBid"].(map[string]interface{}); bid["price"] != 140.0 || bid["timeline"] != "5 days" {
+		t.Errorf("body = %v", req.Body)
+	}
+	if _, ok := req.Body["constraints"]; ok {
+		t.Errorf("empty constraints sent: %v", req.Body)
+	}
+	if req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("header = %v", req.Header)
+	}
+}
+
+func TestStartNegotiationInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/negotiations/start", fixture(t, "negotiations", "start_invalid"))
+
+	_, err := api.client().StartNegotiation(context.Background(), StartNegotiationRequest{TaskID: testTaskID})
+	apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "initialBid" {
+		t.Errorf("details = %+v", apiErr.Details)
+	}
+}
+
+func TestSendNegotiationMessage(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/negotiations/"+testNegotiationID+"/message", fixture(t, "negotiations", "message"))
+
+	n, err := api.client().SendNegotiationMessage(context.Background(), testNegotiationID, NegotiationMessageRequest{
+		FromAgentID: "alice", Message: "Would you do it for 120?", ProposedTerms: &NegotiationTerms{Price: 120},
+	})
+	if err != nil {
+		t.Fatalf("SendNegotiationMessage: %v", err)
+	}
+	if len(n.Messages) != 1 || n.Messages[0].Timestamp.IsZero() || n.ProposedTerms.Price != 120 {
+		t.Errorf("negotiation = %+v", n)
+	}
+	if n.Analysis == nil || n.Analysis.RecommendedCounter == nil || n.Analysis.RecommendedCounter.Price != 138 {
+		t.Errorf("analysis = %+v", n.Analysis)
+	}
+	body := api.last().Body
+	if terms, _ := body["proposedTerms"].(map[string]interface{}); body["fromAgentId"] != "alice" || terms["price"] != 120.0 {
+		t.Errorf("body = %v", body)
+	}
+
+	// Plain messages carry no terms
+	if _, err := api.client().SendNegotiationMessage(context.Background(), testNegotiationID,
+		NegotiationMessageRequest{FromAgentID: "alice", Message: "Still there?"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := api.last().Body["proposedTerms"]; ok {
+		t.Errorf("body = %v", api.last().Body)
+	}
+}
+
+func TestSendNegotiationMessageErrors(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		status   int
+		sentinel error
+	}{
+		{"message_inactive", http.StatusBadRequest, ErrBadRequest},
+		{"message_not_party", http.StatusForbidden, ErrForbidden},
+		{"missing", http.StatusNotFound, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/negotiations/"+testNegotiationID+"/message", fixture(t, "negotiations", tt.fixture))
+
+			_, err := api.client().SendNegotiationMessage(context.Background(), testNegotiationID,
+				NegotiationMessageRequest{FromAgentID: "eve", Message: "Hello"})
+			wantAPIError(t, err, tt.status, tt.sentinel)
+		})
+	}
+}
+
+func TestAcceptNegotiation(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/negotiations/"+testNegotiationID+"/accept", fixture(t, "negotiations", "accept"))
+	client := api.client()
+
+	n, err := client.AcceptNegotiation(context.Background(), testNegotiationID, "agent-7", IdempotencyKey("k2"))
+	if err != nil {
+		t.Fatalf("AcceptNegotiation: %v", err)
+	}
+	if n.Status != NegotiationAgreed || n.ProposedTerms.Price != 120 {
+		t.Errorf("negotiation = %+v", n)
+	}
+	if req := api.last(); req.Body["agentId"] != "agent-7" || req.Header.Get("Idempotency-Key") != "k2" {
+		t.Errorf("request = %+v", req)
+	}
+
+	api.on("POST /api/negotiations/"+testNegotiationID+"/accept", fixture(t, "negotiations", "accept_finalized"))
+	_, err = client.AcceptNegotiation(context.Background(), testNegotiationID, "agent-7")
+	wantAPIError(t, err, http.StatusBadRequest, ErrBadRequest)
+}
+
+func TestCancelNegotiation(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/negotiations/"+testNegotiationID+"/cancel", fixture(t, "negotiations", "cancel"))
+	client := api.client()
+
+	n, err := client.CancelNegotiation(context.Background(), testNegotiationID, "alice", "Found another agent")
+	if err != nil {
+		t.Fatalf("CancelNegotiation: %v", err)
+	}
+	if n.Status != NegotiationCancelled || n.CancelReason != "Found another agent" {
+		t.Errorf("negotiation = %+v", n)
+	}
+	if body := api.last().Body; body["agentId"] != "alice" || body["reason"] != "Found another agent" {
+		t.Errorf("body = %v", body)
+	}
+
+	if _, err := client.CancelNegotiation(context.Background(), testNegotiationID, "alice", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := api.last().Body["reason"]; ok {
+		t.Errorf("empty reason sent: %v", api.last().Body)
+	}
+
+	_, err = client.CancelNegotiation(context.Background(), "neg-x", "alice", "")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestGetNegotiation(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/negotiations/"+testNegotiationID, fixture(t, "negotiations", "get"))
+	api.on("GET /api/negotiations/neg-x", fixture(t, "negotiations", "missing"))
+	client := api.client()
+
+	n, err := client.GetNegotiation(context.Background(), testNegotiationID)
+	if err != nil {
+		t.Fatalf("GetNegotiation: %v", err)
+	}
+	if n.ID != testNegotiationID || n.WorkerID != "agent-7" || len(n.Messages) != 1 ||
+		!n.LastActivity.Equal(time.Date(2026, 1, 1, 0, 10, 0, 0, time.UTC)) {
+		t.Errorf("negotiation = %+v", n)
+	}
+
+	_, err = client.GetNegotiation(context.Background(), "neg-x")
+	apiErr := wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+	if apiErr.Message != "Negotiation not found" {
+		t.Errorf("message = %q", apiErr.Message)
+	}
+}
+
+func TestGetNegotiationAnalysis(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		likelihood float64
+		counter    float64 // 0 for no recommended counter
+	}{
+		{"analysis", 0.8, 138},
+		{"analysis_empty", 0.5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/negotiations/"+testNegotiationID+"/analysis", fixture(t, "negotiations", tt.fixture))
+
+			analysis, err := api.client().GetNegotiationAnalysis(context.Background(), testNegotiationID)
+			if err != nil {
+				t.Fatalf("GetNegotiationAnalysis: %v", err)
+			}
+			if analysis.LikelihoodOfDeal != tt.likelihood || analysis.NextBestAction == "" {
+				t.Errorf("analysis = %+v", analysis)
+			}
+			switch {
+			case tt.counter == 0 && analysis.RecommendedCounter != nil:
+				t.Errorf("counter = %+v, want none", analysis.RecommendedCounter)
+			case tt.counter != 0 && (analysis.RecommendedCounter == nil || analysis.RecommendedCounter.Price != tt.counter):
+				t.Errorf("counter = %+v, want price %v", analysis.RecommendedCounter, tt.counter)
+			}
+		})
+	}
+}
+
+func TestListAgentNegotiations(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/negotiations/agent/agent-7", fixture(t, "negotiations", "agent"))
+
+	list, err := api.client().ListAgentNegotiations(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("ListAgentNegotiations: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != testNegotiationID || list[1].Status != NegotiationDeadlocked {
+		t.Errorf("negotiations = %+v", list)
+	}
+}
+
+func TestNegotiationOffers(t *testing.T) {
+	at := func(min int) Timestamp {
+		return Timestamp{time.Date(2026, 1, 1, 0, min, 0, 0, time.UTC)}
+	}
+	n := Negotiation{
+		PosterID:      "alice",
+		WorkerID:      "bob",
+		StartedAt:     at(0),
+		ProposedTerms: NegotiationTerms{Price: 85, Timeline: "5 days"},
+		Messages: []NegotiationMessage{
+			{FromAgentID: "bob", Message: "Fine, 85", ProposedTerms: &NegotiationTerms{Price: 85}, Timestamp: at(20)},
+			{FromAgentID: "alice", Message: "80?", ProposedTerms: &NegotiationTerms{Price: 80}, Timestamp: at(10)},
+			{FromAgentID: "alice", Message: "Deal soon?", Timestamp: at(30)},
+		},
+	}
+
+	var got []string
+	for _, o := range n.Offers() {
+		got = append(got, o.FromAgentID+" "+formatFloat(o.Terms.Price)+" "+o.Terms.Timeline)
+	}
+	want := []string{"bob 0 5 days", "alice 80 5 days", "bob 85 5 days"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("offers = %q, want %q", got, want)
+	}
+
+	if n.Counterparty("alice") != "bob" || n.Counterparty("bob") != "alice" || n.Counterparty("carol") != "" {
+		t.Errorf("counterparty mismatch")
+	}
+}
+
+func formatFloat(v float64) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
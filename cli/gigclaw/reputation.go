@@ -0,0 +1,93 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Reputation is an agent's reputation after inactivity decay
+type Reputation struct {
+	AgentID             string             `json:"agentId"`
+	BaseReputation      float64            `json:"baseReputation"`
+	EffectiveReputation float64            `json:"effectiveReputation"` // 0-100
+	StreakDays          int                `json:"streakDays"`
+	StreakBonus         float64            `json:"streakBonus"` // percent
+	DecayRate           float64            `json:"decayRate"`   // points per inactive day
+	DaysInactive        int                `json:"daysInactive"`
+	DecayAmount         float64            `json:"decayAmount"`
+	NextDecayAt         Timestamp          `json:"nextDecayAt"`
+	LastActivity        Timestamp          `json:"lastActivity"`
+	Bonuses             map[string]float64 `json:"bonuses,omitempty"`   // multipliers, e.g. 0.1 for +10%
+	Penalties           map[string]float64 `json:"penalties,omitempty"` // multipliers, e.g. 0.1 for -10%
+	Rating              float64            `json:"rating"`              // average stars, 0 when unrated
+	RatingCount         int                `json:"ratingCount"`
+	SkillLevels         map[string]float64 `json:"skillLevels,omitempty"`
+}
+
+// GetReputation retrieves an agent's reputation
+func (c *Client) GetReputation(ctx context.Context, agentID string) (*Reputation, error) {
+	var rep Reputation
+	path := fmt.Sprintf("/api/reputation/%s", url.PathEscape(agentID))
+	if err := c.do(ctx, "get reputation", http.MethodGet, path, nil, &rep, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &rep, nil
+}
+
+// LeaderboardEntry is an agent's place on the reputation leaderboard
+type LeaderboardEntry struct {
+	Rank                int     `json:"rank"`
+	AgentID             string  `json:"agentId"`
+	EffectiveReputation float64 `json:"effectiveReputation"`
+	BaseReputation      float64 `json:"baseReputation"`
+	StreakDays          int     `json:"streakDays"`
+	Rating              float64 `json:"rating"`
+}
+
+// Leaderboard ranks agents by effective reputation
+type Leaderboard struct {
+	Entries []LeaderboardEntry `json:"leaderboard"` // at most 100, best first
+	Total   int                `json:"total"`       // agents with a reputation
+}
+
+// GetLeaderboard retrieves the reputation leaderboard
+func (c *Client) GetLeaderboard(ctx context.Context) (*Leaderboard, error) {
+	var board Leaderboard
+	if err := c.do(ctx, "get leaderboard", http.MethodGet, "/api/reputation/leaderboard", nil, &board, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// RateAgentRequest rates the agent of a verified task
+type RateAgentRequest struct {
+	TaskID   string `json:"taskId"`
+	PosterID string `json:"posterId"`
+	Stars    int    `json:"stars"` // 1-5
+}
+
+// AgentRating is the outcome of rating an agent
+type AgentRating struct {
+	AgentID     string  `json:"agentId"`
+	TaskID      string  `json:"taskId"`
+	Stars       int     `json:"stars"`
+	Rating      float64 `json:"rating"` // the agent's new average
+	RatingCount int     `json:"ratingCount"`
+}
+
+// RateAgent rates the agent of a verified task as its poster, which needs
+// an API key created for the poster. Each task can be rated once; rating it
+// again returns ErrConflict.
+func (c *Client) RateAgent(ctx context.Context, req RateAgentRequest, opts ...RequestOption) (*AgentRating, error) {
+	if req.Stars < 1 || req.Stars > 5 {
+		return nil, fmt.Errorf("stars must be 1-5, got %d", req.Stars)
+	}
+	var rating AgentRating
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "rate agent", http.MethodPost, "/api/reputation/rate", header, req, &rating, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
@@ -0,0 +1,132 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetReputation(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/reputation/agent-7", fixture(t, "reputation", "get"))
+
+	rep, err := api.client().GetReputation(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("GetReputation: %v", err)
+	}
+	if rep.EffectiveReputation != 58.5 || rep.DaysInactive != 3 || rep.DecayAmount != 1.5 ||
+		rep.Bonuses["audit"] != 0.1 || rep.Penalties["late"] != 0.05 || rep.Rating != 4.5 || rep.RatingCount != 2 {
+		t.Errorf("reputation = %+v", rep)
+	}
+	if !rep.LastActivity.Equal(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)) ||
+		!rep.NextDecayAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("last activity %v, next decay %v", rep.LastActivity, rep.NextDecayAt)
+	}
+}
+
+func TestGetReputationNewAgent(t *testing.T) {
+	// The API creates a reputation for any agent ID rather than a 404
+	api := newMockAPI(t)
+	api.on("GET /api/reputation/nobody", fixture(t, "reputation", "get_new"))
+
+	rep, err := api.client().GetReputation(context.Background(), "nobody")
+	if err != nil {
+		t.Fatalf("GetReputation: %v", err)
+	}
+	if rep.BaseReputation != 50 || rep.EffectiveReputation != 50 || rep.RatingCount != 0 ||
+		len(rep.Bonuses) != 0 || len(rep.Penalties) != 0 {
+		t.Errorf("reputation = %+v", rep)
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	tests := []struct {
+		fixture string
+		total   int
+	}{
+		{"leaderboard", 2},
+		{"leaderboard_empty", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/reputation/leaderboard", fixture(t, "reputation", tt.fixture))
+
+			board, err := api.client().GetLeaderboard(context.Background())
+			if err != nil {
+				t.Fatalf("GetLeaderboard: %v", err)
+			}
+			if board.Total != tt.total || len(board.Entries) != tt.total {
+				t.Fatalf("leaderboard = %+v", board)
+			}
+			if tt.total > 0 {
+				first, second := board.Entries[0], board.Entries[1]
+				if first.Rank != 1 || first.AgentID != "agent-7" || first.Rating != 4.5 || second.StreakDays != 1 {
+					t.Errorf("entries = %+v", board.Entries)
+				}
+			}
+		})
+	}
+}
+
+func TestRateAgent(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/reputation/rate", fixture(t, "reputation", "rate"))
+
+	rating, err := api.client().RateAgent(context.Background(),
+		RateAgentRequest{TaskID: testTaskID, PosterID: "alice", Stars: 5}, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("RateAgent: %v", err)
+	}
+	if rating.AgentID != "agent-7" || rating.Stars != 5 || rating.Rating != 4.5 || rating.RatingCount != 2 {
+		t.Errorf("rating = %+v", rating)
+	}
+	req := api.last()
+	if req.Body["taskId"] != testTaskID || req.Body["posterId"] != "alice" || req.Body["stars"] != 5.0 ||
+		req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestRateAgentErrors(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		status   int
+		sentinel error
+		rejected bool
+	}{
+		{"rate_missing", http.StatusNotFound, ErrNotFound, true},
+		{"rate_unverified", http.StatusBadRequest, ErrBadRequest, true},
+		{"rate_not_poster", http.StatusForbidden, ErrForbidden, true},
+		{"rate_invalid", http.StatusBadRequest, ErrValidation, true},
+		// Rated already, possibly by an earlier attempt of this one
+		{"rate_rated", http.StatusConflict, ErrConflict, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/reputation/rate", fixture(t, "reputation", tt.fixture))
+
+			_, err := api.client().RateAgent(context.Background(), RateAgentRequest{TaskID: testTaskID, PosterID: "alice", Stars: 4})
+			apiErr := wantAPIError(t, err, tt.status, tt.sentinel)
+			if apiErr.Rejected() != tt.rejected {
+				t.Errorf("rejected = %v, want %v", apiErr.Rejected(), tt.rejected)
+			}
+		})
+	}
+}
+
+func TestRateAgentStars(t *testing.T) {
+	api := newMockAPI(t)
+	client := api.client()
+
+	for _, stars := range []int{0, 6} {
+		if _, err := client.RateAgent(context.Background(), RateAgentRequest{TaskID: testTaskID, PosterID: "alice", Stars: stars}); err == nil {
+			t.Errorf("RateAgent with %d stars: want error", stars)
+		}
+	}
+	if n := api.count(); n != 0 {
+		t.Errorf("%d requests sent", n)
+	}
+}
@@ -0,0 +1,186 @@
+package gigclaw
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Requests are retried on transport errors and on 408, 429, 500, 502, 503
+// and 504 responses, waiting an exponentially growing, jittered backoff
+// between attempts. A Retry-After header on 429 and 503 responses takes
+// precedence over the computed backoff.
+//
+// Non-idempotent requests (POST, PATCH) are only retried when the server
+// cannot have acted on them: the connection was never established, or
+// the server answered 429 or 503.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed backoff
+	MaxBackoff time.Duration
+
+	// Multiplier grows the backoff after each attempt
+	Multiplier float64
+
+	// Jitter randomly shortens each backoff by up to this fraction (0-1)
+	Jitter float64
+
+	// PerAttemptTimeout bounds each individual attempt; 0 means no limit
+	// beyond the caller's context and the HTTP client timeout
+	PerAttemptTimeout time.Duration
+
+	// MaxRetryAfter is the longest Retry-After the client will honour.
+	// Responses asking for a longer wait are returned to the caller.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy retries three times with exponential backoff and jitter
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	MaxRetryAfter:  time.Minute,
+}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{}
+
+func (p RetryPolicy) validate() error {
+	switch {
+	case p.MaxRetries < 0:
+		return errors.New("gigclaw: MaxRetries must not be negative")
+	case p.InitialBackoff < 0 || p.MaxBackoff < 0 || p.PerAttemptTimeout < 0 || p.MaxRetryAfter < 0:
+		return errors.New("gigclaw: retry durations must not be negative")
+	case p.Multiplier != 0 && p.Multiplier < 1:
+		return errors.New("gigclaw: retry Multiplier must be at least 1")
+	case p.Jitter < 0 || p.Jitter > 1:
+		return errors.New("gigclaw: retry Jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// backoff returns the wait before the given retry (1-based)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	mult := p.Multiplier
+	if mult == 0 {
+		mult = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(mult, float64(retry-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d -= d * p.Jitter * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// idempotentMethod reports whether repeating a request is harmless
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// safeToRetryStatus reports whether the server rejected the request
+// before acting on it
+func safeToRetryStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
+
+// safeToRetryError reports whether a transport error happened before
+// the request reached the server
+func safeToRetryError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date. It returns false if the header is missing or invalid.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		d := at.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelBody releases a per-attempt context once the body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,299 @@
+package gigclaw
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for srv that records retry waits
+// instead of sleeping
+func newTestClient(t *testing.T, srv *httptest.Server, policy RetryPolicy) (*Client, *[]time.Duration) {
+	t.Helper()
+
+	client, err := NewClient(WithBaseURL(srv.URL), WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	var waits []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return client, &waits
+}
+
+var testPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+	MaxRetryAfter:  time.Minute,
+}
+
+func TestRetryReplaysBodyOnEveryAttempt(t *testing.T) {
+	var calls int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"task":{"id":"t1"}}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newTestClient(t, srv, testPolicy)
+	task, _, err := client.CreateTask(context.Background(), CreateTaskRequest{Title: "Audit", Budget: 10})
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if task.ID != "t1" {
+		t.Errorf("task ID = %q, want t1", task.ID)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("server saw %d attempts, want 3", len(bodies))
+	}
+	for i, b := range bodies {
+		if b == "" || b != bodies[0] {
+			t.Errorf("attempt %d body = %q, want %q", i+1, b, bodies[0])
+		}
+	}
+}
+
+func TestRetryExponentialBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client, waits := newTestClient(t, srv, testPolicy)
+	_, err := client.ListTasks(context.Background())
+	if !errors.Is(err, ErrServer) {
+		t.Fatalf("err = %v, want ErrServer", err)
+	}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+	if len(*waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", *waits, want)
+	}
+	for i := range want {
+		if (*waits)[i] != want[i] {
+			t.Errorf("wait %d = %v, want %v", i, (*waits)[i], want[i])
+		}
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"tasks":[]}`))
+	}))
+	defer srv.Close()
+
+	client, waits := newTestClient(t, srv, testPolicy)
+	if _, err := client.ListTasks(context.Background()); err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("waits = %v, want [7s]", *waits)
+	}
+}
+
+func TestRetryAfterBeyondLimitIsReturned(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newTestClient(t, srv, testPolicy)
+	_, err := client.ListTasks(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v, want rate limited *APIError", err)
+	}
+	if apiErr.RetryAfter != time.Hour {
+		t.Errorf("RetryAfter = %v, want 1h", apiErr.RetryAfter)
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d attempts, want 1", calls)
+	}
+}
+
+func TestRetryPostOnlyWhenSafe(t *testing.T) {
+	tests := []struct {
+		status    int
+		wantCalls int32
+	}{
+		{http.StatusInternalServerError, 1},
+		{http.StatusBadGateway, 1},
+		{http.StatusServiceUnavailable, 4},
+		{http.StatusTooManyRequests, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			client, _ := newTestClient(t, srv, testPolicy)
+			if _, err := client.PlaceBid(context.Background(), "t1", 5, ""); err == nil {
+				t.Fatal("PlaceBid succeeded, want error")
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("server saw %d attempts, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryPostAfterConnectionRefused(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // nothing listening: the request never reaches a server
+
+	client, waits := newTestClient(t, srv, testPolicy)
+	_, err := client.PlaceBid(context.Background(), "t1", 5, "")
+	if !errors.Is(err, ErrUnreachable) {
+		t.Fatalf("err = %v, want ErrUnreachable", err)
+	}
+	if len(*waits) != testPolicy.MaxRetries {
+		t.Errorf("retried %d times, want %d", len(*waits), testPolicy.MaxRetries)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Task not found"}`))
+	}))
+	defer srv.Close()
+
+	client, _ := newTestClient(t, srv, testPolicy)
+	_, err := client.GetTask(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if calls != 1 {
+		t.Errorf("server saw %d attempts, want 1", calls)
+	}
+}
+
+func TestRetryPerAttemptTimeout(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	policy := testPolicy
+	policy.PerAttemptTimeout = 50 * time.Millisecond
+	client, _ := newTestClient(t, srv, policy)
+
+	health, err := client.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("status = %q, want ok", health.Status)
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d attempts, want 2", calls)
+	}
+}
+
+func TestRetryStopsWhenContextCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{
+		MaxRetries:     5,
+		InitialBackoff: time.Hour,
+	}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.ListTasks(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ListTasks took %v after cancellation", elapsed)
+	}
+}
+
+func TestBackoffJitterBounds(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second, Multiplier: 2, Jitter: 0.5}
+	for retry := 1; retry <= 5; retry++ {
+		max := time.Second << (retry - 1)
+		if max > 4*time.Second {
+			max = 4 * time.Second
+		}
+		for i := 0; i < 100; i++ {
+			d := policy.backoff(retry)
+			if d > max || d < max/2 {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", retry, d, max/2, max)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, false},
+		{"30", 30 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
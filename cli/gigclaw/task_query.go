@@ -0,0 +1,299 @@
+package gigclaw
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Task sort orders
+const (
+	SortNewest     = "newest" // default
+	SortOldest     = "oldest"
+	SortBudgetHigh = "budget-high"
+	SortBudgetLow  = "budget-low"
+)
+
+// ListTasksOptions filters, sorts and pages a task listing. Zero values
+// mean no filter.
+type ListTasksOptions struct {
+	Status    string
+	Tags      []string // tasks must have every tag among their skills
+	MinBudget float64
+	MaxBudget float64
+	Currency  string
+	PostedBy  string // poster agent ID
+	Sort      string // SortNewest, SortOldest, SortBudgetHigh or SortBudgetLow
+	Limit     int    // page size; 0 returns every task in one page
+	Cursor    string // NextCursor of the previous page
+}
+
+// TaskPage is one page of a task listing
+type TaskPage struct {
+	Tasks      []Task
+	NextCursor string // empty on the last page
+
+	// Unsupported lists the query parameters the server ignored. The client
+	// applied them itself, after downloading every task.
+	Unsupported []string
+}
+
+// query encodes the options as query parameters, returning the names of
+// the ones that are set
+func (o ListTasksOptions) query() (url.Values, []string) {
+	q := url.Values{}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	for _, tag := range o.Tags {
+		q.Add("tag", tag)
+	}
+	if o.MinBudget > 0 {
+		q.Set("minBudget", strconv.FormatFloat(o.MinBudget, 'f', -1, 64))
+	}
+	if o.MaxBudget > 0 {
+		q.Set("maxBudget", strconv.FormatFloat(o.MaxBudget, 'f', -1, 64))
+	}
+	if o.Currency != "" {
+		q.Set("currency", o.Currency)
+	}
+	if o.PostedBy != "" {
+		q.Set("postedBy", o.PostedBy)
+	}
+	if o.Sort != "" {
+		q.Set("sort", o.Sort)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	}
+
+	var names []string
+	for name := range q {
+		if name != "cursor" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return q, names
+}
+
+func (o ListTasksOptions) validate() error {
+	switch o.Sort {
+	case "", SortNewest, SortOldest, SortBudgetHigh, SortBudgetLow:
+	default:
+		return fmt.Errorf("unknown sort %q", o.Sort)
+	}
+	if o.MaxBudget > 0 && o.MinBudget > o.MaxBudget {
+		return fmt.Errorf("minimum budget %v is above maximum budget %v", o.MinBudget, o.MaxBudget)
+	}
+	if o.Limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	return nil
+}
+
+// ListTasksPage retrieves one page of tasks. Filters, sorting and paging
+// the server does not support are applied on the client and reported in
+// TaskPage.Unsupported. A server that does not paginate sends every task
+// on each call, so walk a long listing with ListTasksIter, which
+// downloads it once.
+func (c *Client) ListTasksPage(ctx context.Context, opts ListTasksOptions) (*TaskPage, error) {
+	page, _, err := c.listTasks(ctx, opts)
+	return page, err
+}
+
+// listTasks is ListTasksPage that also returns, when the client paginates,
+// the matching tasks after the page
+func (c *Client) listTasks(ctx context.Context, opts ListTasksOptions) (*TaskPage, []Task, error) {
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	q, requested := opts.query()
+	path := "/api/tasks"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var response ListTasksResponse
+	if err := c.do(ctx, "list tasks", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, nil, err
+	}
+
+	page := &TaskPage{Tasks: response.Tasks, NextCursor: response.NextCursor}
+	for _, name := range requested {
+		if _, ok := response.Filters[name]; !ok {
+			page.Unsupported = append(page.Unsupported, name)
+		}
+	}
+	if len(page.Unsupported) == 0 {
+		return page, nil, nil
+	}
+	c.logger.Debug("Server ignored task query parameters, applying them locally", page.Unsupported)
+
+	// Filtering twice is harmless, so every filter is re-applied
+	filtered := page.Tasks[:0]
+	for _, t := range page.Tasks {
+		if opts.matches(t) {
+			filtered = append(filtered, t)
+		}
+	}
+	page.Tasks = filtered
+
+	if !contains(page.Unsupported, "sort") && !contains(page.Unsupported, "limit") {
+		return page, nil, nil
+	}
+	sortTasks(page.Tasks, opts.Sort)
+
+	if !contains(page.Unsupported, "limit") {
+		return page, nil, nil
+	}
+	offset, err := decodeLocalCursor(opts.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	all := page.Tasks
+	page.Tasks, page.NextCursor = paginate(all, offset, opts.Limit)
+	var rest []Task
+	if page.NextCursor != "" {
+		rest = all[offset+len(page.Tasks):]
+	}
+	return page, rest, nil
+}
+
+// ListTasksIter iterates over every task matching opts, fetching pages of
+// opts.Limit tasks as needed. When the server does not paginate, the
+// listing is downloaded once.
+func (c *Client) ListTasksIter(ctx context.Context, opts ListTasksOptions) iter.Seq2[Task, error] {
+	return func(yield func(Task, error) bool) {
+		for {
+			page, rest, err := c.listTasks(ctx, opts)
+			if err != nil {
+				yield(Task{}, err)
+				return
+			}
+			for _, t := range page.Tasks {
+				if !yield(t, nil) {
+					return
+				}
+			}
+			// The client paginated, so the remaining pages are already here
+			if contains(page.Unsupported, "limit") {
+				for _, t := range rest {
+					if !yield(t, nil) {
+						return
+					}
+				}
+				return
+			}
+			if page.NextCursor == "" || page.NextCursor == opts.Cursor {
+				return
+			}
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
+
+// matches reports whether a task passes every filter in o
+func (o ListTasksOptions) matches(t Task) bool {
+	if o.Status != "" && !strings.EqualFold(t.Status, o.Status) {
+		return false
+	}
+	for _, want := range o.Tags {
+		found := false
+		for _, skill := range t.Skills() {
+			if strings.EqualFold(skill, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if o.MinBudget > 0 && t.Budget < o.MinBudget {
+		return false
+	}
+	if o.MaxBudget > 0 && t.Budget > o.MaxBudget {
+		return false
+	}
+	currency := t.Currency
+	if currency == "" {
+		currency = "USDC" // the API prices every task in USDC and leaves it out
+	}
+	if o.Currency != "" && !strings.EqualFold(currency, o.Currency) {
+		return false
+	}
+	if o.PostedBy != "" && t.PosterID != o.PostedBy {
+		return false
+	}
+	return true
+}
+
+// sortTasks orders tasks in place
+func sortTasks(tasks []Task, by string) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		switch by {
+		case SortOldest:
+			return tasks[i].CreatedAt.Before(tasks[j].CreatedAt.Time)
+		case SortBudgetHigh:
+			return tasks[i].Budget > tasks[j].Budget
+		case SortBudgetLow:
+			return tasks[i].Budget < tasks[j].Budget
+		default:
+			return tasks[i].CreatedAt.After(tasks[j].CreatedAt.Time)
+		}
+	})
+}
+
+// localCursorPrefix marks cursors made by the client when the server
+// does not paginate
+const localCursorPrefix = "offset:"
+
+func decodeLocalCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil && strings.HasPrefix(string(data), localCursorPrefix) {
+		offset, err := strconv.Atoi(strings.TrimPrefix(string(data), localCursorPrefix))
+		if err == nil && offset >= 0 {
+			return offset, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid cursor %q", cursor)
+}
+
+func encodeLocalCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(localCursorPrefix + strconv.Itoa(offset)))
+}
+
+// paginate returns limit tasks from offset, and the cursor of the next page
+func paginate(tasks []Task, offset, limit int) ([]Task, string) {
+	if offset >= len(tasks) {
+		return []Task{}, ""
+	}
+	tasks = tasks[offset:]
+	if limit <= 0 || len(tasks) <= limit {
+		return tasks, ""
+	}
+	return tasks[:limit], encodeLocalCursor(offset + limit)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package gigclaw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// jan returns midnight UTC on the given day of January 2026
+func jan(day int) Timestamp {
+	return Timestamp{time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)}
+}
+
+var queryTasks = []Task{
+	{ID: "a", Budget: 10, Currency: "USDC", Status: "posted", Tags: []string{"rust"}, CreatedAt: jan(1)},
+	{ID: "b", Budget: 50, Currency: "USDC", Status: "posted", Tags: []string{"rust", "audit"}, CreatedAt: jan(2)},
+	{ID: "c", Budget: 80, Currency: "SOL", Status: "posted", Tags: []string{"audit"}, CreatedAt: jan(3)},
+	{ID: "d", Budget: 30, Currency: "USDC", Status: "verified", Tags: []string{"rust"}, CreatedAt: jan(4)},
+}
+
+func taskIDs(tasks []Task) []string {
+	ids := []string{}
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// legacyServer ignores every query parameter, like the current API
+func legacyServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ListTasksResponse{Tasks: queryTasks})
+	}))
+}
+
+func TestListTasksPageFiltersLocally(t *testing.T) {
+	srv := legacyServer(t)
+	defer srv.Close()
+	client, _ := newTestClient(t, srv, testPolicy)
+
+	page, err := client.ListTasksPage(context.Background(), ListTasksOptions{
+		Status:    "posted",
+		Tags:      []string{"RUST"},
+		MinBudget: 20,
+		Sort:      SortBudgetLow,
+	})
+	if err != nil {
+		t.Fatalf("ListTasksPage: %v", err)
+	}
+	if got := taskIDs(page.Tasks); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("tasks = %v, want [b]", got)
+	}
+	want := []string{"minBudget", "sort", "status", "tag"}
+	if !reflect.DeepEqual(page.Unsupported, want) {
+		t.Errorf("Unsupported = %v, want %v", page.Unsupported, want)
+	}
+}
+
+func TestListTasksIterPagesLocally(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(ListTasksResponse{Tasks: queryTasks})
+	}))
+	defer srv.Close()
+	client, _ := newTestClient(t, srv, testPolicy)
+
+	var got []string
+	for task, err := range client.ListTasksIter(context.Background(), ListTasksOptions{Limit: 3, Sort: SortOldest}) {
+		if err != nil {
+			t.Fatalf("ListTasksIter: %v", err)
+		}
+		got = append(got, task.ID)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b", "c", "d"}) {
+		t.Errorf("tasks = %v, want [a b c d]", got)
+	}
+	if requests != 1 {
+		t.Errorf("listing downloaded %d times, want once", requests)
+	}
+}
+
+func TestListTasksPageFiltersServerTasks(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/tasks", fixture(t, "tasks", "list"))
+
+	page, err := api.client().ListTasksPage(context.Background(), ListTasksOptions{
+		Tags:     []string{"Audit"},
+		Currency: "USDC",
+	})
+	if err != nil {
+		t.Fatalf("ListTasksPage: %v", err)
+	}
+	if got := taskIDs(page.Tasks); !reflect.DeepEqual(got, []string{testTaskID}) {
+		t.Errorf("tasks = %v, want [%s]", got, testTaskID)
+	}
+}
+
+func TestListTasksPageServerSide(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		resp := ListTasksResponse{
+			Tasks:   queryTasks[:2],
+			Filters: map[string]interface{}{"currency": "USDC", "limit": 2},
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			resp.NextCursor = "page2"
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	client, _ := newTestClient(t, srv, testPolicy)
+
+	page, err := client.ListTasksPage(context.Background(), ListTasksOptions{Currency: "USDC", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListTasksPage: %v", err)
+	}
+	if len(page.Unsupported) != 0 || page.NextCursor != "page2" {
+		t.Errorf("page = %+v, want server cursor and no unsupported filters", page)
+	}
+	if queries[0] != "currency=USDC&limit=2" {
+		t.Errorf("query = %q", queries[0])
+	}
+
+	page, err = client.ListTasksPage(context.Background(), ListTasksOptions{Currency: "USDC", Limit: 2, Cursor: "page2"})
+	if err != nil || page.NextCursor != "" {
+		t.Fatalf("second page = %+v, %v", page, err)
+	}
+}
+
+func TestListTasksPageRejectsBadCursor(t *testing.T) {
+	srv := legacyServer(t)
+	defer srv.Close()
+	client, _ := newTestClient(t, srv, testPolicy)
+
+	if _, err := client.ListTasksPage(context.Background(), ListTasksOptions{Limit: 2, Cursor: "bogus"}); err == nil {
+		t.Error("ListTasksPage accepted an invalid cursor")
+	}
+}
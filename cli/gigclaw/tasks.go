@@ -0,0 +1,223 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// BlockchainStatus represents the blockchain status of a task
+type BlockchainStatus struct {
+	Status    string `json:"status"` // pending, confirmed, failed
+	Signature string `json:"signature"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Task represents a gig task with blockchain info
+type Task struct {
+	ID               string            `json:"id"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	Budget           float64           `json:"budget"`
+	Currency         string            `json:"currency"`
+	Status           string            `json:"status"`
+	Tags             []string          `json:"tags"`
+	RequiredSkills   []string          `json:"requiredSkills,omitempty"`
+	CreatedAt        Timestamp         `json:"createdAt"`
+	Deadline         Timestamp         `json:"deadline"`
+	PosterID         string            `json:"posterId,omitempty"`
+	AssignedAgent    string            `json:"assignedAgent,omitempty"`
+	DeliveryURL      string            `json:"deliveryUrl,omitempty"`
+	Signature        string            `json:"signature,omitempty"` // task creation transaction
+	Bids             []Bid             `json:"bids,omitempty"`
+	AcceptedBid      *Bid              `json:"acceptedBid,omitempty"`
+	BlockchainStatus *BlockchainStatus `json:"blockchain,omitempty"`
+}
+
+// Skills returns the skills a task asks for: its required skills, or its
+// tags when it has none
+func (t Task) Skills() []string {
+	if len(t.RequiredSkills) > 0 {
+		return t.RequiredSkills
+	}
+	return t.Tags
+}
+
+// Bid represents a task bid
+type Bid struct {
+	ID        string    `json:"id"`
+	AgentID   string    `json:"agentId"`
+	Amount    float64   `json:"amount"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status"`
+	Accepted  bool      `json:"accepted,omitempty"`
+	CreatedAt Timestamp `json:"createdAt"`
+}
+
+// ListTasksResponse represents the API response for listing tasks
+type ListTasksResponse struct {
+	Tasks      []Task                 `json:"tasks"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+	Filters    map[string]interface{} `json:"filters,omitempty"` // query parameters the server applied
+}
+
+// CreateTaskRequest holds the fields of a new task
+type CreateTaskRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Budget      float64  `json:"budget"`
+	Currency    string   `json:"currency"`
+	Tags        []string `json:"tags"`
+	// Optional; the task's tags stand in for required skills when empty.
+	// The API takes 1 to 10.
+	RequiredSkills []string  `json:"requiredSkills,omitempty"`
+	PosterID       string    `json:"posterId,omitempty"`
+	Deadline       Timestamp `json:"deadline"` // required
+}
+
+// CreateTaskResponse represents the API response for creating a task
+type CreateTaskResponse struct {
+	Task       Task              `json:"task"`
+	Blockchain *BlockchainStatus `json:"blockchain,omitempty"`
+}
+
+// ListTasks retrieves all tasks
+func (c *Client) ListTasks(ctx context.Context) ([]Task, error) {
+	var response ListTasksResponse
+	if err := c.do(ctx, "list tasks", http.MethodGet, "/api/tasks", nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Tasks, nil
+}
+
+// GetTask retrieves a single task by ID
+func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	var task Task
+	path := fmt.Sprintf("/api/tasks/%s", url.PathEscape(taskID))
+	if err := c.do(ctx, "get task", http.MethodGet, path, nil, &task, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// CreateTask creates a new task, returning it with its blockchain status
+func (c *Client) CreateTask(ctx context.Context, req CreateTaskRequest, opts ...RequestOption) (*Task, *BlockchainStatus, error) {
+	if req.Tags == nil {
+		req.Tags = []string{}
+	}
+	if len(req.RequiredSkills) == 0 {
+		req.RequiredSkills = req.Tags
+	}
+
+	var response CreateTaskResponse
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "create task", http.MethodPost, "/api/tasks", header, req, &response, http.StatusCreated); err != nil {
+		return nil, nil, err
+	}
+	return &response.Task, response.Blockchain, nil
+}
+
+// PlaceBid places a bid on a task
+func (c *Client) PlaceBid(ctx context.Context, taskID string, amount float64, message string, opts ...RequestOption) (*Bid, error) {
+	payload := map[string]interface{}{
+		"amount":  amount,
+		"message": message,
+	}
+	return c.placeBid(ctx, taskID, payload, opts)
+}
+
+// PlaceAgentBid places a bid on a task on behalf of agentID, e.g. for
+// terms agreed in a negotiation
+func (c *Client) PlaceAgentBid(ctx context.Context, taskID, agentID string, amount float64, message string, opts ...RequestOption) (*Bid, error) {
+	payload := map[string]interface{}{
+		"agentId": agentID,
+		"amount":  amount,
+		"message": message,
+	}
+	return c.placeBid(ctx, taskID, payload, opts)
+}
+
+// PlaceBidResponse represents the API response for placing a bid
+type PlaceBidResponse struct {
+	Message string `json:"message"`
+	Bid     Bid    `json:"bid"`
+}
+
+// placeBid posts a bid to /api/tasks/:id/bid
+func (c *Client) placeBid(ctx context.Context, taskID string, payload interface{}, opts []RequestOption) (*Bid, error) {
+	var response PlaceBidResponse
+	path := fmt.Sprintf("/api/tasks/%s/bid", url.PathEscape(taskID))
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "place bid", http.MethodPost, path, header, payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response.Bid, nil
+}
+
+// AcceptBid accepts a bid on a task
+func (c *Client) AcceptBid(ctx context.Context, taskID, bidID string, opts ...RequestOption) error {
+	payload := map[string]interface{}{
+		"bidId": bidID,
+	}
+
+	path := fmt.Sprintf("/api/tasks/%s/accept", url.PathEscape(taskID))
+	header := idempotencyHeader(opts)
+	return c.doWithHeader(ctx, "accept bid", http.MethodPost, path, header, payload, nil, http.StatusOK)
+}
+
+// TaskUpdateResponse represents the API response for a task state change
+type TaskUpdateResponse struct {
+	Message    string            `json:"message"`
+	Task       Task              `json:"task"`
+	Blockchain *BlockchainStatus `json:"blockchain,omitempty"` // cancel only
+}
+
+// CompleteTask delivers the work for a task assigned to agentID
+func (c *Client) CompleteTask(ctx context.Context, taskID, agentID, deliveryURL string, opts ...RequestOption) (*Task, error) {
+	payload := map[string]interface{}{
+		"agentId":     agentID,
+		"deliveryUrl": deliveryURL,
+	}
+	return c.updateTask(ctx, "complete task", taskID, "complete", payload, opts)
+}
+
+// VerifyTask approves the delivered work, releasing the escrowed payment
+// to the agent
+func (c *Client) VerifyTask(ctx context.Context, taskID string, opts ...RequestOption) (*Task, error) {
+	return c.updateTask(ctx, "verify task", taskID, "verify", map[string]interface{}{}, opts)
+}
+
+// CancelTask cancels a task posted by posterID that is still open for
+// bids, refunding any escrowed funds. It returns the status of the refund
+// on chain, which is "skipped" for tasks that were never on chain.
+func (c *Client) CancelTask(ctx context.Context, taskID, posterID string, opts ...RequestOption) (*Task, *BlockchainStatus, error) {
+	payload := map[string]interface{}{
+		"posterId": posterID,
+	}
+	response, err := c.postTaskAction(ctx, "cancel task", taskID, "cancel", payload, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &response.Task, response.Blockchain, nil
+}
+
+// updateTask posts a lifecycle action to /api/tasks/:id/<action>
+func (c *Client) updateTask(ctx context.Context, op, taskID, action string, payload interface{}, opts []RequestOption) (*Task, error) {
+	response, err := c.postTaskAction(ctx, op, taskID, action, payload, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Task, nil
+}
+
+// postTaskAction posts a lifecycle action, returning the whole response
+func (c *Client) postTaskAction(ctx context.Context, op, taskID, action string, payload interface{}, opts []RequestOption) (*TaskUpdateResponse, error) {
+	var response TaskUpdateResponse
+	path := fmt.Sprintf("/api/tasks/%s/%s", url.PathEscape(taskID), action)
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, op, http.MethodPost, path, header, payload, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,224 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTaskID = "taskmk3b9x2qa1b2"
+
+func TestListTasks(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/tasks", fixture(t, "tasks", "list"))
+	client := api.client()
+
+	tasks, err := client.ListTasks(context.Background())
+	if err != nil {
+		t.Fatalf("ListTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != testTaskID || len(tasks[0].Bids) != 1 {
+		t.Fatalf("tasks = %+v", tasks)
+	}
+	if want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC); !tasks[0].CreatedAt.Equal(want) {
+		t.Errorf("createdAt = %v, want %v", tasks[0].CreatedAt, want)
+	}
+
+	// Tasks read from chain carry no timestamps
+	api.on("GET /api/tasks", fixture(t, "tasks", "list_chain"))
+	tasks, err = client.ListTasks(context.Background())
+	if err != nil {
+		t.Fatalf("ListTasks from chain: %v", err)
+	}
+	if len(tasks) != 1 || !tasks[0].CreatedAt.IsZero() {
+		t.Errorf("chain tasks = %+v", tasks)
+	}
+}
+
+func TestGetTask(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/tasks/"+testTaskID, fixture(t, "tasks", "get"))
+	api.on("GET /api/tasks/taskmissing", fixture(t, "tasks", "get_missing"))
+	client := api.client()
+
+	task, err := client.GetTask(context.Background(), testTaskID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if task.AssignedAgent != "agent-7" || task.AcceptedBid == nil || len(task.Bids) != 2 {
+		t.Fatalf("task = %+v", task)
+	}
+	bid := task.Bids[0]
+	if !bid.Accepted || bid.AgentID != "agent-7" || !bid.CreatedAt.Equal(time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("bid = %+v", bid)
+	}
+	if task.Bids[1].Accepted || task.AcceptedBid.CreatedAt.IsZero() {
+		t.Errorf("bids = %+v, accepted %+v", task.Bids, task.AcceptedBid)
+	}
+
+	_, err = client.GetTask(context.Background(), "taskmissing")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestCreateTask(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/tasks", fixture(t, "tasks", "create"))
+	client := api.client()
+
+	task, chain, err := client.CreateTask(context.Background(), CreateTaskRequest{
+		Title: "Audit token program", Description: "Review the escrow program for reentrancy",
+		Budget: 150, Currency: "USDC", RequiredSkills: []string{"rust", "audit"}, PosterID: "alice",
+		Deadline: Timestamp{time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if task.ID != testTaskID || task.CreatedAt.IsZero() || chain == nil || chain.Status != "confirmed" {
+		t.Errorf("task = %+v, chain = %+v", task, chain)
+	}
+	req := api.last()
+	if req.Header.Get("Idempotency-Key") != "k1" || req.Body["posterId"] != "alice" {
+		t.Errorf("request = %+v", req)
+	}
+	if tags, ok := req.Body["tags"].([]interface{}); !ok || len(tags) != 0 {
+		t.Errorf("tags = %v, want []", req.Body["tags"])
+	}
+	if req.Body["deadline"] != "2026-02-01T00:00:00Z" {
+		t.Errorf("deadline = %v, want 2026-02-01T00:00:00Z", req.Body["deadline"])
+	}
+
+	// Tags stand in for missing required skills
+	_, _, err = client.CreateTask(context.Background(), CreateTaskRequest{
+		Title: "Audit token program", Description: "Review the escrow program for reentrancy",
+		Budget: 150, Tags: []string{"rust"}, PosterID: "alice",
+		Deadline: Timestamp{time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("CreateTask with tags: %v", err)
+	}
+	if skills, ok := api.last().Body["requiredSkills"].([]interface{}); !ok || len(skills) != 1 || skills[0] != "rust" {
+		t.Errorf("requiredSkills = %v, want [rust]", api.last().Body["requiredSkills"])
+	}
+
+	api.on("POST /api/tasks", fixture(t, "tasks", "create_invalid"))
+	_, _, err = client.CreateTask(context.Background(), CreateTaskRequest{Title: "Fix"})
+	apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+	if !strings.Contains(apiErr.Error(), "Deadline must be valid ISO8601 date") {
+		t.Errorf("err = %v, want the missing deadline reported", apiErr)
+	}
+}
+
+func TestPlaceBid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/tasks/"+testTaskID+"/bid", fixture(t, "tasks", "bid"))
+	client := api.client()
+
+	bid, err := client.PlaceAgentBid(context.Background(), testTaskID, "agent-7", 135, "Can start today", IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("PlaceAgentBid: %v", err)
+	}
+	if bid.ID != "bidk3b9x2q1w" || bid.AgentID != "agent-7" || bid.Amount != 135 || bid.CreatedAt.IsZero() {
+		t.Errorf("bid = %+v", bid)
+	}
+	req := api.last()
+	if req.Body["agentId"] != "agent-7" || req.Body["amount"] != float64(135) || req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("request = %+v", req)
+	}
+
+	if _, err := client.PlaceBid(context.Background(), testTaskID, 135, ""); err != nil {
+		t.Fatalf("PlaceBid: %v", err)
+	}
+	if _, ok := api.last().Body["agentId"]; ok {
+		t.Errorf("PlaceBid sent an agent ID: %v", api.last().Body)
+	}
+
+	api.on("POST /api/tasks/"+testTaskID+"/bid", fixture(t, "tasks", "bid_closed"))
+	_, err = client.PlaceAgentBid(context.Background(), testTaskID, "agent-7", 135, "")
+	if apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrBadRequest); !apiErr.Rejected() {
+		t.Errorf("bid on a closed task not rejected: %v", apiErr)
+	}
+}
+
+func TestAcceptBid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/tasks/"+testTaskID+"/accept", fixture(t, "tasks", "accept"))
+	client := api.client()
+
+	if err := client.AcceptBid(context.Background(), testTaskID, "bidk3b9x2q1w", IdempotencyKey("k1")); err != nil {
+		t.Fatalf("AcceptBid: %v", err)
+	}
+	if req := api.last(); req.Body["bidId"] != "bidk3b9x2q1w" || req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("request = %+v", req)
+	}
+
+	api.on("POST /api/tasks/"+testTaskID+"/accept", fixture(t, "tasks", "accept_missing_bid"))
+	err := client.AcceptBid(context.Background(), testTaskID, "bidmissing")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestCompleteTask(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/tasks/"+testTaskID+"/complete", fixture(t, "tasks", "complete"))
+	client := api.client()
+
+	task, err := client.CompleteTask(context.Background(), testTaskID, "agent-7", "https://example.com/pr/1")
+	if err != nil {
+		t.Fatalf("CompleteTask: %v", err)
+	}
+	if task.Status != "completed" || task.DeliveryURL != "https://example.com/pr/1" {
+		t.Errorf("task = %+v", task)
+	}
+	if body := api.last().Body; body["agentId"] != "agent-7" || body["deliveryUrl"] != "https://example.com/pr/1" {
+		t.Errorf("body = %v", body)
+	}
+
+	api.on("POST /api/tasks/"+testTaskID+"/complete", fixture(t, "tasks", "complete_not_assigned"))
+	_, err = client.CompleteTask(context.Background(), testTaskID, "agent-9", "https://example.com/pr/2")
+	wantAPIError(t, err, http.StatusForbidden, ErrForbidden)
+}
+
+func TestVerifyTask(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/tasks/"+testTaskID+"/verify", fixture(t, "tasks", "verify"))
+
+	task, err := api.client().VerifyTask(context.Background(), testTaskID)
+	if err != nil {
+		t.Fatalf("VerifyTask: %v", err)
+	}
+	if task.Status != "verified" || task.AcceptedBid == nil || task.AcceptedBid.Amount != 135 {
+		t.Errorf("task = %+v", task)
+	}
+}
+
+func TestCancelTask(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/tasks/"+testTaskID+"/cancel", fixture(t, "tasks", "cancel"))
+	client := api.client()
+
+	task, chain, err := client.CancelTask(context.Background(), testTaskID, "alice", IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("CancelTask: %v", err)
+	}
+	if task.Status != "cancelled" || chain == nil || chain.Status != "skipped" {
+		t.Errorf("task = %+v, chain = %+v", task, chain)
+	}
+	if req := api.last(); req.Body["posterId"] != "alice" || req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("request = %+v", req)
+	}
+
+	tests := []struct {
+		fixture  string
+		status   int
+		sentinel error
+	}{
+		{"cancel_not_poster", http.StatusForbidden, ErrForbidden},
+		{"cancel_assigned", http.StatusBadRequest, ErrBadRequest},
+	}
+	for _, tt := range tests {
+		api.on("POST /api/tasks/"+testTaskID+"/cancel", fixture(t, "tasks", tt.fixture))
+		_, _, err := client.CancelTask(context.Background(), testTaskID, "bob")
+		wantAPIError(t, err, tt.status, tt.sentinel)
+	}
+}
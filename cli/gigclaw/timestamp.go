@@ -0,0 +1,56 @@
+package gigclaw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timestamp is a point in time sent by the API either as milliseconds
+// since the Unix epoch or as an RFC 3339 string. It encodes as RFC 3339,
+// or null when zero.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON accepts a number of milliseconds, a string or null
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			t.Time = time.Time{}
+			return nil
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp %q: %w", s, err)
+		}
+		t.Time = parsed
+		return nil
+	}
+
+	var ms float64
+	if err := json.Unmarshal(data, &ms); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+	t.Time = time.UnixMilli(int64(ms))
+	return nil
+}
+
+// MarshalJSON encodes the time as RFC 3339, or null when zero
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,45 @@
+package gigclaw
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`1767323045000`, want},
+		{`"2026-01-02T03:04:05Z"`, want},
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, ts.Time, tt.want)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ts); err == nil {
+		t.Error("Unmarshal(\"yesterday\") succeeded, want error")
+	}
+}
+
+func TestTimestampMarshal(t *testing.T) {
+	data, _ := json.Marshal(struct {
+		A Timestamp `json:"a"`
+		B Timestamp `json:"b"`
+	}{A: Timestamp{time.UnixMilli(1767323045000)}})
+	if string(data) != `{"a":"2026-01-02T03:04:05Z","b":null}` {
+		t.Errorf("Marshal = %s", data)
+	}
+}
@@ -0,0 +1,37 @@
+package gigclaw
+
+import (
+	"math"
+	"strings"
+)
+
+// USDCDecimals is the number of decimal places of the USDC token; one
+// USDC is 1,000,000 base units
+const USDCDecimals = 6
+
+// currencyDecimals maps currencies to the decimal places of their
+// on-chain base unit
+var currencyDecimals = map[string]int{
+	"USDC": USDCDecimals,
+	"SOL":  9, // lamports
+}
+
+// CurrencyDecimals returns the decimal places of a currency's base unit.
+// Unknown currencies are treated like USDC.
+func CurrencyDecimals(currency string) int {
+	if d, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return d
+	}
+	return USDCDecimals
+}
+
+// ToBaseUnits converts an amount to the currency's on-chain base units,
+// rounding to the nearest unit
+func ToBaseUnits(amount float64, currency string) int64 {
+	return int64(math.Round(amount * math.Pow10(CurrencyDecimals(currency))))
+}
+
+// FromBaseUnits converts on-chain base units to an amount of the currency
+func FromBaseUnits(units int64, currency string) float64 {
+	return float64(units) / math.Pow10(CurrencyDecimals(currency))
+}
@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// Event names used by the API documentation for the bid events. The API
+// sends gigclaw.WebhookTaskBid and gigclaw.WebhookTaskAssigned; Parse
+// decodes both spellings into the same types.
+const (
+	EventBidPlaced   = "bid.placed"
+	EventBidAccepted = "bid.accepted"
+)
+
+// Delivery is a verified webhook delivery
+type Delivery struct {
+	Event     string            `json:"event"`
+	ID        string            `json:"deliveryId"` // stable across retries; empty on test deliveries
+	Attempt   int               `json:"-"`          // 1-3, from the attempt header; 0 on test deliveries
+	Timestamp gigclaw.Timestamp `json:"timestamp"`
+	Payload   json.RawMessage   `json:"payload"`
+
+	// Data is the payload decoded into the event's type, e.g. *TaskCreated,
+	// or nil for events this package does not know
+	Data interface{} `json:"-"`
+}
+
+// TaskCreated is the payload of task.created
+type TaskCreated struct {
+	TaskID         string   `json:"taskId"`
+	Title          string   `json:"title"`
+	Budget         float64  `json:"budget"`
+	PosterID       string   `json:"posterId"`
+	RequiredSkills []string `json:"requiredSkills"`
+}
+
+// BidPlaced is the payload of task.bid
+type BidPlaced struct {
+	TaskID  string  `json:"taskId"`
+	BidID   string  `json:"bidId"`
+	AgentID string  `json:"agentId"`
+	Amount  float64 `json:"amount"`
+	Message string  `json:"message,omitempty"`
+}
+
+// BidAccepted is the payload of task.assigned
+type BidAccepted struct {
+	TaskID   string  `json:"taskId"`
+	BidID    string  `json:"bidId"`
+	AgentID  string  `json:"agentId"` // the assigned agent
+	PosterID string  `json:"posterId,omitempty"`
+	Amount   float64 `json:"amount,omitempty"`
+}
+
+// TaskCompleted is the payload of task.completed
+type TaskCompleted struct {
+	TaskID  string `json:"taskId"`
+	AgentID string `json:"agentId"`
+}
+
+// TaskVerified is the payload of task.verified
+type TaskVerified struct {
+	TaskID   string `json:"taskId"`
+	AgentID  string `json:"agentId"`
+	PosterID string `json:"posterId,omitempty"`
+}
+
+// TaskCancelled is the payload of task.cancelled
+type TaskCancelled struct {
+	TaskID   string `json:"taskId"`
+	Reason   string `json:"reason"` // e.g. deadline_expired, stale_no_bids
+	PosterID string `json:"posterId"`
+}
+
+// PaymentReleased is the payload of payment.released
+type PaymentReleased struct {
+	TaskID    string  `json:"taskId"`
+	AgentID   string  `json:"agentId"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency,omitempty"`
+	Signature string  `json:"signature,omitempty"` // the release transaction
+}
+
+// ReputationUpdated is the payload of reputation.updated
+type ReputationUpdated struct {
+	AgentID             string  `json:"agentId"`
+	BaseReputation      float64 `json:"baseReputation"`
+	EffectiveReputation float64 `json:"effectiveReputation"`
+	StreakDays          int     `json:"streakDays,omitempty"`
+}
+
+// Test is the payload of a test delivery
+type Test struct {
+	Message   string `json:"message"`
+	WebhookID string `json:"webhookId"`
+	AgentID   string `json:"agentId"`
+}
+
+// newPayload returns a pointer to the payload type of event, or nil
+func newPayload(event string) interface{} {
+	switch strings.ToLower(event) {
+	case gigclaw.WebhookTaskCreated:
+		return &TaskCreated{}
+	case gigclaw.WebhookTaskBid, EventBidPlaced:
+		return &BidPlaced{}
+	case gigclaw.WebhookTaskAssigned, EventBidAccepted:
+		return &BidAccepted{}
+	case gigclaw.WebhookTaskCompleted:
+		return &TaskCompleted{}
+	case gigclaw.WebhookTaskVerified:
+		return &TaskVerified{}
+	case gigclaw.WebhookTaskCancelled:
+		return &TaskCancelled{}
+	case gigclaw.WebhookPaymentReleased:
+		return &PaymentReleased{}
+	case gigclaw.WebhookReputationUpdated:
+		return &ReputationUpdated{}
+	case gigclaw.WebhookTest:
+		return &Test{}
+	}
+	return nil
+}
+
+// Parse decodes a delivery body. It does not check the signature; see
+// VerifyDelivery.
+func Parse(body []byte) (*Delivery, error) {
+	var d Delivery
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, fmt.Errorf("webhook: failed to decode delivery: %w", err)
+	}
+	if d.Event == "" {
+		return nil, fmt.Errorf("webhook: delivery has no event")
+	}
+
+	if data := newPayload(d.Event); data != nil && len(d.Payload) > 0 {
+		if err := json.Unmarshal(d.Payload, data); err != nil {
+			return nil, fmt.Errorf("webhook: failed to decode %s payload: %w", d.Event, err)
+		}
+		d.Data = data
+	}
+	return &d, nil
+}
+
+// TaskID returns the task the delivery concerns, if any
+func (d *Delivery) TaskID() string {
+	var ref struct {
+		TaskID string `json:"taskId"`
+	}
+	json.Unmarshal(d.Payload, &ref)
+	return ref.TaskID
+}
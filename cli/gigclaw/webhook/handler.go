@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// MaxBodySize bounds the size of a delivery accepted by Verify
+const MaxBodySize = 1 << 20
+
+type contextKey struct{}
+
+// FromContext returns the delivery verified by Verify
+func FromContext(ctx context.Context) (*Delivery, bool) {
+	d, ok := ctx.Value(contextKey{}).(*Delivery)
+	return d, ok
+}
+
+// Verify returns middleware that passes only correctly signed deliveries
+// to next, with the decoded delivery in the request context (see
+// FromContext) and the raw body still readable.
+//
+// Other requests are answered directly: 405 for methods other than POST,
+// 413 for bodies over MaxBodySize, 401 for missing or invalid signatures
+// and 400 for bodies that are not deliveries.
+func Verify(next http.Handler, secrets ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+			}
+			return
+		}
+
+		if err := VerifyDelivery(body, r.Header.Get(gigclaw.SignatureHeader), secrets...); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		d, err := Parse(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Test deliveries carry their ID in the header only
+		if d.ID == "" {
+			d.ID = r.Header.Get(gigclaw.DeliveryHeader)
+		}
+		d.Attempt, _ = strconv.Atoi(r.Header.Get(gigclaw.AttemptHeader))
+
+		r = r.WithContext(context.WithValue(r.Context(), contextKey{}, d))
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
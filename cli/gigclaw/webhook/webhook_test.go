@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OmaClaw/gigclaw/cli/gigclaw"
+)
+
+// signatureVector is an entry of testdata/signatures.json, which the API's
+// tests check generateSignature against
+type signatureVector struct {
+	Name      string `json:"name"`
+	Secret    string `json:"secret"`
+	Event     string `json:"event"`
+	Body      string `json:"body"`
+	Signed    string `json:"signed"` // "payload" or "body"
+	Signature string `json:"signature"`
+	Valid     bool   `json:"valid"`
+}
+
+func loadVectors(t *testing.T) []signatureVector {
+	t.Helper()
+	data, err := os.ReadFile("testdata/signatures.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Vectors []signatureVector `json:"vectors"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	return file.Vectors
+}
+
+func TestSignatureVectors(t *testing.T) {
+	for _, v := range loadVectors(t) {
+		t.Run(v.Name, func(t *testing.T) {
+			err := VerifyDelivery([]byte(v.Body), v.Signature, v.Secret)
+			if (err == nil) != v.Valid {
+				t.Fatalf("VerifyDelivery = %v, want valid %v", err, v.Valid)
+			}
+			if !v.Valid {
+				return
+			}
+
+			signed := []byte(v.Body)
+			if v.Signed == "payload" {
+				d, err := Parse(signed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				signed = d.Payload
+			}
+			if got := Sign(signed, v.Secret); got != v.Signature {
+				t.Errorf("Sign = %s, want %s", got, v.Signature)
+			}
+			if err := VerifySignature(signed, v.Signature, v.Secret); err != nil {
+				t.Errorf("VerifySignature: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifySignatureErrors(t *testing.T) {
+	payload := []byte(`{"taskId":"7f3a"}`)
+	sig := Sign(payload, "s3cret")
+
+	tests := []struct {
+		name   string
+		header string
+		want   error
+	}{
+		{"valid", sig, nil},
+		{"missing", "", ErrMissingSignature},
+		{"no prefix", strings.TrimPrefix(sig, "sha256="), ErrInvalidSignature},
+		{"not hex", "sha256=zz", ErrInvalidSignature},
+		{"truncated", sig[:len(sig)-2], ErrInvalidSignature},
+		{"uppercase prefix", "SHA256=" + strings.TrimPrefix(sig, "sha256="), ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		if err := VerifySignature(payload, tt.header, "s3cret"); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// Re-encoding the payload changes the signed bytes
+	if err := VerifySignature([]byte(`{"taskId": "7f3a"}`), sig, "s3cret"); err == nil {
+		t.Error("reformatted payload verified")
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, v := range loadVectors(t) {
+		if !v.Valid {
+			continue
+		}
+		d, err := Parse([]byte(v.Body))
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		if d.Event != v.Event || d.Timestamp.IsZero() || d.Data == nil {
+			t.Errorf("%s: delivery = %+v", v.Name, d)
+		}
+	}
+
+	d, err := Parse([]byte(`{"event":"bid.accepted","payload":{"taskId":"7f3a","bidId":"b1","agentId":"agent-7"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := d.Data.(*BidAccepted); !ok || e.BidID != "b1" || d.TaskID() != "7f3a" {
+		t.Errorf("data = %#v", d.Data)
+	}
+
+	d, err = Parse([]byte(`{"event":"agent.renamed","payload":{"agentId":"agent-7"}}`))
+	if err != nil || d.Data != nil || string(d.Payload) != `{"agentId":"agent-7"}` {
+		t.Errorf("unknown event: delivery = %+v, err = %v", d, err)
+	}
+
+	if _, err := Parse([]byte(`{"payload":{}}`)); err == nil {
+		t.Error("delivery without event parsed")
+	}
+}
+
+func TestVerifyMiddleware(t *testing.T) {
+	var got *Delivery
+	var gotBody string
+	handler := Verify(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext(r.Context())
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	}), "old secret", "whsec_5f2b")
+
+	v := loadVectors(t)[0]
+	send := func(method, body, signature string) int {
+		req := httptest.NewRequest(method, "/hook", strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set(gigclaw.SignatureHeader, signature)
+		}
+		req.Header.Set(gigclaw.AttemptHeader, "2")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send(http.MethodPost, v.Body, v.Signature); code != http.StatusNoContent {
+		t.Fatalf("status = %d", code)
+	}
+	created, ok := got.Data.(*TaskCreated)
+	if !ok || created.Title != "Audit the escrow program" || len(created.RequiredSkills) != 2 {
+		t.Errorf("data = %#v", got.Data)
+	}
+	if got.ID == "" || got.Attempt != 2 || gotBody != v.Body {
+		t.Errorf("delivery = %+v, body = %q", got, gotBody)
+	}
+
+	got = nil
+	tests := []struct {
+		name, method, body, signature string
+		want                          int
+	}{
+		{"unsigned", http.MethodPost, v.Body, "", http.StatusUnauthorized},
+		{"forged", http.MethodPost, v.Body, Sign([]byte(v.Body), "guess"), http.StatusUnauthorized},
+		{"get", http.MethodGet, "", v.Signature, http.StatusMethodNotAllowed},
+		{"not a delivery", http.MethodPost, `[1]`, Sign([]byte(`[1]`), "whsec_5f2b"), http.StatusBadRequest},
+		{"too large", http.MethodPost, strings.Repeat(" ", MaxBodySize+1), v.Signature, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		if code := send(tt.method, tt.body, tt.signature); code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, code, tt.want)
+		}
+	}
+	if got != nil {
+		t.Errorf("rejected request reached the handler: %+v", got)
+	}
+}
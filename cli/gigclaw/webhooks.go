@@ -0,0 +1,152 @@
+package gigclaw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Webhook event types
+const (
+	WebhookTaskCreated       = "task.created"
+	WebhookTaskBid           = "task.bid"
+	WebhookTaskAssigned      = "task.assigned"
+	WebhookTaskCompleted     = "task.completed"
+	WebhookTaskVerified      = "task.verified"
+	WebhookTaskCancelled     = "task.cancelled"
+	WebhookPaymentReleased   = "payment.released"
+	WebhookReputationUpdated = "reputation.updated"
+	WebhookTest              = "test" // sent by TestWebhook only
+)
+
+// WebhookEvents are the event types a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookTaskCreated, WebhookTaskBid, WebhookTaskAssigned, WebhookTaskCompleted,
+	WebhookTaskVerified, WebhookTaskCancelled, WebhookPaymentReleased, WebhookReputationUpdated,
+}
+
+// Headers sent with every webhook delivery
+const (
+	SignatureHeader = "X-GigClaw-Signature" // sha256=<hex HMAC>
+	EventHeader     = "X-GigClaw-Event"
+	DeliveryHeader  = "X-GigClaw-Delivery"
+	AttemptHeader   = "X-GigClaw-Attempt" // 1-3; absent on test deliveries
+)
+
+// Webhook is a subscription delivering events to a URL
+type Webhook struct {
+	ID            string    `json:"id"`
+	URL           string    `json:"url"`
+	Events        []string  `json:"events"`
+	Active        bool      `json:"active"`
+	CreatedAt     Timestamp `json:"createdAt"`
+	LastDelivered Timestamp `json:"lastDelivered"`
+	FailureCount  int       `json:"failureCount"` // the server pauses a webhook after 10
+}
+
+// WebhookRegistration is a newly registered webhook with its signing
+// secret. The secret is only returned once.
+type WebhookRegistration struct {
+	WebhookID string   `json:"webhookId"`
+	Secret    string   `json:"secret"`
+	Events    []string `json:"events"`
+	URL       string   `json:"url"`
+}
+
+// WebhookDelivery is an attempt to deliver an event to a webhook
+type WebhookDelivery struct {
+	ID           string    `json:"id"` // sent as X-GigClaw-Delivery
+	WebhookID    string    `json:"webhookId"`
+	Event        string    `json:"event"`
+	URL          string    `json:"url"`
+	Status       string    `json:"status"` // success, failed or pending
+	StatusCode   int       `json:"statusCode,omitempty"`
+	ResponseBody string    `json:"responseBody,omitempty"`
+	Timestamp    Timestamp `json:"timestamp"`
+	RetryCount   int       `json:"retryCount"`
+}
+
+// WebhookTestResult is the outcome of a test delivery
+type WebhookTestResult struct {
+	Success      bool   `json:"success"`
+	StatusCode   int    `json:"statusCode,omitempty"` // the webhook URL's response
+	ResponseBody string `json:"responseBody,omitempty"`
+	Message      string `json:"message"`
+	Error        string `json:"error,omitempty"` // why the URL could not be reached
+}
+
+// RegisterWebhook subscribes url to events on behalf of agentID
+func (c *Client) RegisterWebhook(ctx context.Context, agentID, webhookURL string, events []string, opts ...RequestOption) (*WebhookRegistration, error) {
+	payload := map[string]interface{}{
+		"agentId": agentID,
+		"url":     webhookURL,
+		"events":  events,
+	}
+
+	var reg WebhookRegistration
+	header := idempotencyHeader(opts)
+	if err := c.doWithHeader(ctx, "register webhook", http.MethodPost, "/api/webhooks/register", header, payload, &reg, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &reg, nil
+}
+
+// ListWebhooks retrieves an agent's webhooks. Secrets are never listed.
+func (c *Client) ListWebhooks(ctx context.Context, agentID string) ([]Webhook, error) {
+	var response struct {
+		Webhooks []Webhook `json:"webhooks"`
+		Count    int       `json:"count"`
+	}
+	path := fmt.Sprintf("/api/webhooks/%s", url.PathEscape(agentID))
+	if err := c.do(ctx, "list webhooks", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Webhooks, nil
+}
+
+// ListWebhookDeliveries retrieves the last 50 deliveries to an agent's
+// webhooks, newest first
+func (c *Client) ListWebhookDeliveries(ctx context.Context, agentID string) ([]WebhookDelivery, error) {
+	var response struct {
+		Logs  []WebhookDelivery `json:"logs"`
+		Total int               `json:"total"`
+	}
+	path := fmt.Sprintf("/api/webhooks/%s/logs", url.PathEscape(agentID))
+	if err := c.do(ctx, "list webhook deliveries", http.MethodGet, path, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Logs, nil
+}
+
+// TestWebhook sends a signed test event to a webhook. A URL that cannot
+// be reached is reported in the result rather than as an error.
+func (c *Client) TestWebhook(ctx context.Context, agentID, webhookID string) (*WebhookTestResult, error) {
+	payload := map[string]interface{}{
+		"agentId":   agentID,
+		"webhookId": webhookID,
+	}
+
+	// The server answers 500 when the URL is unreachable
+	var result WebhookTestResult
+	if err := c.do(ctx, "test webhook", http.MethodPost, "/api/webhooks/test", payload, &result, http.StatusOK, http.StatusInternalServerError); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetWebhookActive pauses or resumes a webhook. Resuming resets its
+// failure count.
+func (c *Client) SetWebhookActive(ctx context.Context, agentID, webhookID string, active bool) error {
+	payload := map[string]interface{}{
+		"active": active,
+	}
+	path := fmt.Sprintf("/api/webhooks/%s/%s/status", url.PathEscape(agentID), url.PathEscape(webhookID))
+	return c.do(ctx, "update webhook", http.MethodPatch, path, payload, nil, http.StatusOK)
+}
+
+// DeleteWebhook removes a webhook
+func (c *Client) DeleteWebhook(ctx context.Context, agentID, webhookID string) error {
+	path := fmt.Sprintf("/api/webhooks/%s/%s", url.PathEscape(agentID), url.PathEscape(webhookID))
+	return c.do(ctx, "delete webhook", http.MethodDelete, path, nil, nil, http.StatusOK)
+}
@@ -0,0 +1,169 @@
+package gigclaw
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testWebhookID = "3f2b8c1e-9d4a-4e7b-a6c5-1f0e2d3c4b5a"
+
+func TestRegisterWebhook(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/webhooks/register", fixture(t, "webhooks", "register"))
+
+	events := []string{WebhookTaskBid, WebhookTaskAssigned}
+	reg, err := api.client().RegisterWebhook(context.Background(), "agent-7", "https://agent-7.example.com/hooks", events, IdempotencyKey("k1"))
+	if err != nil {
+		t.Fatalf("RegisterWebhook: %v", err)
+	}
+	if reg.WebhookID != testWebhookID || len(reg.Secret) != 64 || !reflect.DeepEqual(reg.Events, events) {
+		t.Errorf("registration = %+v", reg)
+	}
+	req := api.last()
+	sent, _ := req.Body["events"].([]interface{})
+	if req.Body["agentId"] != "agent-7" || req.Body["url"] != "https://agent-7.example.com/hooks" || len(sent) != 2 || sent[0] != "task.bid" {
+		t.Errorf("body = %v", req.Body)
+	}
+	if req.Header.Get("Idempotency-Key") != "k1" {
+		t.Errorf("header = %v", req.Header)
+	}
+}
+
+func TestRegisterWebhookInvalid(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/webhooks/register", fixture(t, "webhooks", "register_invalid"))
+
+	_, err := api.client().RegisterWebhook(context.Background(), "agent-7", "https://agent-7.example.com/hooks", []string{"task.bidded"})
+	apiErr := wantAPIError(t, err, http.StatusBadRequest, ErrValidation)
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "events[0]" || apiErr.Details[0].Message != "Invalid event type" {
+		t.Errorf("details = %+v", apiErr.Details)
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	tests := []struct {
+		fixture string
+		count   int
+	}{
+		{"list", 1},
+		{"list_empty", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("GET /api/webhooks/agent-7", fixture(t, "webhooks", tt.fixture))
+
+			hooks, err := api.client().ListWebhooks(context.Background(), "agent-7")
+			if err != nil {
+				t.Fatalf("ListWebhooks: %v", err)
+			}
+			if len(hooks) != tt.count {
+				t.Fatalf("webhooks = %+v", hooks)
+			}
+			if tt.count == 0 {
+				return
+			}
+			h := hooks[0]
+			if h.ID != testWebhookID || h.Active || h.FailureCount != 10 ||
+				!h.CreatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) ||
+				!h.LastDelivered.Equal(time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)) {
+				t.Errorf("webhook = %+v", h)
+			}
+		})
+	}
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("GET /api/webhooks/agent-7/logs", fixture(t, "webhooks", "logs"))
+
+	logs, err := api.client().ListWebhookDeliveries(context.Background(), "agent-7")
+	if err != nil {
+		t.Fatalf("ListWebhookDeliveries: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("logs = %+v", logs)
+	}
+	d := logs[0]
+	if d.WebhookID != testWebhookID || d.Event != WebhookTaskBid || d.Status != "failed" || d.StatusCode != 502 ||
+		d.RetryCount != 2 || d.Timestamp.IsZero() {
+		t.Errorf("delivery = %+v", d)
+	}
+}
+
+func TestTestWebhook(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		success    bool
+		statusCode int
+		reason     bool // the result explains why the URL was unreachable
+	}{
+		{"test", true, 204, false},
+		{"test_failed", false, 401, false},
+		{"test_unreachable", false, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newMockAPI(t)
+			api.on("POST /api/webhooks/test", fixture(t, "webhooks", tt.fixture))
+
+			result, err := api.client().TestWebhook(context.Background(), "agent-7", testWebhookID)
+			if err != nil {
+				t.Fatalf("TestWebhook: %v", err)
+			}
+			if result.Success != tt.success || result.StatusCode != tt.statusCode || (result.Error != "") != tt.reason {
+				t.Errorf("result = %+v", result)
+			}
+			if body := api.last().Body; body["agentId"] != "agent-7" || body["webhookId"] != testWebhookID {
+				t.Errorf("body = %v", body)
+			}
+		})
+	}
+}
+
+func TestTestWebhookMissing(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("POST /api/webhooks/test", fixture(t, "webhooks", "test_missing"))
+
+	_, err := api.client().TestWebhook(context.Background(), "agent-7", testWebhookID)
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestSetWebhookActive(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("PATCH /api/webhooks/agent-7/"+testWebhookID+"/status", fixture(t, "webhooks", "status"))
+	api.on("PATCH /api/webhooks/carol/"+testWebhookID+"/status", fixture(t, "webhooks", "status_missing"))
+	client := api.client()
+
+	for _, active := range []bool{true, false} {
+		if err := client.SetWebhookActive(context.Background(), "agent-7", testWebhookID, active); err != nil {
+			t.Fatalf("SetWebhookActive(%v): %v", active, err)
+		}
+		if body := api.last().Body; body["active"] != active {
+			t.Errorf("body = %v, want active %v", body, active)
+		}
+	}
+
+	err := client.SetWebhookActive(context.Background(), "carol", testWebhookID, true)
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	api := newMockAPI(t)
+	api.on("DELETE /api/webhooks/agent-7/"+testWebhookID, fixture(t, "webhooks", "delete"))
+	api.on("DELETE /api/webhooks/agent-7/gone", fixture(t, "webhooks", "delete_missing"))
+	client := api.client()
+
+	if err := client.DeleteWebhook(context.Background(), "agent-7", testWebhookID); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	if req := api.last(); req.Method != http.MethodDelete || req.Body != nil {
+		t.Errorf("request = %+v", req)
+	}
+
+	err := client.DeleteWebhook(context.Background(), "agent-7", "gone")
+	wantAPIError(t, err, http.StatusNotFound, ErrNotFound)
+}